@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"go.yaml.in/yaml/v3"
+
+	cbuilder "github.com/scribd/go-sdk/internal/pkg/configuration/builder"
+)
+
+// inspector collects the configurations built while loading the SDK
+// configuration.
+type inspector struct {
+	root string
+
+	mu    sync.Mutex
+	names []string
+	built map[string]cbuilder.Built
+}
+
+// entry is a configuration value with its source.
+type entry struct {
+	Value  any    `json:"value"`
+	Source string `json:"source"`
+}
+
+func newInspector(root string) *inspector {
+	return &inspector{
+		root:  root,
+		built: make(map[string]cbuilder.Built),
+	}
+}
+
+func (i *inspector) add(built cbuilder.Built) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if _, ok := i.built[built.Name]; !ok {
+		i.names = append(i.names, built.Name)
+	}
+	i.built[built.Name] = built
+}
+
+// entries returns the redacted entries of the configuration with the given
+// name, by key.
+func (i *inspector) entries(name string) (keys []string, entries map[string]entry) {
+	built := i.built[name]
+
+	keys = built.Config.AllKeys()
+	slices.Sort(keys)
+
+	entries = make(map[string]entry, len(keys))
+	for _, key := range keys {
+		source := built.Sources[key]
+		entries[key] = entry{
+			Value:  redact(key, built.Config.Get(key), source),
+			Source: i.sourceString(source),
+		}
+	}
+
+	return keys, entries
+}
+
+// sourceString returns the source, with the path of the files relative to
+// the application root.
+func (i *inspector) sourceString(source cbuilder.Source) string {
+	if source.Kind == cbuilder.SourceFile && i.root != "" {
+		if rel, err := filepath.Rel(i.root, source.Name); err == nil && !strings.HasPrefix(rel, "..") {
+			source.Name = rel
+		}
+	}
+
+	return source.String()
+}
+
+func (i *inspector) write(w io.Writer, format string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if format == formatJSON {
+		return i.writeJSON(w)
+	}
+
+	return i.writeYAML(w)
+}
+
+// writeJSON writes the configurations as a JSON object, where every value is
+// an object with the value and its source.
+func (i *inspector) writeJSON(w io.Writer) error {
+	out := make(map[string]any, len(i.names))
+
+	for _, name := range i.names {
+		tree := make(map[string]any)
+		keys, entries := i.entries(name)
+		for _, key := range keys {
+			setNested(tree, strings.Split(key, "."), entries[key])
+		}
+		out[name] = tree
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(out)
+}
+
+func setNested(tree map[string]any, path []string, value any) {
+	for _, name := range path[:len(path)-1] {
+		child, ok := tree[name].(map[string]any)
+		if !ok {
+			child = make(map[string]any)
+			tree[name] = child
+		}
+		tree = child
+	}
+
+	tree[path[len(path)-1]] = value
+}
+
+// writeYAML writes the configurations as a YAML document, where every value
+// is commented with its source.
+func (i *inspector) writeYAML(w io.Writer) error {
+	doc := &yaml.Node{Kind: yaml.MappingNode}
+
+	for _, name := range i.names {
+		tree := mappingNode(doc, name)
+
+		keys, entries := i.entries(name)
+		for _, key := range keys {
+			path := strings.Split(key, ".")
+
+			parent := tree
+			for _, name := range path[:len(path)-1] {
+				parent = mappingNode(parent, name)
+			}
+
+			value := &yaml.Node{}
+			if err := value.Encode(entries[key].Value); err != nil {
+				return fmt.Errorf("encoding %s.%s: %w", name, key, err)
+			}
+
+			keyNode := &yaml.Node{Kind: yaml.ScalarNode, Value: path[len(path)-1]}
+			if value.Kind == yaml.ScalarNode {
+				value.LineComment = entries[key].Source
+			} else {
+				keyNode.LineComment = entries[key].Source
+			}
+			parent.Content = append(parent.Content, keyNode, value)
+		}
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+// mappingNode returns the mapping of the given key in parent, adding it if
+// it does not exist.
+func mappingNode(parent *yaml.Node, key string) *yaml.Node {
+	for i := 0; i < len(parent.Content); i += 2 {
+		if parent.Content[i].Value == key {
+			return parent.Content[i+1]
+		}
+	}
+
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, child)
+
+	return child
+}
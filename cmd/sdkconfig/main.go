@@ -0,0 +1,171 @@
+/*
+Command sdkconfig prints the effective configuration of an application using
+the SDK, as loaded by configuration.NewConfig for a given ENV.
+
+Every value is annotated with its source: the configuration file, the ENV
+variable overriding it, or the SDK default. Secrets are redacted.
+
+Usage:
+
+	sdkconfig [flags] [subsystem ...]
+
+Without subsystems, every subsystem with a configuration file is loaded. With
+subsystems, such as `database logger`, only those are loaded and their
+configuration files are required.
+
+The flags are:
+
+	-env ENV
+		the ENV to load, instead of APP_ENV
+	-root DIR
+		the application root directory, with the config directory, instead
+		of APP_ROOT
+	-format yaml|json
+		the output format, yaml by default
+	-check
+		only check the configuration, exiting with status 1 when it cannot
+		be loaded or is not valid
+*/
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/scribd/go-sdk/internal/pkg/configuration/validation"
+	"github.com/scribd/go-sdk/pkg/configuration"
+)
+
+const (
+	exitOK      = 0
+	exitInvalid = 1
+	exitUsage   = 2
+
+	formatYAML = "yaml"
+	formatJSON = "json"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("sdkconfig", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: sdkconfig [flags] [subsystem ...]")
+		flags.PrintDefaults()
+	}
+
+	env := flags.String("env", os.Getenv("APP_ENV"), "the ENV to load, instead of APP_ENV")
+	root := flags.String("root", os.Getenv("APP_ROOT"), "the application root directory, instead of APP_ROOT")
+	format := flags.String("format", formatYAML, "the output format: yaml or json")
+	check := flags.Bool("check", false, "only check the configuration, exiting with status 1 when it is not valid")
+
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	if *format != formatYAML && *format != formatJSON {
+		fmt.Fprintf(stderr, "unknown format %q\n", *format)
+		return exitUsage
+	}
+
+	// The SDK packages read the ENV and the root directory from the ENV
+	// variables.
+	if err := setEnv("APP_ENV", *env); err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+	if err := setEnv("APP_ROOT", *root); err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	inspector := newInspector(*root)
+	_, err := configuration.NewConfig(append(options(flags.Args()), configuration.OnBuild(inspector.add))...)
+
+	if err != nil {
+		printErrors(stderr, err)
+	}
+
+	if *check {
+		if err != nil {
+			return exitInvalid
+		}
+
+		fmt.Fprintln(stdout, "configuration is valid")
+		return exitOK
+	}
+
+	if writeErr := inspector.write(stdout, *format); writeErr != nil {
+		fmt.Fprintln(stderr, writeErr)
+		return exitInvalid
+	}
+
+	if err != nil {
+		return exitInvalid
+	}
+
+	return exitOK
+}
+
+func setEnv(key, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	return os.Setenv(key, value)
+}
+
+// options loads the given subsystems, or any subsystem configured when
+// there are none.
+func options(args []string) []configuration.Option {
+	if len(args) == 0 {
+		return []configuration.Option{configuration.Optional(
+			configuration.App,
+			configuration.Database,
+			configuration.Instrumentation,
+			configuration.Logger,
+			configuration.Server,
+			configuration.Tracking,
+			configuration.PubSub,
+			configuration.Cache,
+			configuration.AWS,
+			configuration.Statsig,
+			configuration.Metrics,
+		)}
+	}
+
+	subsystems := make([]configuration.Subsystem, 0, len(args))
+	for _, arg := range args {
+		subsystems = append(subsystems, configuration.Subsystem(arg))
+	}
+
+	return []configuration.Option{configuration.With(subsystems...)}
+}
+
+func printErrors(w io.Writer, err error) {
+	var errs configuration.Errors
+	if !errors.As(err, &errs) {
+		fmt.Fprintln(w, err)
+		return
+	}
+
+	// The validation errors hold the path of the values, which starts with
+	// the name of the configuration.
+	for _, subsystemErr := range errs {
+		var fieldErrs validation.Errors
+		if errors.As(subsystemErr.Err, &fieldErrs) {
+			for _, fieldErr := range fieldErrs {
+				fmt.Fprintln(w, fieldErr)
+			}
+			continue
+		}
+
+		fmt.Fprintln(w, subsystemErr)
+	}
+}
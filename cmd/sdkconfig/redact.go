@@ -0,0 +1,102 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+
+	cbuilder "github.com/scribd/go-sdk/internal/pkg/configuration/builder"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveWords are the words of the configuration keys holding secrets,
+// such as "password", "secret_access_key" or "dsn".
+var sensitiveWords = map[string]bool{
+	"credentials": true,
+	"dsn":         true,
+	"key":         true,
+	"passphrase":  true,
+	"passwd":      true,
+	"password":    true,
+	"secret":      true,
+	"token":       true,
+}
+
+// redact returns the value to print for the key. The values resolved from
+// secret references and the values of sensitive keys are redacted, unless
+// they are empty; the passwords of the URLs are redacted everywhere.
+func redact(key string, value any, source cbuilder.Source) any {
+	if isEmpty(value) {
+		return value
+	}
+
+	if source.Secret || isSensitive(key) {
+		return redacted
+	}
+
+	switch v := value.(type) {
+	case string:
+		return redactURL(v)
+	case []string:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = redactURL(item)
+		}
+		return items
+	case []any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = redact(key, item, cbuilder.Source{})
+		}
+		return items
+	case map[string]any:
+		fields := make(map[string]any, len(v))
+		for k, field := range v {
+			fields[k] = redact(k, field, cbuilder.Source{})
+		}
+		return fields
+	}
+
+	return value
+}
+
+func isSensitive(key string) bool {
+	name := key[strings.LastIndex(key, ".")+1:]
+
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' }) {
+		if sensitiveWords[word] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isEmpty(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []any:
+		return len(v) == 0
+	case []string:
+		return len(v) == 0
+	}
+
+	return false
+}
+
+// redactURL redacts the password of the value if it is a URL with one.
+func redactURL(value string) string {
+	if !strings.Contains(value, "://") {
+		return value
+	}
+
+	u, err := url.Parse(value)
+	if err != nil {
+		return value
+	}
+
+	return u.Redacted()
+}
@@ -0,0 +1,116 @@
+package builder
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"go.yaml.in/yaml/v3"
+)
+
+// configExtensions are the extensions of the configuration files, in lookup
+// order. The format of a file is detected from its extension.
+var configExtensions = []string{".yml", ".yaml", ".json", ".toml"}
+
+// fileSystem is where the configuration files are read from. Paths are
+// native file paths.
+type fileSystem interface {
+	Stat(name string) (fs.FileInfo, error)
+	ReadDir(name string) ([]fs.DirEntry, error)
+	ReadFile(name string) ([]byte, error)
+	// Join joins path elements, and Abs makes a configuration path
+	// absolute, the way the file system expects them.
+	Join(elem ...string) string
+	Abs(name string) string
+	// Watchable reports whether the changes of the files can be watched.
+	Watchable() bool
+}
+
+// osFS is the file system of the operating system.
+type osFS struct{}
+
+func (osFS) Stat(name string) (fs.FileInfo, error)      { return os.Stat(name) }
+func (osFS) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(name) }
+func (osFS) ReadFile(name string) ([]byte, error)       { return os.ReadFile(name) }
+func (osFS) Join(elem ...string) string                 { return filepath.Join(elem...) }
+func (osFS) Watchable() bool                            { return true }
+
+func (osFS) Abs(name string) string {
+	if abs, err := filepath.Abs(name); err == nil {
+		return abs
+	}
+
+	return name
+}
+
+// ioFS adapts an fs.FS, such as an embed.FS. Its paths are slash separated
+// and relative to the root of the fs.FS.
+type ioFS struct {
+	fsys fs.FS
+}
+
+func (f ioFS) Stat(name string) (fs.FileInfo, error)      { return fs.Stat(f.fsys, f.Abs(name)) }
+func (f ioFS) ReadDir(name string) ([]fs.DirEntry, error) { return fs.ReadDir(f.fsys, f.Abs(name)) }
+func (f ioFS) ReadFile(name string) ([]byte, error)       { return fs.ReadFile(f.fsys, f.Abs(name)) }
+func (ioFS) Join(elem ...string) string                   { return path.Join(elem...) }
+func (ioFS) Watchable() bool                              { return false }
+
+func (ioFS) Abs(name string) string {
+	return path.Clean(strings.TrimPrefix(filepath.ToSlash(name), "/"))
+}
+
+// findFile returns the path of the first <name>.<ext> regular file of dir,
+// trying the configuration extensions in order, or an empty string if there
+// is none.
+func findFile(fsys fileSystem, dir, name string) string {
+	for _, ext := range configExtensions {
+		file := fsys.Join(dir, name+ext)
+		// Stat follows the symlinks of the Kubernetes ConfigMap volumes.
+		if info, err := fsys.Stat(file); err == nil && info.Mode().IsRegular() {
+			return file
+		}
+	}
+
+	return ""
+}
+
+// readSettings reads a configuration file, decoding it according to its
+// extension.
+func readSettings(fsys fileSystem, file string) (map[string]any, error) {
+	content, err := fsys.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := decodeSettings(path.Ext(filepath.ToSlash(file)), content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", file, err)
+	}
+
+	return settings, nil
+}
+
+func decodeSettings(ext string, content []byte) (map[string]any, error) {
+	var (
+		settings map[string]any
+		err      error
+	)
+
+	switch ext {
+	case ".yml", ".yaml":
+		err = yaml.Unmarshal(content, &settings)
+	case ".json":
+		err = json.Unmarshal(content, &settings)
+	case ".toml":
+		err = toml.Unmarshal(content, &settings)
+	default:
+		err = fmt.Errorf("unsupported configuration format %q", ext)
+	}
+
+	return settings, err
+}
@@ -0,0 +1,82 @@
+package builder
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViperBuilderFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"config/embedded.json":           {Data: []byte(`{"test": {"host": "json", "port": 3306, "nested": {"a": "json"}}}`)},
+		"config/embedded.test.toml":      {Data: []byte("port = 3307\n\n[nested]\nb = \"toml\"\n")},
+		"config/embedded.d/10-frag.yaml": {Data: []byte("nested:\n  c: yaml\n")},
+		"config/embedded.d/README.md":    {Data: []byte("not a layer")},
+		"other/embedded.yml":             {Data: []byte("test:\n  host: other\n")},
+		"config/malformed.toml":          {Data: []byte("test = [")},
+		"config/unsupported.ini":         {Data: []byte("[test]\nhost = ini\n")},
+		"config/yaml-first.yml":          {Data: []byte("test:\n  host: yml\n")},
+		"config/yaml-first.json":         {Data: []byte(`{"test": {"host": "json"}}`)},
+	}
+
+	t.Run("FormatsAndLayers", func(t *testing.T) {
+		var sources map[string]Source
+		v, err := New("embedded").FS(fsys).OnBuild(func(b Built) { sources = b.Sources }).Build()
+		require.NoError(t, err)
+
+		assert.Equal(t, "json", v.GetString("host"))
+		assert.Equal(t, 3307, v.GetInt("port"))
+		assert.Equal(t, "json", v.GetString("nested.a"))
+		assert.Equal(t, "toml", v.GetString("nested.b"))
+		assert.Equal(t, "yaml", v.GetString("nested.c"))
+
+		assert.Equal(t, Source{Kind: SourceFile, Name: "config/embedded.json"}, sources["host"])
+		assert.Equal(t, Source{Kind: SourceFile, Name: "config/embedded.test.toml"}, sources["port"])
+		assert.Equal(t, Source{Kind: SourceFile, Name: "config/embedded.d/10-frag.yaml"}, sources["nested.c"])
+	})
+
+	t.Run("ENVOverrides", func(t *testing.T) {
+		t.Setenv("APP_EMBEDDED_HOST", "env")
+
+		v, err := New("embedded").FS(fsys).Build()
+		require.NoError(t, err)
+		assert.Equal(t, "env", v.GetString("host"))
+	})
+
+	t.Run("ConfigPath", func(t *testing.T) {
+		v, err := New("embedded").FS(fsys).ConfigPath("/other").Build()
+		require.NoError(t, err)
+
+		// The config directory comes first.
+		assert.Equal(t, "json", v.GetString("host"))
+	})
+
+	t.Run("ExtensionOrder", func(t *testing.T) {
+		v, err := New("yaml-first").FS(fsys).Build()
+		require.NoError(t, err)
+		assert.Equal(t, "yml", v.GetString("host"))
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := New("malformed").FS(fsys).Build()
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrNotFound)
+		assert.Contains(t, err.Error(), "config/malformed.toml")
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := New("unsupported").FS(fsys).Build()
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("NotWatchable", func(t *testing.T) {
+		b := New("embedded").FS(fsys)
+		_, err := b.Build()
+		require.NoError(t, err)
+
+		_, err = b.Watch(nil, nil)
+		assert.Error(t, err)
+	})
+}
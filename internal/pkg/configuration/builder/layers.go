@@ -0,0 +1,256 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/scribd/go-sdk/pkg/configuration/remote"
+)
+
+const (
+	// localLayer is the suffix of the configuration files with the local
+	// overrides, which are not meant to be committed.
+	localLayer = "local"
+	// fragmentsDirSuffix is the suffix of the directories with configuration
+	// fragments.
+	fragmentsDirSuffix = ".d"
+)
+
+// layer is a source of settings, such as a configuration file, and the keys
+// it sets.
+type layer struct {
+	source Source
+	keys   map[string]bool
+}
+
+// readLayers returns the settings of the ENV, merging the layers on top of
+// the environment section of the base configuration file, in order:
+//
+//  1. <name>.<env>.yml
+//  2. every file of the <name>.d directory, in lexical order
+//  3. the settings of the remote provider
+//  4. <name>.local.yml
+//
+// Contrary to the base file, the layers hold the settings of the ENV without
+// an environment section. Every file can be in any supported format. It
+// returns nil settings when neither the base file nor the layers have
+// settings for the ENV.
+func (vb *ViperBuilder) readLayers(env string) (map[string]any, error) {
+	vb.layers = nil
+	found := false
+
+	settings := map[string]any{}
+	add := func(source Source, layerSettings map[string]any) {
+		found = true
+		mergeSettings(settings, layerSettings)
+		vb.layers = append(vb.layers, layer{source: source, keys: flattenKeys(layerSettings, "")})
+	}
+
+	var read []string
+	readFile := func(file string) error {
+		// The ENV specific layer of the "local" ENV is the local layer.
+		if file == "" || slices.Contains(read, file) {
+			return nil
+		}
+		read = append(read, file)
+
+		layerSettings, err := readSettings(vb.fsys, file)
+		if err != nil {
+			return err
+		}
+
+		add(Source{Kind: SourceFile, Name: file}, layerSettings)
+		return nil
+	}
+
+	var files []string
+	localFile := ""
+	if vb.configFile != "" {
+		base, err := readSettings(vb.fsys, vb.configFile)
+		if err != nil {
+			return nil, err
+		}
+		if section, ok := envSection(base, env); ok {
+			add(Source{Kind: SourceFile, Name: vb.configFile}, section)
+		}
+
+		dir := filepath.Dir(vb.configFile)
+		fragments, err := vb.fragmentFiles(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append([]string{vb.layerFile(dir, env)}, fragments...)
+		localFile = vb.layerFile(dir, localLayer)
+	}
+
+	for _, file := range files {
+		if err := readFile(file); err != nil {
+			return nil, err
+		}
+	}
+
+	if vb.remote != nil {
+		remoteSettings, err := vb.fetchRemote(env)
+		if err != nil {
+			return nil, err
+		}
+		if remoteSettings != nil {
+			add(Source{Kind: SourceRemote, Name: remoteName(vb.remote)}, remoteSettings)
+		}
+	}
+
+	if err := readFile(localFile); err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, nil
+	}
+
+	return settings, nil
+}
+
+// envSection returns the settings of the ENV section of the base
+// configuration file. Keys are case-insensitive.
+func envSection(base map[string]any, env string) (map[string]any, bool) {
+	for key, value := range base {
+		if section, ok := value.(map[string]any); ok && strings.EqualFold(key, env) {
+			return section, true
+		}
+	}
+
+	return nil, false
+}
+
+// fetchRemote returns the settings of the remote provider for the ENV, or nil
+// settings if it has none.
+func (vb *ViperBuilder) fetchRemote(env string) (map[string]any, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteFetchTimeout)
+	defer cancel()
+
+	settings, err := vb.remote.Fetch(ctx, vb.name, env)
+	if errors.Is(err, remote.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching remote %s configuration: %w", vb.name, err)
+	}
+
+	return settings, nil
+}
+
+// remoteName names the provider in the sources of the configuration values.
+func remoteName(provider remote.Provider) string {
+	if stringer, ok := provider.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+
+	return ""
+}
+
+// layerFile returns the path of the <name>.<suffix> layer file, or an empty
+// string if it does not exist.
+func (vb *ViperBuilder) layerFile(dir, suffix string) string {
+	return findFile(vb.fsys, dir, vb.name+"."+suffix)
+}
+
+// fragmentsDir returns the path of the directory with the configuration
+// fragments.
+func (vb *ViperBuilder) fragmentsDir(dir string) string {
+	return vb.fsys.Join(dir, vb.name+fragmentsDirSuffix)
+}
+
+// fragmentFiles returns the configuration files of the fragments directory,
+// sorted lexically. Hidden files are skipped, as well as the directories
+// Kubernetes creates when mounting a ConfigMap.
+func (vb *ViperBuilder) fragmentFiles(dir string) ([]string, error) {
+	fragmentsDir := vb.fragmentsDir(dir)
+
+	entries, err := vb.fsys.ReadDir(fragmentsDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", fragmentsDir, err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") || !slices.Contains(configExtensions, path.Ext(name)) {
+			continue
+		}
+
+		file := vb.fsys.Join(fragmentsDir, name)
+		// Stat follows the symlinks of the ConfigMap volumes.
+		if info, err := vb.fsys.Stat(file); err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+
+		files = append(files, file)
+	}
+
+	// ReadDir already sorts the entries by file name.
+	return files, nil
+}
+
+// mergeSettings merges src into dst:
+//   - maps are merged key by key, recursively; keys are case-insensitive;
+//   - any other value, including lists, replaces the previous value;
+//   - a null value removes the previous value. Without a previous value, as
+//     in the base file, the key is kept with an empty value, so that its ENV
+//     variable still overrides it.
+func mergeSettings(dst, src map[string]any) {
+	for key, value := range src {
+		key = strings.ToLower(key)
+
+		if _, ok := dst[key]; ok && value == nil {
+			delete(dst, key)
+			continue
+		}
+
+		srcMap, srcIsMap := value.(map[string]any)
+		dstMap, dstIsMap := dst[key].(map[string]any)
+
+		switch {
+		case srcIsMap && dstIsMap:
+			mergeSettings(dstMap, srcMap)
+		case srcIsMap:
+			// The map is copied, so that merging other layers into it
+			// never modifies src.
+			copied := map[string]any{}
+			mergeSettings(copied, srcMap)
+			dst[key] = copied
+		default:
+			dst[key] = value
+		}
+	}
+}
+
+// flattenKeys returns the keys of the values of settings, with the nested
+// keys separated by dots, like the viper keys.
+func flattenKeys(settings map[string]any, prefix string) map[string]bool {
+	keys := map[string]bool{}
+
+	for key, value := range settings {
+		key = prefix + strings.ToLower(key)
+
+		if nested, ok := value.(map[string]any); ok && len(nested) > 0 {
+			for nestedKey := range flattenKeys(nested, key+".") {
+				keys[nestedKey] = true
+			}
+			continue
+		}
+
+		keys[key] = true
+	}
+
+	return keys
+}
@@ -0,0 +1,177 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFragment(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	fragmentsDir := filepath.Join(dir, "layered.d")
+	require.NoError(t, os.MkdirAll(fragmentsDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(fragmentsDir, name), []byte(content), 0o600))
+}
+
+func TestViperBuilderLayers(t *testing.T) {
+	t.Run("Precedence", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfig(t, dir, "layered", `
+common: &common
+  base: base
+  env_file: base
+  fragment: base
+  local: base
+
+test:
+  <<: *common
+`)
+		writeConfig(t, dir, "layered.test", "env_file: env\nfragment: env\nlocal: env\n")
+		writeConfig(t, dir, "layered.development", "env_file: development\n")
+		writeFragment(t, dir, "20-second.yaml", "fragment: second\nlocal: second\n")
+		writeFragment(t, dir, "10-first.yml", "fragment: first\nfirst: first\n")
+		writeFragment(t, dir, ".hidden.yml", "fragment: hidden\n")
+		writeFragment(t, dir, "README.md", "fragment: readme\n")
+		writeConfig(t, dir, "layered.local", "local: local\n")
+
+		v, err := New("layered").ConfigPath(dir).Build()
+		require.NoError(t, err)
+
+		assert.Equal(t, "base", v.GetString("base"))
+		assert.Equal(t, "env", v.GetString("env_file"))
+		assert.Equal(t, "second", v.GetString("fragment"))
+		assert.Equal(t, "first", v.GetString("first"))
+		assert.Equal(t, "local", v.GetString("local"))
+	})
+
+	t.Run("MergeSemantics", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfig(t, dir, "layered", `
+test:
+  kafka:
+    client_id: app
+    broker_urls: ["a:9092", "b:9092"]
+    publisher:
+      topic: events
+      max_attempts: 3
+  cors:
+    enabled: true
+`)
+		writeConfig(t, dir, "layered.local", `
+Kafka:
+  broker_urls: ["localhost:9092"]
+  Publisher:
+    Max_Attempts: 1
+cors:
+`)
+
+		v, err := New("layered").ConfigPath(dir).Build()
+		require.NoError(t, err)
+
+		// Maps are merged, keys are case-insensitive.
+		assert.Equal(t, "app", v.GetString("kafka.client_id"))
+		assert.Equal(t, "events", v.GetString("kafka.publisher.topic"))
+		assert.Equal(t, 1, v.GetInt("kafka.publisher.max_attempts"))
+		// Lists are replaced.
+		assert.Equal(t, []string{"localhost:9092"}, v.GetStringSlice("kafka.broker_urls"))
+		// Null values remove the previous value.
+		assert.False(t, v.IsSet("cors.enabled"))
+	})
+
+	t.Run("NullInBase", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfig(t, dir, "layered", "test:\n  username: app\n  password:\n  nested:\n    token:\n")
+		t.Setenv("APP_LAYERED_PASSWORD", "fromenv")
+		t.Setenv("APP_LAYERED_NESTED_TOKEN", "tokenfromenv")
+
+		v, err := New("layered").ConfigPath(dir).Build()
+		require.NoError(t, err)
+
+		// The keys declared empty in the base file are decoded from their
+		// ENV variables.
+		var config struct {
+			Password string `mapstructure:"password"`
+			Nested   struct {
+				Token string `mapstructure:"token"`
+			} `mapstructure:"nested"`
+		}
+		require.NoError(t, v.Unmarshal(&config))
+		assert.Equal(t, "fromenv", config.Password)
+		assert.Equal(t, "tokenfromenv", config.Nested.Token)
+	})
+
+	t.Run("ENVOnlyInLayer", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfig(t, dir, "layered", "development:\n  foo: bar\n")
+		writeConfig(t, dir, "layered.test", "foo: baz\n")
+
+		v, err := New("layered").ConfigPath(dir).Build()
+		require.NoError(t, err)
+		assert.Equal(t, "baz", v.GetString("foo"))
+	})
+
+	t.Run("ENVNotFound", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfig(t, dir, "layered", "development:\n  foo: bar\n")
+		writeConfig(t, dir, "layered.development", "foo: baz\n")
+
+		_, err := New("layered").ConfigPath(dir).Build()
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("InvalidLayer", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfig(t, dir, "layered", "test:\n  foo: bar\n")
+		writeFragment(t, dir, "10-invalid.yml", "- not\n- a map\n")
+
+		_, err := New("layered").ConfigPath(dir).Build()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "10-invalid.yml")
+	})
+
+	t.Run("Sources", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfig(t, dir, "layered", "test:\n  base: base\n  nested:\n    a: base\n    b: base\n")
+		writeFragment(t, dir, "10-fragment.yml", "nested:\n  b: fragment\n")
+
+		var sources map[string]Source
+		_, err := New("layered").ConfigPath(dir).OnBuild(func(b Built) { sources = b.Sources }).Build()
+		require.NoError(t, err)
+
+		assert.Equal(t, filepath.Join(dir, "layered.yml"), sources["base"].Name)
+		assert.Equal(t, filepath.Join(dir, "layered.yml"), sources["nested.a"].Name)
+		assert.Equal(t, filepath.Join(dir, "layered.d", "10-fragment.yml"), sources["nested.b"].Name)
+	})
+
+	t.Run("WatchesNewLayers", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfig(t, dir, "layered", "test:\n  foo: bar\n")
+
+		b := New("layered").ConfigPath(dir)
+		_, err := b.Build()
+		require.NoError(t, err)
+
+		changes := make(chan string, 1)
+		w, err := b.Watch(func(v *viper.Viper) error {
+			changes <- v.GetString("foo")
+			return nil
+		}, nil)
+		require.NoError(t, err)
+		defer w.Close()
+
+		writeConfig(t, dir, "layered.local", "foo: local\n")
+
+		select {
+		case got := <-changes:
+			assert.Equal(t, "local", got)
+		case <-time.After(5 * time.Second):
+			t.Fatal("configuration was not reloaded")
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package builder
+
+import (
+	"fmt"
+	"io/fs"
+	"sync"
+	"time"
+
+	"github.com/scribd/go-sdk/pkg/configuration/remote"
+)
+
+// Loader creates the builders of the configurations loaded together, such as
+// by configuration.NewConfig, and tells where they read the configurations
+// from. The zero value creates builders like New, reading the configuration
+// files of the operating system file system only.
+type Loader struct {
+	// FS, if set, holds the configuration files instead of the operating
+	// system file system. They are looked up in its "config" directory.
+	FS fs.FS
+	// Remote, if set, provides settings merged on top of the configuration
+	// files. The configuration files are optional then.
+	Remote remote.Provider
+	// PollInterval is the interval at which a watched configuration is
+	// fetched again from Remote.
+	PollInterval time.Duration
+	// OnBuild, if set, is called with every configuration built by the
+	// builders of the loader, see ViperBuilder.OnBuild.
+	OnBuild func(Built)
+}
+
+var (
+	configsMu sync.RWMutex
+	configs   = map[string]func(Loader) (any, error){}
+)
+
+// Register registers the function loading the configuration of an SDK
+// package under the given name, such as "database", replacing any function
+// previously registered with it. The SDK packages register their
+// configuration when they are initialized, for Load to load it with any
+// loader without exposing it in their API.
+func Register(name string, load func(Loader) (any, error)) {
+	configsMu.Lock()
+	defer configsMu.Unlock()
+
+	configs[name] = load
+}
+
+// Load loads the configuration registered under the given name with the
+// loader. It returns the value returned by the registered function, usually
+// a pointer to the Config of the SDK package.
+func (l Loader) Load(name string) (any, error) {
+	configsMu.RLock()
+	load, ok := configs[name]
+	configsMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no configuration registered as %q", name)
+	}
+
+	return load(l)
+}
+
+// New initializes and returns a new ViperBuilder reading the configuration
+// from where the loader tells.
+func (l Loader) New(name string) *ViperBuilder {
+	vb := New(name)
+	if l.FS != nil {
+		vb.FS(l.FS)
+	}
+	if l.Remote != nil {
+		vb.Remote(l.Remote, l.PollInterval)
+	}
+	if l.OnBuild != nil {
+		vb.OnBuild(l.OnBuild)
+	}
+
+	return vb
+}
@@ -0,0 +1,45 @@
+package builder
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoaderLoad(t *testing.T) {
+	Register("loader-test", func(l Loader) (any, error) {
+		return l.New("valid").ConfigPath("testdata").Build()
+	})
+
+	var built []string
+	loader := Loader{OnBuild: func(b Built) { built = append(built, b.Name) }}
+
+	config, err := loader.Load("loader-test")
+	require.NoError(t, err)
+	assert.NotNil(t, config)
+	assert.Equal(t, []string{"valid"}, built)
+
+	_, err = loader.Load("unknown")
+	assert.EqualError(t, err, `no configuration registered as "unknown"`)
+}
+
+func TestLoaderNew(t *testing.T) {
+	fsys := fstest.MapFS{
+		"config/loader.yml": {Data: []byte("test:\n  host: fs\n  port: 1\n")},
+	}
+	provider := fakeProvider{"loader/test": {"port": 2}}
+
+	var built []string
+	loader := Loader{FS: fsys, Remote: provider, OnBuild: func(b Built) { built = append(built, b.Name) }}
+	v, err := loader.New("loader").Build()
+
+	require.NoError(t, err)
+	assert.Equal(t, "fs", v.GetString("host"))
+	assert.Equal(t, 2, v.GetInt("port"))
+	assert.Equal(t, []string{"loader"}, built)
+
+	_, err = New("loader").ConfigPath(t.TempDir()).Build()
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,104 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scribd/go-sdk/pkg/configuration/remote"
+)
+
+type fakeProvider map[string]map[string]any
+
+func (p fakeProvider) Fetch(_ context.Context, name, env string) (map[string]any, error) {
+	settings, ok := p[name+"/"+env]
+	if !ok {
+		return nil, remote.ErrNotFound
+	}
+
+	return settings, nil
+}
+
+func (fakeProvider) String() string { return "fake" }
+
+func TestViperBuilderRemote(t *testing.T) {
+	provider := fakeProvider{
+		"layered/test": {"remote": "remote", "local": "remote", "nested": map[string]any{"b": "remote"}},
+		"remote/test":  {"host": "remote"},
+	}
+
+	t.Run("Precedence", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfig(t, dir, "layered", "test:\n  base: base\n  remote: base\n  nested:\n    a: base\n    b: base\n")
+		writeConfig(t, dir, "layered.local", "local: local\n")
+		t.Setenv("APP_LAYERED_BASE", "env")
+
+		var sources map[string]Source
+		v, err := New("layered").ConfigPath(dir).Remote(provider, 0).
+			OnBuild(func(b Built) { sources = b.Sources }).Build()
+		require.NoError(t, err)
+
+		assert.Equal(t, "env", v.GetString("base"))
+		assert.Equal(t, "remote", v.GetString("remote"))
+		assert.Equal(t, "local", v.GetString("local"))
+		assert.Equal(t, "base", v.GetString("nested.a"))
+		assert.Equal(t, "remote", v.GetString("nested.b"))
+
+		assert.Equal(t, Source{Kind: SourceRemote, Name: "fake"}, sources["remote"])
+		assert.Equal(t, "remote:fake", sources["nested.b"].String())
+	})
+
+	t.Run("WithoutFiles", func(t *testing.T) {
+		v, err := New("remote").ConfigPath(t.TempDir()).Remote(provider, 0).Build()
+		require.NoError(t, err)
+		assert.Equal(t, "remote", v.GetString("host"))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := New("missing").ConfigPath(t.TempDir()).Remote(provider, 0).Build()
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		failing := remote.ProviderFunc(func(context.Context, string, string) (map[string]any, error) {
+			return nil, errors.New("unavailable")
+		})
+
+		_, err := New("remote").ConfigPath(t.TempDir()).Remote(failing, 0).Build()
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrNotFound)
+		assert.Contains(t, err.Error(), "unavailable")
+	})
+
+	t.Run("Polling", func(t *testing.T) {
+		var calls atomic.Int32
+		polled := remote.ProviderFunc(func(context.Context, string, string) (map[string]any, error) {
+			return map[string]any{"calls": calls.Add(1)}, nil
+		})
+
+		b := New("remote").ConfigPath(t.TempDir()).Remote(polled, 10*time.Millisecond)
+		_, err := b.Build()
+		require.NoError(t, err)
+
+		changes := make(chan int, 10)
+		w, err := b.Watch(func(v *viper.Viper) error {
+			changes <- v.GetInt("calls")
+			return nil
+		}, nil)
+		require.NoError(t, err)
+		defer w.Close()
+
+		select {
+		case got := <-changes:
+			assert.Greater(t, got, 1)
+		case <-time.After(5 * time.Second):
+			t.Fatal("remote configuration was not polled")
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package builder
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// SourceKind is the kind of source a configuration value comes from.
+type SourceKind string
+
+const (
+	// SourceFile is a configuration file.
+	SourceFile SourceKind = "file"
+	// SourceRemote is a remote provider.
+	SourceRemote SourceKind = "remote"
+	// SourceEnv is an ENV variable.
+	SourceEnv SourceKind = "env"
+	// SourceDefault is a default value set by the SDK.
+	SourceDefault SourceKind = "default"
+)
+
+// Source tells where the value of a configuration key comes from.
+type Source struct {
+	Kind SourceKind
+	// Name is the path of the file, the name of the ENV variable or the
+	// description of the remote provider. It is empty for the default
+	// values.
+	Name string
+	// Secret reports whether the value was resolved from a secret reference.
+	Secret bool
+}
+
+func (s Source) String() string {
+	if s.Name == "" {
+		return string(s.Kind)
+	}
+
+	return fmt.Sprintf("%s:%s", s.Kind, s.Name)
+}
+
+// Built is a configuration built by a ViperBuilder.
+type Built struct {
+	// Name is the name of the configuration, such as "database".
+	Name string
+	// Config is the configuration returned by Build.
+	Config *viper.Viper
+	// Sources holds the source of every key of Config.
+	Sources map[string]Source
+}
+
+// sources returns the source of every key of vConf, following the
+// precedence order of Build: ENV variables, configuration files and remote
+// settings, defaults.
+func (vb *ViperBuilder) sources(vConf *viper.Viper, secretKeys []string) map[string]Source {
+	sources := make(map[string]Source)
+
+	for _, key := range vConf.AllKeys() {
+		source := Source{Kind: SourceDefault, Secret: slices.Contains(secretKeys, key)}
+
+		switch {
+		case key == "env":
+			if _, ok := os.LookupEnv("APP_ENV"); ok {
+				source.Kind, source.Name = SourceEnv, "APP_ENV"
+			}
+		case vb.envVarSet(key):
+			source.Kind, source.Name = SourceEnv, vb.envVar(key)
+		default:
+			if l, ok := vb.layerOf(key); ok {
+				source.Kind, source.Name = l.source.Kind, l.source.Name
+			}
+		}
+
+		sources[key] = source
+	}
+
+	return sources
+}
+
+// layerOf returns the last layer setting the key, if any.
+func (vb *ViperBuilder) layerOf(key string) (layer, bool) {
+	for i := len(vb.layers) - 1; i >= 0; i-- {
+		if vb.layers[i].keys[key] {
+			return vb.layers[i], true
+		}
+	}
+
+	return layer{}, false
+}
+
+// envVar returns the ENV variable overriding the key.
+func (vb *ViperBuilder) envVar(key string) string {
+	return fmt.Sprintf("APP_%s_%s", strings.ToUpper(vb.name), strings.ToUpper(strings.ReplaceAll(key, ".", "_")))
+}
+
+func (vb *ViperBuilder) envVarSet(key string) bool {
+	_, ok := os.LookupEnv(vb.envVar(key))
+	return ok
+}
@@ -0,0 +1,42 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnBuild(t *testing.T) {
+	t.Setenv("BUILDER_TEST_PASSWORD", "s3cr3t")
+	t.Setenv("APP_SECRETS_MISSING", "from env")
+
+	var got []Built
+	vb := New("secrets").ConfigPath("testdata").SetDefault("timeout", "1s").OnBuild(func(b Built) {
+		got = append(got, b)
+	})
+	_, err := vb.Build()
+	require.NoError(t, err)
+
+	file, err := filepath.Abs(filepath.Join("testdata", "secrets.yml"))
+	require.NoError(t, err)
+
+	require.Len(t, got, 1)
+	assert.Equal(t, "secrets", got[0].Name)
+	assert.Equal(t, "s3cr3t", got[0].Config.GetString("password"))
+	assert.Equal(t, map[string]Source{
+		"env":      {Kind: SourceEnv, Name: "APP_ENV"},
+		"password": {Kind: SourceFile, Name: file, Secret: true},
+		"hosts":    {Kind: SourceFile, Name: file, Secret: true},
+		"missing":  {Kind: SourceEnv, Name: "APP_SECRETS_MISSING"},
+		"timeout":  {Kind: SourceDefault},
+	}, got[0].Sources)
+
+	assert.Equal(t, "file:"+file, got[0].Sources["password"].String())
+	assert.Equal(t, "default", got[0].Sources["timeout"].String())
+
+	_, err = New("secrets").ConfigPath("testdata").Build()
+	require.NoError(t, err)
+	assert.Len(t, got, 1)
+}
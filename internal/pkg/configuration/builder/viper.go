@@ -1,13 +1,39 @@
 package builder
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log"
 	"os"
 	"path"
+	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
+
+	"github.com/scribd/go-sdk/pkg/configuration/remote"
+	"github.com/scribd/go-sdk/pkg/secrets"
+)
+
+// ErrNotFound is returned by Build when there is no configuration file nor
+// remote settings, or when they have no configuration for the current ENV.
+// It allows to tell a missing configuration apart from a malformed one.
+var ErrNotFound = errors.New("configuration not found")
+
+const (
+	// secretsResolveTimeout bounds the time spent resolving the secret
+	// references of a single configuration.
+	secretsResolveTimeout = 30 * time.Second
+	// remoteFetchTimeout bounds the time spent fetching the remote settings
+	// of a single configuration.
+	remoteFetchTimeout = 30 * time.Second
+	// defaultPollInterval is the interval at which a watched configuration
+	// with a remote provider is fetched again, when no interval is set.
+	defaultPollInterval = time.Minute
 )
 
 // ViperBuilder is a builder to streamline Viper configuration and building.
@@ -15,9 +41,21 @@ type ViperBuilder struct {
 	vConf    *viper.Viper
 	defaults map[string]any
 	name     string
+	onBuild  func(Built)
+
+	fsys         fileSystem
+	configPaths  []string
+	remote       remote.Provider
+	pollInterval time.Duration
+
+	// configFile is the configuration file read by the last call to Build,
+	// if any, and layers are all the sources of settings it read.
+	configFile string
+	layers     []layer
 }
 
-// New initializes and returns a new ViperBuilder.
+// New initializes and returns a new ViperBuilder, reading the
+// configuration files of the operating system file system.
 func New(name string) *ViperBuilder {
 	vConf := viper.New()
 
@@ -25,20 +63,52 @@ func New(name string) *ViperBuilder {
 	if err := vConf.BindEnv("ENV", "APP_ENV"); err != nil {
 		log.Fatalf("Could not bind ENV for APP_ENV")
 	}
-	vConf.SetConfigName(name)
-	vConf.AddConfigPath(path.Join(os.Getenv("APP_ROOT"), "config"))
-	vConf.SetConfigType("yaml")
 
-	return &ViperBuilder{
+	vb := &ViperBuilder{
 		vConf:    vConf,
 		name:     name,
 		defaults: make(map[string]any),
+		fsys:     osFS{},
 	}
+
+	return vb
 }
 
-// ConfigPath sets the path argument as the Viper config path.
+// ConfigPath adds the path argument to the directories where the
+// configuration file is looked up, after $APP_ROOT/config.
 func (vb *ViperBuilder) ConfigPath(path string) *ViperBuilder {
-	vb.vConf.AddConfigPath(path)
+	vb.configPaths = append(vb.configPaths, path)
+	return vb
+}
+
+// FS reads the configuration files from fsys, such as an embed.FS, instead
+// of the operating system file system. The configuration file is looked up
+// in the "config" directory of fsys, then in the paths set with ConfigPath,
+// which are relative to the root of fsys. Configurations read from an fs.FS
+// cannot be watched for file changes.
+func (vb *ViperBuilder) FS(fsys fs.FS) *ViperBuilder {
+	vb.fsys = ioFS{fsys: fsys}
+	return vb
+}
+
+// Remote merges the settings fetched from the provider on top of the
+// configuration files, which become optional. A watched configuration is
+// fetched again every pollInterval, or every minute if it's zero.
+func (vb *ViperBuilder) Remote(provider remote.Provider, pollInterval time.Duration) *ViperBuilder {
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+
+	vb.remote = provider
+	vb.pollInterval = pollInterval
+	return vb
+}
+
+// OnBuild calls fn with every configuration successfully built, along with
+// the sources of its keys. It allows tools to inspect the configurations
+// loaded by the SDK packages with their own defaults.
+func (vb *ViperBuilder) OnBuild(fn func(Built)) *ViperBuilder {
+	vb.onBuild = fn
 	return vb
 }
 
@@ -52,38 +122,170 @@ func (vb *ViperBuilder) SetDefault(key string, value any) *ViperBuilder {
 
 // Build builds the Viper config and returns it.
 // It first extracts a Viper instance for the specific environment it's running
-// in, then explicitly calls BindEnv for each of the attributes of the
+// in, merging the configuration layers and the remote settings on top of the
+// configuration file (see readLayers), then explicitly calls BindEnv for each of the attributes of the
 // configuration. This is done to force Viper to be aware of the ENV variables
 // for each of those configuration attributes. The Viper instance returned by
 // this function can be unmarshalled by the caller in a configuration-specific
 // type while respecting the precedence order.
+//
+// Build can be called more than once: every call re-reads the configuration
+// files and the ENV variables and returns a new, independent Viper instance.
 func (vb *ViperBuilder) Build() (*viper.Viper, error) {
-	if err := vb.vConf.ReadInConfig(); err != nil {
-		return nil, err
+	vb.configFile = vb.findConfigFile()
+	if vb.configFile == "" && vb.remote == nil {
+		return nil, fmt.Errorf("%w: no %s configuration file in %s",
+			ErrNotFound, vb.name, strings.Join(vb.searchPaths(), ", "))
 	}
 
 	env := vb.vConf.GetString("ENV")
-	vb.vConf = vb.vConf.Sub(env)
-	if vb.vConf == nil {
-		return nil, fmt.Errorf("no %s configuration for ENV %s", vb.name, env)
+	settings, err := vb.readLayers(env)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return nil, fmt.Errorf("%w: no %s configuration for ENV %s", ErrNotFound, vb.name, env)
+	}
+
+	vConf := viper.New()
+	if err := vConf.MergeConfigMap(settings); err != nil {
+		return nil, err
 	}
 
-	vb.vConf.Set("ENV", env)
-	vb.vConf.SetEnvPrefix(fmt.Sprintf("APP_%s", strings.ToUpper(vb.name)))
-	vb.vConf.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
-	vb.vConf.AutomaticEnv()
+	vConf.Set("ENV", env)
+	vConf.SetEnvPrefix(fmt.Sprintf("APP_%s", strings.ToUpper(vb.name)))
+	vConf.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	vConf.AutomaticEnv()
 
-	allKeys := vb.vConf.AllKeys()
+	allKeys := vConf.AllKeys()
 	for _, k := range allKeys {
-		if err := vb.vConf.BindEnv(strings.ToUpper(k)); err != nil {
+		if err := vConf.BindEnv(strings.ToUpper(k)); err != nil {
 			return nil, fmt.Errorf("could not configure %s for ENV %s", k, env)
 		}
 
 	}
 
 	for key, val := range vb.defaults {
-		vb.vConf.SetDefault(key, val)
+		vConf.SetDefault(key, val)
+	}
+
+	secretKeys, err := resolveSecrets(vConf)
+	if err != nil {
+		return nil, fmt.Errorf("%s configuration: %w", vb.name, err)
+	}
+
+	if vb.onBuild != nil {
+		vb.onBuild(Built{Name: vb.name, Config: vConf, Sources: vb.sources(vConf, secretKeys)})
+	}
+
+	return vConf, nil
+}
+
+// resolveSecrets replaces the secret references found in the configuration
+// values, wherever they come from, with the secrets they point to. It returns
+// the keys of the values holding secrets.
+func resolveSecrets(vConf *viper.Viper) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), secretsResolveTimeout)
+	defer cancel()
+
+	var secretKeys []string
+
+	for _, key := range vConf.AllKeys() {
+		switch value := vConf.Get(key).(type) {
+		case string:
+			if !secrets.HasReference(value) {
+				continue
+			}
+
+			resolved, err := secrets.Resolve(ctx, value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			vConf.Set(key, resolved)
+			secretKeys = append(secretKeys, key)
+		case []any:
+			resolved := make([]any, len(value))
+			changed := false
+			for i, item := range value {
+				resolved[i] = item
+
+				str, ok := item.(string)
+				if !ok || !secrets.HasReference(str) {
+					continue
+				}
+
+				secret, err := secrets.Resolve(ctx, str)
+				if err != nil {
+					return nil, fmt.Errorf("%s[%d]: %w", key, i, err)
+				}
+				resolved[i] = secret
+				changed = true
+			}
+			if changed {
+				vConf.Set(key, resolved)
+				secretKeys = append(secretKeys, key)
+			}
+		}
+	}
+
+	return secretKeys, nil
+}
+
+// searchPaths returns the directories where the configuration file is looked
+// up, in order.
+func (vb *ViperBuilder) searchPaths() []string {
+	defaultPath := path.Join(os.Getenv("APP_ROOT"), "config")
+	if _, ok := vb.fsys.(ioFS); ok {
+		defaultPath = "config"
+	}
+
+	paths := make([]string, 0, len(vb.configPaths)+1)
+	for _, p := range append([]string{defaultPath}, vb.configPaths...) {
+		p = vb.fsys.Abs(p)
+		if !slices.Contains(paths, p) {
+			paths = append(paths, p)
+		}
+	}
+
+	return paths
+}
+
+// findConfigFile returns the path of the configuration file, or an empty
+// string if there is none. The first directory of the search paths holding a
+// <name>.<ext> file wins.
+func (vb *ViperBuilder) findConfigFile() string {
+	for _, dir := range vb.searchPaths() {
+		if file := findFile(vb.fsys, dir, vb.name); file != "" {
+			return file
+		}
+	}
+
+	return ""
+}
+
+// configFiles returns the configuration files the last call to Build read.
+func (vb *ViperBuilder) configFiles() []string {
+	var files []string
+	for _, l := range vb.layers {
+		if l.source.Kind == SourceFile {
+			files = append(files, l.source.Name)
+		}
+	}
+
+	return files
+}
+
+// configDirs returns the directories where the configuration files are
+// looked up by the last call to Build, if their changes can be watched.
+func (vb *ViperBuilder) configDirs() []string {
+	if vb.configFile == "" || !vb.fsys.Watchable() {
+		return nil
+	}
+
+	dirs := []string{filepath.Dir(vb.configFile)}
+	if info, err := vb.fsys.Stat(vb.fragmentsDir(dirs[0])); err == nil && info.IsDir() {
+		dirs = append(dirs, vb.fragmentsDir(dirs[0]))
 	}
 
-	return vb.vConf, nil
+	return dirs
 }
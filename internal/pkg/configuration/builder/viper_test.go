@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestViperBuilder(t *testing.T) {
@@ -47,3 +48,31 @@ func TestViperBuilder(t *testing.T) {
 		}
 	})
 }
+
+func TestViperBuilderSecrets(t *testing.T) {
+	t.Setenv("BUILDER_TEST_PASSWORD", "s3cr3t")
+
+	t.Run("ResolvesFileValues", func(t *testing.T) {
+		v, err := New("secrets").ConfigPath("testdata").Build()
+		require.NoError(t, err)
+
+		assert.Equal(t, "s3cr3t", v.GetString("password"))
+		assert.Equal(t, []string{"plain", "s3cr3t"}, v.GetStringSlice("hosts"))
+	})
+
+	t.Run("ResolvesEnvOverrides", func(t *testing.T) {
+		t.Setenv("APP_SECRETS_MISSING", "${env:BUILDER_TEST_PASSWORD}")
+
+		v, err := New("secrets").ConfigPath("testdata").Build()
+		require.NoError(t, err)
+
+		assert.Equal(t, "s3cr3t", v.GetString("missing"))
+	})
+
+	t.Run("FailsOnUnresolvedReference", func(t *testing.T) {
+		t.Setenv("APP_SECRETS_MISSING", "${env:BUILDER_TEST_UNSET}")
+
+		_, err := New("secrets").ConfigPath("testdata").Build()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,208 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// watchDebounce is the quiet period to wait for after a file system event
+// before rebuilding the configuration. Editors and Kubernetes ConfigMap
+// updates produce bursts of events for a single logical change.
+const watchDebounce = 100 * time.Millisecond
+
+// Watcher rebuilds a configuration every time one of the files it was read
+// from changes on disk and, when it has a remote provider, periodically.
+//
+// A Watcher never replaces a valid configuration with an invalid one: when
+// the rebuild fails, the error is reported and the previous configuration is
+// kept.
+type Watcher struct {
+	builder  *ViperBuilder
+	onChange func(*viper.Viper) error
+	onError  func(error)
+
+	// fsWatcher is nil when the configuration files cannot be watched.
+	fsWatcher *fsnotify.Watcher
+	// poll is nil without a remote provider.
+	poll      *time.Ticker
+	done      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// Watch starts watching the configuration files read by the last call to
+// Build, and polling the remote provider, if any. Every time they change,
+// the configuration is built again and passed to onChange. Errors, either
+// building the configuration or returned by onChange, are passed to onError;
+// onError can be nil.
+//
+// Build must be called successfully before Watch.
+func (vb *ViperBuilder) Watch(onChange func(*viper.Viper) error, onError func(error)) (*Watcher, error) {
+	dirs := vb.configDirs()
+	if len(dirs) == 0 && vb.remote == nil {
+		return nil, fmt.Errorf("no %s configuration to watch, Build must be called first and the files must be on the OS file system", vb.name)
+	}
+
+	if onError == nil {
+		onError = func(error) {}
+	}
+
+	w := &Watcher{
+		builder:  vb,
+		onChange: onChange,
+		onError:  onError,
+		done:     make(chan struct{}),
+	}
+
+	if len(dirs) > 0 {
+		fsWatcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			return nil, fmt.Errorf("creating %s configuration watcher: %w", vb.name, err)
+		}
+
+		// Directories are watched instead of files to pick up atomic
+		// saves, Kubernetes ConfigMap symlink swaps, which replace the
+		// file, and the layers created after the initial Build.
+		for _, dir := range dirs {
+			if err := fsWatcher.Add(dir); err != nil {
+				_ = fsWatcher.Close()
+				return nil, fmt.Errorf("watching %s: %w", dir, err)
+			}
+		}
+
+		w.fsWatcher = fsWatcher
+	}
+
+	if vb.remote != nil {
+		w.poll = time.NewTicker(vb.pollInterval)
+	}
+
+	w.wg.Add(1)
+	go w.run()
+
+	return w, nil
+}
+
+// Close stops watching the configuration.
+func (w *Watcher) Close() error {
+	var err error
+	w.closeOnce.Do(func() {
+		close(w.done)
+		if w.fsWatcher != nil {
+			err = w.fsWatcher.Close()
+		}
+		if w.poll != nil {
+			w.poll.Stop()
+		}
+		w.wg.Wait()
+	})
+
+	return err
+}
+
+func (w *Watcher) run() {
+	defer w.wg.Done()
+
+	timer := time.NewTimer(watchDebounce)
+	timer.Stop()
+
+	// Receiving from a nil channel blocks forever, which disables the
+	// cases of the missing event sources.
+	var (
+		events <-chan fsnotify.Event
+		errs   <-chan error
+		ticks  <-chan time.Time
+	)
+	if w.fsWatcher != nil {
+		events, errs = w.fsWatcher.Events, w.fsWatcher.Errors
+	}
+	if w.poll != nil {
+		ticks = w.poll.C
+	}
+
+	for {
+		select {
+		case <-w.done:
+			timer.Stop()
+			return
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+			timer.Reset(watchDebounce)
+		case err, ok := <-errs:
+			if !ok {
+				return
+			}
+			w.onError(fmt.Errorf("watching %s configuration: %w", w.builder.name, err))
+		case <-timer.C:
+			w.reload()
+		case <-ticks:
+			w.reload()
+		}
+	}
+}
+
+func (w *Watcher) reload() {
+	vConf, err := w.builder.Build()
+	if err != nil {
+		w.onError(fmt.Errorf("reloading %s configuration: %w", w.builder.name, err))
+		return
+	}
+
+	// A fragments directory may have been created since the last Build.
+	// Adding a directory already watched is a no-op.
+	if w.fsWatcher != nil {
+		for _, dir := range w.builder.configDirs() {
+			if err := w.fsWatcher.Add(dir); err != nil {
+				w.onError(fmt.Errorf("watching %s: %w", dir, err))
+			}
+		}
+	}
+
+	if err := w.onChange(vConf); err != nil {
+		w.onError(fmt.Errorf("applying %s configuration: %w", w.builder.name, err))
+	}
+}
+
+// WatchDecoded watches the configuration built by vb and decodes it with
+// decode every time it changes. onChange is called with the previously
+// decoded value and the new one, but only when they differ. current is the
+// value decoded from the initial Build.
+func WatchDecoded[T any](
+	vb *ViperBuilder,
+	current *T,
+	decode func(*viper.Viper) (*T, error),
+	onChange func(old, new *T),
+	onError func(error),
+) (*Watcher, error) {
+	if current == nil {
+		return nil, errors.New("watching requires the current configuration")
+	}
+
+	// Reloads are serialized by the Watcher, previous needs no locking.
+	previous := current
+
+	return vb.Watch(func(vConf *viper.Viper) error {
+		next, err := decode(vConf)
+		if err != nil {
+			return err
+		}
+
+		if reflect.DeepEqual(previous, next) {
+			return nil
+		}
+
+		old := previous
+		previous = next
+		onChange(old, next)
+
+		return nil
+	}, onError)
+}
@@ -0,0 +1,120 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".yml"), []byte(content), 0o600))
+}
+
+func TestWatcher(t *testing.T) {
+	t.Run("RequiresBuild", func(t *testing.T) {
+		_, err := New("valid").ConfigPath("testdata").Watch(func(*viper.Viper) error { return nil }, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("ReloadsOnChange", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfig(t, dir, "watched", "test:\n  foo: bar\n")
+
+		b := New("watched").ConfigPath(dir)
+		_, err := b.Build()
+		require.NoError(t, err)
+
+		changes := make(chan string, 1)
+		errs := make(chan error, 1)
+		w, err := b.Watch(func(v *viper.Viper) error {
+			changes <- v.GetString("foo")
+			return nil
+		}, func(err error) { errs <- err })
+		require.NoError(t, err)
+		defer w.Close()
+
+		writeConfig(t, dir, "watched", "test:\n  foo: baz\n")
+
+		select {
+		case got := <-changes:
+			assert.Equal(t, "baz", got)
+		case err := <-errs:
+			t.Fatalf("unexpected error: %s", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("configuration was not reloaded")
+		}
+	})
+
+	t.Run("ReportsInvalidFile", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfig(t, dir, "watched", "test:\n  foo: bar\n")
+
+		b := New("watched").ConfigPath(dir)
+		_, err := b.Build()
+		require.NoError(t, err)
+
+		errs := make(chan error, 1)
+		w, err := b.Watch(func(v *viper.Viper) error {
+			t.Error("invalid configuration must not be applied")
+			return nil
+		}, func(err error) { errs <- err })
+		require.NoError(t, err)
+		defer w.Close()
+
+		writeConfig(t, dir, "watched", "development:\n  foo: bar\n")
+
+		select {
+		case err := <-errs:
+			assert.Error(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("error was not reported")
+		}
+	})
+
+	t.Run("WatchDecodedSkipsUnchanged", func(t *testing.T) {
+		type settings struct {
+			Foo string `mapstructure:"foo"`
+			Bar string `mapstructure:"bar"`
+		}
+		decode := func(v *viper.Viper) (*settings, error) {
+			s := &settings{}
+			return s, v.Unmarshal(s)
+		}
+
+		dir := t.TempDir()
+		writeConfig(t, dir, "watched", "test:\n  foo: bar\n")
+
+		b := New("watched").ConfigPath(dir)
+		v, err := b.Build()
+		require.NoError(t, err)
+		current, err := decode(v)
+		require.NoError(t, err)
+
+		changes := make(chan [2]*settings, 2)
+		w, err := WatchDecoded(b, current, decode, func(old, new *settings) {
+			changes <- [2]*settings{old, new}
+		}, nil)
+		require.NoError(t, err)
+		defer w.Close()
+
+		// A comment does not change the decoded value.
+		writeConfig(t, dir, "watched", "# comment\ntest:\n  foo: bar\n")
+		time.Sleep(3 * watchDebounce)
+		writeConfig(t, dir, "watched", "test:\n  foo: bar\n  bar: baz\n")
+
+		select {
+		case got := <-changes:
+			assert.Equal(t, "", got[0].Bar)
+			assert.Equal(t, "baz", got[1].Bar)
+		case <-time.After(5 * time.Second):
+			t.Fatal("configuration was not reloaded")
+		}
+	})
+}
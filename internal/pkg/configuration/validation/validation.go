@@ -0,0 +1,430 @@
+/*
+Package validation checks the configuration structs against the rules
+declared in their `validate` struct tags and against their custom
+validators, and reports every violation with the path of the value in the
+configuration file and the ENV variable overriding it.
+
+The rules of a field are separated by commas and checked in order:
+
+	Workers int `mapstructure:"workers" validate:"when=Enabled,gt=0"`
+
+The built-in rules are:
+
+	required      the value is not the zero value
+	gt=N, gte=N   the number is greater than (or equal to) N; the length for
+	lt=N, lte=N   strings, slices and maps; N is a duration for time.Duration
+	oneof=A B C   the value is one of the space-separated values
+	port          the string is a port number, between 0 and 65535
+	duration      the string is a duration, such as "5s"
+	omitempty     the next rules are skipped if the value is the zero value
+	when=Field    the next rules are skipped unless the bool Field of the
+	              same struct is true
+
+More rules can be registered with Register. Rules involving several fields
+are implemented by the Validator interface.
+*/
+package validation
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	tagName = "validate"
+
+	ruleOmitEmpty = "omitempty"
+	ruleWhen      = "when"
+)
+
+// Rule checks a single configuration value. param is the part of the rule
+// after the equal sign, if any. The error returned describes the violation,
+// e.g. "must be > 0".
+type Rule func(value reflect.Value, param string) error
+
+// Validator is implemented by the configuration structs with rules that
+// cannot be expressed with struct tags. Validate is called after the rules
+// of the struct fields are checked. A violation of a specific field is
+// returned as a FieldError, or as Errors, with a Path relative to the
+// struct; any other error is reported for the struct as a whole.
+type Validator interface {
+	Validate() error
+}
+
+// FieldError is the violation of a validation rule by a configuration value.
+type FieldError struct {
+	// Path is the path of the value in the configuration, such as
+	// "pubsub.kafka.subscriber.workers".
+	Path string
+	// Env is the ENV variable overriding the value, such as
+	// "APP_PUBSUB_KAFKA_SUBSCRIBER_WORKERS". It is empty for the values
+	// that cannot be overridden, like the items of a list.
+	Env string
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	if e.Env == "" {
+		return fmt.Sprintf("%s: %s", e.Path, e.Err)
+	}
+
+	return fmt.Sprintf("%s (%s): %s", e.Path, e.Env, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Errors holds all the violations found in a configuration.
+type Errors []*FieldError
+
+func (e Errors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the violations, so that errors.Is and errors.As inspect
+// every one of them.
+func (e Errors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+var (
+	rulesMu sync.RWMutex
+	rules   = map[string]Rule{
+		"required": required,
+		"gt":       compare("gt", ">", func(c int) bool { return c > 0 }),
+		"gte":      compare("gte", ">=", func(c int) bool { return c >= 0 }),
+		"lt":       compare("lt", "<", func(c int) bool { return c < 0 }),
+		"lte":      compare("lte", "<=", func(c int) bool { return c <= 0 }),
+		"oneof":    oneOf,
+		"port":     port,
+		"duration": duration,
+	}
+
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// Register registers the rule under the given name, replacing any rule
+// previously registered with it.
+func Register(name string, rule Rule) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+
+	rules[name] = rule
+}
+
+// Validate checks the configuration loaded from the configuration file with
+// the given name, such as "pubsub", or from a key of it, such as
+// "settings.payments", and returns the violations found as Errors, or nil if
+// there are none.
+func Validate(name string, config any) error {
+	v := &validator{}
+	v.walk(reflect.ValueOf(config), path{key: name, env: "APP_" + strings.ToUpper(strings.ReplaceAll(name, ".", "_"))})
+
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return v.errs
+}
+
+// path is the location of a value in the configuration.
+type path struct {
+	key string
+	// env is empty when the value cannot be overridden by an ENV variable.
+	env string
+}
+
+func (p path) field(name string) path {
+	child := path{key: p.key + "." + name}
+	if p.env != "" {
+		child.env = p.env + "_" + strings.ToUpper(name)
+	}
+
+	return child
+}
+
+func (p path) index(i int) path {
+	return path{key: fmt.Sprintf("%s[%d]", p.key, i)}
+}
+
+func (p path) join(relative string) path {
+	if relative == "" {
+		return p
+	}
+
+	child := p
+	for _, name := range strings.Split(relative, ".") {
+		child = child.field(name)
+	}
+
+	return child
+}
+
+type validator struct {
+	errs Errors
+}
+
+func (v *validator) report(p path, err error) {
+	v.errs = append(v.errs, &FieldError{Path: p.key, Env: p.env, Err: err})
+}
+
+func (v *validator) walk(value reflect.Value, p path) {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !value.IsNil() {
+			v.walk(value.Elem(), p)
+		}
+	case reflect.Struct:
+		v.walkStruct(value, p)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			v.walk(value.Index(i), p.index(i))
+		}
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return
+		}
+
+		iter := value.MapRange()
+		for iter.Next() {
+			v.walk(iter.Value(), p.field(iter.Key().String()))
+		}
+	}
+}
+
+func (v *validator) walkStruct(value reflect.Value, p path) {
+	typ := value.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, squash := fieldName(field)
+		if name == "-" {
+			continue
+		}
+
+		fieldPath := p
+		if !squash {
+			fieldPath = p.field(name)
+		}
+
+		fieldValue := value.Field(i)
+		if tag, ok := field.Tag.Lookup(tagName); ok {
+			v.checkRules(value, fieldValue, tag, fieldPath)
+		}
+
+		v.walk(fieldValue, fieldPath)
+	}
+
+	v.callValidator(value, p)
+}
+
+// fieldName returns the configuration key of the field, following the
+// mapstructure conventions used to decode it.
+func fieldName(field reflect.StructField) (name string, squash bool) {
+	tag := field.Tag.Get("mapstructure")
+	name, opts, _ := strings.Cut(tag, ",")
+
+	if strings.Contains(opts, "squash") || (field.Anonymous && name == "") {
+		return "", true
+	}
+
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+
+	return name, false
+}
+
+func (v *validator) checkRules(parent, value reflect.Value, tag string, p path) {
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+
+		switch name {
+		case "":
+			continue
+		case ruleOmitEmpty:
+			if value.IsZero() {
+				return
+			}
+			continue
+		case ruleWhen:
+			condition := parent.FieldByName(param)
+			if !condition.IsValid() || condition.Kind() != reflect.Bool {
+				v.report(p, fmt.Errorf("invalid %s rule: %s is not a bool field", ruleWhen, param))
+				return
+			}
+			if !condition.Bool() {
+				return
+			}
+			continue
+		}
+
+		rulesMu.RLock()
+		check, ok := rules[name]
+		rulesMu.RUnlock()
+
+		if !ok {
+			v.report(p, fmt.Errorf("unknown validation rule %q", name))
+			return
+		}
+
+		if err := check(value, param); err != nil {
+			v.report(p, err)
+			// The following rules most likely fail for the same reason.
+			return
+		}
+	}
+}
+
+func (v *validator) callValidator(value reflect.Value, p path) {
+	validatorImpl, ok := asValidator(value)
+	if !ok {
+		return
+	}
+
+	err := validatorImpl.Validate()
+	if err == nil {
+		return
+	}
+
+	var errs Errors
+	var fieldErr *FieldError
+
+	switch {
+	case errors.As(err, &errs):
+		for _, e := range errs {
+			v.report(p.join(e.Path), e.Err)
+		}
+	case errors.As(err, &fieldErr):
+		v.report(p.join(fieldErr.Path), fieldErr.Err)
+	default:
+		v.report(p, err)
+	}
+}
+
+func asValidator(value reflect.Value) (Validator, bool) {
+	if value.CanAddr() {
+		if impl, ok := value.Addr().Interface().(Validator); ok {
+			return impl, true
+		}
+	}
+
+	if value.CanInterface() {
+		impl, ok := value.Interface().(Validator)
+		return impl, ok
+	}
+
+	return nil, false
+}
+
+func required(value reflect.Value, _ string) error {
+	if value.IsZero() {
+		return errors.New("is required")
+	}
+
+	return nil
+}
+
+// compare returns a rule comparing the value, or its length, with the
+// rule parameter. ok tells whether the result of the comparison, -1, 0 or
+// +1, satisfies the rule.
+func compare(name, operator string, ok func(int) bool) Rule {
+	return func(value reflect.Value, param string) error {
+		var (
+			result int
+			err    error
+			what   = "must be"
+		)
+
+		switch {
+		case value.Type() == durationType:
+			var limit time.Duration
+			if limit, err = time.ParseDuration(param); err == nil {
+				result = cmp.Compare(value.Int(), int64(limit))
+			}
+		case value.CanInt():
+			var limit int64
+			if limit, err = strconv.ParseInt(param, 10, 64); err == nil {
+				result = cmp.Compare(value.Int(), limit)
+			}
+		case value.CanUint():
+			var limit uint64
+			if limit, err = strconv.ParseUint(param, 10, 64); err == nil {
+				result = cmp.Compare(value.Uint(), limit)
+			}
+		case value.CanFloat():
+			var limit float64
+			if limit, err = strconv.ParseFloat(param, 64); err == nil {
+				result = cmp.Compare(value.Float(), limit)
+			}
+		case value.Kind() == reflect.String, value.Kind() == reflect.Slice, value.Kind() == reflect.Map:
+			what = "length must be"
+			var limit int
+			if limit, err = strconv.Atoi(param); err == nil {
+				result = cmp.Compare(value.Len(), limit)
+			}
+		default:
+			return fmt.Errorf("invalid %s rule for type %s", name, value.Type())
+		}
+
+		if err != nil {
+			return fmt.Errorf("invalid %s rule parameter %q", name, param)
+		}
+
+		if !ok(result) {
+			return fmt.Errorf("%s %s %s", what, operator, param)
+		}
+
+		return nil
+	}
+}
+
+func oneOf(value reflect.Value, param string) error {
+	allowed := strings.Fields(param)
+	actual := fmt.Sprint(value.Interface())
+
+	for _, v := range allowed {
+		if actual == v {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("must be one of %s, got %q", strings.Join(allowed, ", "), actual)
+}
+
+func port(value reflect.Value, _ string) error {
+	if _, err := strconv.ParseUint(value.String(), 10, 16); err != nil || value.Kind() != reflect.String {
+		return fmt.Errorf("must be a port number, got %q", value.String())
+	}
+
+	return nil
+}
+
+func duration(value reflect.Value, _ string) error {
+	if _, err := time.ParseDuration(value.String()); err != nil || value.Kind() != reflect.String {
+		return fmt.Errorf("must be a duration such as 5s, got %q", value.String())
+	}
+
+	return nil
+}
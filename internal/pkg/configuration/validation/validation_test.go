@@ -0,0 +1,283 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errCustom = errors.New("custom violation")
+
+type (
+	testConfig struct {
+		Port     string        `mapstructure:"port" validate:"omitempty,port"`
+		Timeout  string        `mapstructure:"timeout" validate:"omitempty,duration"`
+		Pool     int           `mapstructure:"pool" validate:"gte=0"`
+		Level    string        `mapstructure:"level" validate:"oneof=debug info"`
+		Interval time.Duration `mapstructure:"interval" validate:"lte=1m"`
+		Ratio    float64       `mapstructure:"ratio" validate:"lt=1"`
+		Hosts    []string      `mapstructure:"hosts" validate:"gt=0"`
+		Name     string        `validate:"required"`
+		Even     int           `mapstructure:"even" validate:"even"`
+
+		Worker   testWorker            `mapstructure:"worker"`
+		Items    []testItem            `mapstructure:"items"`
+		Services map[string]testWorker `mapstructure:"services"`
+		Custom   *testCustom           `mapstructure:"custom"`
+
+		ignored int `validate:"gt=0"`
+	}
+
+	testWorker struct {
+		Enabled bool   `mapstructure:"enabled"`
+		Workers int    `mapstructure:"workers" validate:"when=Enabled,gt=0"`
+		Group   string `mapstructure:"group_id" validate:"when=Enabled,required"`
+	}
+
+	testItem struct {
+		Path string `mapstructure:"path" validate:"required"`
+	}
+
+	testCustom struct {
+		Err error
+	}
+)
+
+func (c testCustom) Validate() error {
+	return c.Err
+}
+
+func init() {
+	Register("even", func(value reflect.Value, _ string) error {
+		if value.Int()%2 != 0 {
+			return fmt.Errorf("must be even, got %d", value.Int())
+		}
+		return nil
+	})
+}
+
+func validConfig() testConfig {
+	return testConfig{
+		Port:     "8080",
+		Timeout:  "1s",
+		Level:    "info",
+		Interval: time.Second,
+		Ratio:    0.5,
+		Hosts:    []string{"localhost"},
+		Name:     "test",
+		Worker:   testWorker{Enabled: true, Workers: 1, Group: "group"},
+		Items:    []testItem{{Path: "/"}},
+		Services: map[string]testWorker{"default": {}},
+		Custom:   &testCustom{},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		modify func(c *testConfig)
+		want   []string
+	}{
+		{
+			name:   "Valid",
+			modify: func(c *testConfig) {},
+		},
+		{
+			name: "ZeroValuesOmitted",
+			modify: func(c *testConfig) {
+				c.Port = ""
+				c.Timeout = ""
+				c.Worker = testWorker{}
+				c.ignored = -1
+			},
+		},
+		{
+			name: "Port",
+			modify: func(c *testConfig) {
+				c.Port = "http"
+			},
+			want: []string{`test.port (APP_TEST_PORT): must be a port number, got "http"`},
+		},
+		{
+			name: "PortOutOfRange",
+			modify: func(c *testConfig) {
+				c.Port = "65536"
+			},
+			want: []string{`test.port (APP_TEST_PORT): must be a port number, got "65536"`},
+		},
+		{
+			name: "Duration",
+			modify: func(c *testConfig) {
+				c.Timeout = "10"
+			},
+			want: []string{`test.timeout (APP_TEST_TIMEOUT): must be a duration such as 5s, got "10"`},
+		},
+		{
+			name: "Comparisons",
+			modify: func(c *testConfig) {
+				c.Pool = -1
+				c.Interval = time.Hour
+				c.Ratio = 1
+				c.Hosts = nil
+			},
+			want: []string{
+				"test.pool (APP_TEST_POOL): must be >= 0",
+				"test.interval (APP_TEST_INTERVAL): must be <= 1m",
+				"test.ratio (APP_TEST_RATIO): must be < 1",
+				"test.hosts (APP_TEST_HOSTS): length must be > 0",
+			},
+		},
+		{
+			name: "OneOf",
+			modify: func(c *testConfig) {
+				c.Level = "verbose"
+			},
+			want: []string{`test.level (APP_TEST_LEVEL): must be one of debug, info, got "verbose"`},
+		},
+		{
+			name: "RequiredWithoutMapstructureTag",
+			modify: func(c *testConfig) {
+				c.Name = ""
+			},
+			want: []string{"test.name (APP_TEST_NAME): is required"},
+		},
+		{
+			name: "RegisteredRule",
+			modify: func(c *testConfig) {
+				c.Even = 3
+			},
+			want: []string{"test.even (APP_TEST_EVEN): must be even, got 3"},
+		},
+		{
+			name: "When",
+			modify: func(c *testConfig) {
+				c.Worker.Workers = 0
+				c.Worker.Group = ""
+			},
+			want: []string{
+				"test.worker.workers (APP_TEST_WORKER_WORKERS): must be > 0",
+				"test.worker.group_id (APP_TEST_WORKER_GROUP_ID): is required",
+			},
+		},
+		{
+			name: "SliceItem",
+			modify: func(c *testConfig) {
+				c.Items = append(c.Items, testItem{})
+			},
+			want: []string{"test.items[1].path: is required"},
+		},
+		{
+			name: "MapValue",
+			modify: func(c *testConfig) {
+				c.Services["default"] = testWorker{Enabled: true, Group: "group"}
+			},
+			want: []string{"test.services.default.workers (APP_TEST_SERVICES_DEFAULT_WORKERS): must be > 0"},
+		},
+		{
+			name: "ValidatorError",
+			modify: func(c *testConfig) {
+				c.Custom.Err = errCustom
+			},
+			want: []string{"test.custom (APP_TEST_CUSTOM): custom violation"},
+		},
+		{
+			name: "ValidatorFieldError",
+			modify: func(c *testConfig) {
+				c.Custom.Err = &FieldError{Path: "nested.field", Err: errCustom}
+			},
+			want: []string{"test.custom.nested.field (APP_TEST_CUSTOM_NESTED_FIELD): custom violation"},
+		},
+		{
+			name: "ValidatorErrors",
+			modify: func(c *testConfig) {
+				c.Custom.Err = Errors{{Path: "a", Err: errCustom}, {Path: "b", Err: errCustom}}
+			},
+			want: []string{
+				"test.custom.a (APP_TEST_CUSTOM_A): custom violation",
+				"test.custom.b (APP_TEST_CUSTOM_B): custom violation",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := validConfig()
+			tc.modify(&c)
+
+			err := Validate("test", &c)
+			if len(tc.want) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			var errs Errors
+			require.True(t, errors.As(err, &errs))
+
+			got := make([]string, 0, len(errs))
+			for _, e := range errs {
+				got = append(got, e.Error())
+			}
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, strings.Join(tc.want, "\n"), err.Error())
+		})
+	}
+}
+
+func TestValidateUnwrap(t *testing.T) {
+	c := validConfig()
+	c.Custom.Err = errCustom
+	c.Pool = -1
+
+	err := Validate("test", c)
+	assert.ErrorIs(t, err, errCustom)
+
+	var fieldErr *FieldError
+	require.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "test.pool", fieldErr.Path)
+	assert.Equal(t, "APP_TEST_POOL", fieldErr.Env)
+}
+
+func TestValidateInvalidRules(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config any
+		want   string
+	}{
+		{
+			name: "UnknownRule",
+			config: struct {
+				Value int `validate:"unknown"`
+			}{},
+			want: `test.value (APP_TEST_VALUE): unknown validation rule "unknown"`,
+		},
+		{
+			name: "WhenNotBool",
+			config: struct {
+				Enabled string
+				Value   int `validate:"when=Enabled,gt=0"`
+			}{},
+			want: "test.value (APP_TEST_VALUE): invalid when rule: Enabled is not a bool field",
+		},
+		{
+			name: "InvalidParameter",
+			config: struct {
+				Value int `validate:"gt=zero"`
+			}{},
+			want: `test.value (APP_TEST_VALUE): invalid gt rule parameter "zero"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate("test", tc.config)
+			require.Error(t, err)
+			assert.Equal(t, tc.want, err.Error())
+		})
+	}
+}
@@ -34,6 +34,20 @@ func (Test) GenerateProto() error {
 		"sdktest.proto")
 }
 
+type Proto mg.Namespace
+
+// Generates the proto files of the log levels admin service.
+func (Proto) Generate() error {
+	return sh.RunV(
+		"protoc",
+		"--proto_path=./pkg/logger/leveladmin",
+		"--go_out=./pkg/logger/leveladmin",
+		"--go_opt=paths=source_relative",
+		"--go-grpc_out=./pkg/logger/leveladmin",
+		"--go-grpc_opt=paths=source_relative",
+		"leveladmin.proto")
+}
+
 type Fmt mg.Namespace
 
 // Runs gofmt.
@@ -1,8 +1,11 @@
 package app
 
 import (
+	"io"
 	"os"
 	"path"
+	"reflect"
+	"sync"
 	"time"
 
 	"github.com/spf13/viper"
@@ -10,89 +13,245 @@ import (
 	cbuilder "github.com/scribd/go-sdk/internal/pkg/configuration/builder"
 )
 
+// ChangeFunc is called with the previous and the new value of a key every
+// time a configuration reload changes it.
+type ChangeFunc func(old, new any)
+
 // Config is custom application configuration.
 type Config struct {
+	mu    sync.RWMutex
 	vConf *viper.Viper
+	// name is the name of the configuration file, used to name the ENV
+	// variables in the errors.
+	name string
+
+	// overrides keeps the values assigned with Set, so that they survive
+	// configuration reloads.
+	overrides map[string]any
+	listeners map[string][]ChangeFunc
+
+	builder *cbuilder.ViperBuilder
+	watcher io.Closer
+}
+
+func init() {
+	cbuilder.Register("app", func(loader cbuilder.Loader) (any, error) {
+		return newDefaultConfig(loader)
+	})
 }
 
 // NewDefaultConfig returns a new Config with default values
 func NewDefaultConfig() (*Config, error) {
-	return NewConfig(
-		path.Join(os.Getenv("APP_ROOT"), "config"),
-		"settings",
-	)
+	return newDefaultConfig(cbuilder.Loader{})
+}
+
+func newDefaultConfig(loader cbuilder.Loader) (*Config, error) {
+	return newConfig(loader, path.Join(os.Getenv("APP_ROOT"), "config"), "settings")
 }
 
 // NewConfig sets up the app configuration, setting default values and configurations.
 func NewConfig(configPath string, configName string) (*Config, error) {
-	conf := &Config{}
-	viperBuilder := cbuilder.New(configName).ConfigPath(configPath)
+	return newConfig(cbuilder.Loader{}, configPath, configName)
+}
+
+func newConfig(loader cbuilder.Loader, configPath string, configName string) (*Config, error) {
+	conf := &Config{
+		name:      configName,
+		overrides: map[string]any{},
+		listeners: map[string][]ChangeFunc{},
+	}
+	viperBuilder := loader.New(configName).ConfigPath(configPath)
 
 	vConf, err := viperBuilder.Build()
 	if err != nil {
 		return nil, err
 	}
 
+	// The build hook is for the initial load only, not for the reloads.
+	viperBuilder.OnBuild(nil)
+
 	conf.vConf = vConf
+	conf.builder = viperBuilder
 	return conf, nil
 }
 
+// Watch enables the watch mode: the configuration file is read again every
+// time it changes, and the subscriptions registered with OnChange are
+// notified about the keys whose value changed.
+//
+// If the changed file cannot be loaded, the current configuration is kept and
+// the error is passed to onError, which can be nil.
+func (c *Config) Watch(onError func(error)) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.watcher != nil {
+		return nil
+	}
+
+	watcher, err := c.builder.Watch(c.reload, onError)
+	if err != nil {
+		return err
+	}
+
+	c.watcher = watcher
+	return nil
+}
+
+// OnChange subscribes fn to the changes of key. The key can be a leaf or
+// any parent key, in which case fn is called when any value below it
+// changes. Subscriptions only receive notifications in watch mode.
+func (c *Config) OnChange(key string, fn ChangeFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.listeners[key] = append(c.listeners[key], fn)
+}
+
+// Close stops watching the configuration file.
+func (c *Config) Close() error {
+	c.mu.Lock()
+	watcher := c.watcher
+	c.watcher = nil
+	c.mu.Unlock()
+
+	if watcher == nil {
+		return nil
+	}
+
+	// The watcher is closed without holding the lock, as closing it waits
+	// for a reload in progress, which takes the lock.
+	return watcher.Close()
+}
+
+func (c *Config) reload(vConf *viper.Viper) error {
+	type change struct {
+		fn       ChangeFunc
+		old, new any
+	}
+
+	c.mu.Lock()
+	for key, value := range c.overrides {
+		vConf.Set(key, value)
+	}
+
+	var changes []change
+	for key, fns := range c.listeners {
+		oldValue, newValue := c.vConf.Get(key), vConf.Get(key)
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		for _, fn := range fns {
+			changes = append(changes, change{fn: fn, old: oldValue, new: newValue})
+		}
+	}
+
+	c.vConf = vConf
+	c.mu.Unlock()
+
+	// The subscriptions are notified without holding the lock, so they
+	// can read the configuration.
+	for _, ch := range changes {
+		ch.fn(ch.old, ch.new)
+	}
+
+	return nil
+}
+
 // Bool returns a key's value as bool.
 func (c *Config) Bool(key string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return c.vConf.GetBool(key)
 }
 
 // Float64 returns a key's value as float64.
 func (c *Config) Float64(key string) float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return c.vConf.GetFloat64(key)
 }
 
 // Int returns a key's value as int.
 func (c *Config) Int(key string) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return c.vConf.GetInt(key)
 }
 
 // String returns a key's value as string.
 func (c *Config) String(key string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return c.vConf.GetString(key)
 }
 
 // StringMap returns a key's value as map[string]interface{}.
 func (c *Config) StringMap(key string) map[string]any {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return c.vConf.GetStringMap(key)
 }
 
 // StringMapString returns a key's value as map[string]string.
 func (c *Config) StringMapString(key string) map[string]string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return c.vConf.GetStringMapString(key)
 }
 
 // StringSlice returns a key's value as []string.
 func (c *Config) StringSlice(key string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return c.vConf.GetStringSlice(key)
 }
 
 // Time returns a key's value as time.Time.
 func (c *Config) Time(key string) time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return c.vConf.GetTime(key)
 }
 
 // Duration returns a key's value as time.Duration.
 func (c *Config) Duration(key string) time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return c.vConf.GetDuration(key)
 }
 
 // Set sets a value to a key.
+// Values set this way take precedence over the ones loaded on reload.
 func (c *Config) Set(key string, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.overrides[key] = value
 	c.vConf.Set(key, value)
 }
 
 // IsSet checks if the key has assigned value.
 func (c *Config) IsSet(key string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return c.vConf.IsSet(key)
 }
 
 // AllSettings returns all settings as map.
 func (c *Config) AllSettings() map[string]any {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return c.vConf.AllSettings()
 }
@@ -1,7 +1,10 @@
 package app
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -86,3 +89,117 @@ func TestGetters(t *testing.T) {
 		t.Errorf("Got time: %s, expected time: %s", actual, expectedTime)
 	}
 }
+
+func TestOnChange(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) {
+		if err := os.WriteFile(filepath.Join(dir, "settings.yml"), []byte(content), 0o600); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+	write("test:\n  name: foo\n  port: 80\n")
+
+	cfg, err := NewConfig(dir, "settings")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	cfg.Set("override", "kept")
+
+	type change struct{ old, new any }
+	changes := make(chan change, 2)
+	cfg.OnChange("name", func(old, new any) { changes <- change{old, new} })
+	cfg.OnChange("port", func(old, new any) { t.Errorf("Unchanged key notified: %v -> %v", old, new) })
+
+	errs := make(chan error, 1)
+	if err := cfg.Watch(func(err error) { errs <- err }); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer cfg.Close()
+
+	write("test:\n  name: bar\n  port: 80\n")
+
+	select {
+	case got := <-changes:
+		if got.old != "foo" || got.new != "bar" {
+			t.Errorf("Got change: %v -> %v, expected: foo -> bar", got.old, got.new)
+		}
+	case err := <-errs:
+		t.Fatalf("Unexpected error: %s", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Configuration was not reloaded")
+	}
+
+	if actual := cfg.String("name"); actual != "bar" {
+		t.Errorf("Got: %s, expected: bar", actual)
+	}
+	if actual := cfg.String("override"); actual != "kept" {
+		t.Errorf("Got: %s, expected: kept", actual)
+	}
+
+	// A file that cannot be loaded keeps the previous configuration.
+	write("foo")
+
+	select {
+	case <-errs:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Error was not reported")
+	}
+
+	if actual := cfg.String("name"); actual != "bar" {
+		t.Errorf("Got: %s, expected: bar", actual)
+	}
+}
+
+func TestCloseDuringReload(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) {
+		if err := os.WriteFile(filepath.Join(dir, "settings.yml"), []byte(content), 0o600); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+	write("test:\n  name: foo\n")
+
+	cfg, err := NewConfig(dir, "settings")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// The subscription reads the configuration while Close waits for the
+	// reload to finish.
+	entered, release := make(chan struct{}), make(chan struct{})
+	var once sync.Once
+	cfg.OnChange("name", func(_, _ any) {
+		once.Do(func() {
+			close(entered)
+			<-release
+			_ = cfg.String("name")
+		})
+	})
+
+	if err := cfg.Watch(nil); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	write("test:\n  name: bar\n")
+
+	select {
+	case <-entered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Configuration was not reloaded")
+	}
+
+	closed := make(chan error, 1)
+	go func() { closed <- cfg.Close() }()
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	select {
+	case err := <-closed:
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close did not return")
+	}
+}
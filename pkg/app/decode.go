@@ -0,0 +1,173 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/viper"
+
+	"github.com/scribd/go-sdk/internal/pkg/configuration/validation"
+)
+
+// ErrKeyNotFound is returned when decoding a key that is not set.
+var ErrKeyNotFound = errors.New("key not found")
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Unmarshal decodes the value of key into out, which must be a non-nil
+// pointer. An empty key decodes the whole configuration.
+//
+// Values are decoded with the mapstructure hooks the SDK uses for its own
+// configurations: durations and comma separated lists can be given as
+// strings. The struct fields are looked up one by one, so that:
+//
+//   - every nested key can be overridden by an ENV variable, such as
+//     APP_SETTINGS_PAYMENTS_TIMEOUT for the timeout field of the payments
+//     key, even when the configuration file does not set it;
+//   - a field missing from the configuration gets the value of its
+//     `default` struct tag, if any.
+//
+// The decoded value is then checked against its `validate` struct tags (see
+// the configuration package), so that `validate:"required"` reports the
+// missing keys. A key that is not set is an ErrKeyNotFound error, unless out
+// points to a struct.
+func Unmarshal(c *Config, key string, out any) error {
+	value := reflect.ValueOf(out)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return fmt.Errorf("unable to decode into %T: not a non-nil pointer", out)
+	}
+
+	c.mu.RLock()
+	input, found := lookup(c.vConf, key, value.Elem().Type())
+	c.mu.RUnlock()
+
+	if !found && !isStruct(value.Elem().Type()) {
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.StringToWeakSliceHookFunc(","),
+		),
+		WeaklyTypedInput: true,
+		Result:           out,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(input); err != nil {
+		return fmt.Errorf("unable to decode %s: %w", key, err)
+	}
+
+	name := c.name
+	if key != "" {
+		name = name + "." + key
+	}
+
+	return validation.Validate(name, out)
+}
+
+// Get returns the value of key decoded as T. See Unmarshal for the
+// decoding rules.
+func Get[T any](c *Config, key string) (T, error) {
+	var value T
+	err := Unmarshal(c, key, &value)
+
+	return value, err
+}
+
+// MustGet is like Get but panics if the value cannot be decoded.
+func MustGet[T any](c *Config, key string) T {
+	value, err := Get[T](c, key)
+	if err != nil {
+		panic(err)
+	}
+
+	return value
+}
+
+// lookup returns the input to decode into a value of type typ from key, and
+// whether the configuration sets any value for it. Structs are looked up
+// field by field, filling the missing fields with their default values.
+func lookup(vConf *viper.Viper, key string, typ reflect.Type) (any, bool) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if !isStruct(typ) {
+		if !vConf.IsSet(key) {
+			return nil, false
+		}
+
+		return vConf.Get(key), true
+	}
+
+	input := map[string]any{}
+	found := false
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if strings.Contains(opts, "squash") {
+			// A squashed field that is not a struct has no map to merge
+			// when its key is unset, or set to a scalar: skip it.
+			squashed, ok := lookup(vConf, key, field.Type)
+			squashedMap, isMap := squashed.(map[string]any)
+			if !isMap {
+				continue
+			}
+			for k, v := range squashedMap {
+				input[k] = v
+			}
+			found = found || ok
+			continue
+		}
+
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		fieldKey := name
+		if key != "" {
+			fieldKey = key + "." + name
+		}
+
+		value, ok := lookup(vConf, fieldKey, field.Type)
+		if !ok {
+			if def, hasDefault := field.Tag.Lookup("default"); hasDefault {
+				input[name] = def
+			} else if isStruct(field.Type) {
+				// Keep the defaults of the nested fields.
+				input[name] = value
+			}
+			continue
+		}
+
+		input[name] = value
+		found = true
+	}
+
+	return input, found
+}
+
+func isStruct(typ reflect.Type) bool {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	return typ.Kind() == reflect.Struct && typ != timeType
+}
@@ -0,0 +1,137 @@
+package app
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type retrySettings struct {
+	Attempts int           `mapstructure:"attempts"`
+	Backoff  time.Duration `mapstructure:"backoff" default:"100ms"`
+}
+
+type paymentsSettings struct {
+	Provider   string        `mapstructure:"provider" validate:"required"`
+	APIKey     string        `mapstructure:"api_key" validate:"required"`
+	Timeout    time.Duration `mapstructure:"timeout"`
+	Currencies []string      `mapstructure:"currencies"`
+	Region     string        `mapstructure:"region" default:"us-east-1"`
+	Retry      retrySettings `mapstructure:"retry"`
+}
+
+func TestUnmarshal(t *testing.T) {
+	t.Setenv("APP_DECODE_PAYMENTS_API_KEY", "key")
+	t.Setenv("APP_DECODE_PAYMENTS_RETRY_BACKOFF", "1s")
+
+	cfg, err := NewConfig("testdata", "decode")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var actual paymentsSettings
+	if err := Unmarshal(cfg, "payments", &actual); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := paymentsSettings{
+		Provider:   "stripe",
+		APIKey:     "key",
+		Timeout:    2 * time.Second,
+		Currencies: []string{"usd", "eur"},
+		Region:     "us-east-1",
+		Retry:      retrySettings{Attempts: 3, Backoff: time.Second},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Got: %+v, expected: %+v", actual, expected)
+	}
+}
+
+func TestUnmarshalRequired(t *testing.T) {
+	cfg, err := NewConfig("testdata", "decode")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var actual paymentsSettings
+	err = Unmarshal(cfg, "payments", &actual)
+
+	expected := "decode.payments.api_key (APP_DECODE_PAYMENTS_API_KEY): is required"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Got error: %v, expected: %s", err, expected)
+	}
+}
+
+func TestUnmarshalSquashedMap(t *testing.T) {
+	cfg, err := NewConfig("testdata", "decode")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	type settings struct {
+		Provider string            `mapstructure:"provider"`
+		Extra    map[string]string `mapstructure:",squash"`
+	}
+
+	// Only structs can be squashed: a squashed map of a key that is not set
+	// fails to decode instead of panicking.
+	var actual settings
+	if err := Unmarshal(cfg, "missing", &actual); err == nil {
+		t.Error("Expected an error decoding a squashed map")
+	}
+}
+
+func TestGet(t *testing.T) {
+	t.Setenv("APP_DECODE_PAYMENTS_CURRENCIES", "gbp,chf")
+
+	cfg, err := NewConfig("testdata", "decode")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if actual, err := Get[int](cfg, "port"); err != nil || actual != 8080 {
+		t.Errorf("Got: %d (%v), expected: 8080", actual, err)
+	}
+
+	if actual, err := Get[time.Duration](cfg, "payments.timeout"); err != nil || actual != 2*time.Second {
+		t.Errorf("Got: %s (%v), expected: 2s", actual, err)
+	}
+
+	if actual, err := Get[[]string](cfg, "payments.currencies"); err != nil || strings.Join(actual, ",") != "gbp,chf" {
+		t.Errorf("Got: %v (%v), expected: [gbp chf]", actual, err)
+	}
+
+	if _, err := Get[string](cfg, "missing"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Got error: %v, expected: %s", err, ErrKeyNotFound)
+	}
+
+	if _, err := Get[int](cfg, "payments.provider"); err == nil {
+		t.Error("Expected an error decoding a string into an int")
+	}
+
+	// A struct missing from the configuration gets its default values.
+	actual, err := Get[retrySettings](cfg, "missing")
+	if err != nil || actual.Backoff != 100*time.Millisecond {
+		t.Errorf("Got: %+v (%v), expected a backoff of 100ms", actual, err)
+	}
+}
+
+func TestMustGet(t *testing.T) {
+	cfg, err := NewConfig("testdata", "decode")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if actual := MustGet[string](cfg, "payments.provider"); actual != "stripe" {
+		t.Errorf("Got: %s, expected: stripe", actual)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected MustGet to panic")
+		}
+	}()
+	MustGet[string](cfg, "missing")
+}
@@ -11,8 +11,10 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/sagemakerruntime"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
 	"github.com/aws/aws-sdk-go-v2/service/sfn"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
@@ -140,6 +142,57 @@ func (b *Builder) NewSQSService(
 	return sqs.NewFromConfig(cfg, opts...), nil
 }
 
+func (b *Builder) NewSecretsManagerService(
+	cfg aws.Config,
+	serviceName string, opts ...func(options *secretsmanager.Options)) (*secretsmanager.Client, error) {
+	secretsManagerCfg, ok := b.config.SecretsManager[serviceName]
+	if !ok {
+		return nil, fmt.Errorf("secrets manager config for service %s not found", serviceName)
+	}
+	defaultOpts := []func(*secretsmanager.Options){
+		func(options *secretsmanager.Options) {
+			if secretsManagerCfg.Region != "" {
+				options.Region = secretsManagerCfg.Region
+			}
+			if credentialsSet(&secretsManagerCfg.Credentials) {
+				options.Credentials = getCredentialsProvider(cfg, &secretsManagerCfg.Credentials)
+			}
+			if secretsManagerCfg.HTTPClient.MaxIdleConns > 0 {
+				options.HTTPClient = createHttpClient(&secretsManagerCfg.HTTPClient)
+			}
+		},
+	}
+
+	opts = append(defaultOpts, opts...)
+
+	return secretsmanager.NewFromConfig(cfg, opts...), nil
+}
+
+func (b *Builder) NewSSMService(
+	cfg aws.Config, serviceName string, opts ...func(options *ssm.Options)) (*ssm.Client, error) {
+	ssmCfg, ok := b.config.SSM[serviceName]
+	if !ok {
+		return nil, fmt.Errorf("ssm config for service %s not found", serviceName)
+	}
+	defaultOpts := []func(*ssm.Options){
+		func(options *ssm.Options) {
+			if ssmCfg.Region != "" {
+				options.Region = ssmCfg.Region
+			}
+			if credentialsSet(&ssmCfg.Credentials) {
+				options.Credentials = getCredentialsProvider(cfg, &ssmCfg.Credentials)
+			}
+			if ssmCfg.HTTPClient.MaxIdleConns > 0 {
+				options.HTTPClient = createHttpClient(&ssmCfg.HTTPClient)
+			}
+		},
+	}
+
+	opts = append(defaultOpts, opts...)
+
+	return ssm.NewFromConfig(cfg, opts...), nil
+}
+
 func createHttpClient(cfg *HTTPClient) *http.Client {
 	defaultRoundTripper := http.DefaultTransport
 	defaultTransport := defaultRoundTripper.(*http.Transport)
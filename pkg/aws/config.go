@@ -6,13 +6,14 @@ import (
 	"strings"
 
 	cbuilder "github.com/scribd/go-sdk/internal/pkg/configuration/builder"
+	"github.com/scribd/go-sdk/internal/pkg/configuration/validation"
 )
 
 type (
 	HTTPClient struct {
 		// MaxIdleConns, if non-zero, controls the maximum idle
 		// (keep-alive) connections to keep per-host.
-		MaxIdleConns int `mapstructure:"max_idle_conns"`
+		MaxIdleConns int `mapstructure:"max_idle_conns" validate:"gte=0"`
 	}
 
 	StaticConfig struct {
@@ -79,6 +80,24 @@ type (
 		Credentials CredentialsConfig `mapstructure:"credentials"`
 	}
 
+	SecretsManagerConfig struct {
+		// Region is the region to send requests to.
+		Region string `mapstructure:"region"`
+		// HTTPClient is the configuration for the HttpClient AWS the SDK's API clients will use to invoke HTTP requests.
+		HTTPClient HTTPClient `mapstructure:"http_client"`
+		// Credentials is the configuration for the AWS credentials.
+		Credentials CredentialsConfig `mapstructure:"credentials"`
+	}
+
+	SSMConfig struct {
+		// Region is the region to send requests to.
+		Region string `mapstructure:"region"`
+		// HTTPClient is the configuration for the HttpClient AWS the SDK's API clients will use to invoke HTTP requests.
+		HTTPClient HTTPClient `mapstructure:"http_client"`
+		// Credentials is the configuration for the AWS credentials.
+		Credentials CredentialsConfig `mapstructure:"credentials"`
+	}
+
 	Config struct {
 		// AWSConfig is the configuration for the AWS SDK.
 		AWSConfig AWSConfig `mapstructure:"config"`
@@ -90,12 +109,26 @@ type (
 		SFN map[string]SFNConfig `mapstructure:"sfn"`
 		// SQS is the configuration for the Sqs clients.
 		SQS map[string]SQSConfig `mapstructure:"sqs"`
+		// SecretsManager is the configuration for the Secrets Manager clients.
+		SecretsManager map[string]SecretsManagerConfig `mapstructure:"secretsmanager"`
+		// SSM is the configuration for the SSM clients.
+		SSM map[string]SSMConfig `mapstructure:"ssm"`
 	}
 )
 
+func init() {
+	cbuilder.Register("aws", func(loader cbuilder.Loader) (any, error) {
+		return newConfig(loader)
+	})
+}
+
 func NewConfig() (*Config, error) {
+	return newConfig(cbuilder.Loader{})
+}
+
+func newConfig(loader cbuilder.Loader) (*Config, error) {
 	config := &Config{}
-	viperBuilder := cbuilder.New("aws")
+	viperBuilder := loader.New("aws")
 
 	appName := strings.ReplaceAll(os.Getenv("APP_SETTINGS_NAME"), "-", "_")
 	viperBuilder.SetDefault("aws", fmt.Sprintf("%s_%s", appName, os.Getenv("APP_ENV")))
@@ -109,5 +142,9 @@ func NewConfig() (*Config, error) {
 		return config, fmt.Errorf("unable to decode into struct: %s", err.Error())
 	}
 
+	if err = validation.Validate("aws", config); err != nil {
+		return config, err
+	}
+
 	return config, nil
 }
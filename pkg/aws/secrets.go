@@ -0,0 +1,157 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+
+	"github.com/scribd/go-sdk/pkg/secrets"
+)
+
+const (
+	// SecretsManagerScheme is the scheme of references to AWS Secrets
+	// Manager secrets: `${awssm:<secret name or ARN>[#<JSON key>]}`.
+	SecretsManagerScheme = "awssm"
+	// SSMScheme is the scheme of references to AWS SSM Parameter Store
+	// parameters: `${ssm:<parameter name>}`.
+	SSMScheme = "ssm"
+
+	// secretJSONKeySeparator separates the secret identifier from the key
+	// to extract when the secret is a JSON object.
+	secretJSONKeySeparator = "#"
+)
+
+type (
+	// SecretsManagerAPI is the subset of the Secrets Manager client used
+	// to resolve secrets.
+	SecretsManagerAPI interface {
+		GetSecretValue(
+			ctx context.Context,
+			params *secretsmanager.GetSecretValueInput,
+			optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+	}
+
+	// SSMAPI is the subset of the SSM client used to resolve parameters.
+	SSMAPI interface {
+		GetParameter(
+			ctx context.Context,
+			params *ssm.GetParameterInput,
+			optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+	}
+
+	// SecretsManagerResolver resolves secret references with AWS Secrets Manager.
+	SecretsManagerResolver struct {
+		client SecretsManagerAPI
+	}
+
+	// SSMResolver resolves secret references with AWS SSM Parameter Store.
+	SSMResolver struct {
+		client SSMAPI
+	}
+)
+
+var (
+	_ secrets.SecretResolver = new(SecretsManagerResolver)
+	_ secrets.SecretResolver = new(SSMResolver)
+)
+
+// NewSecretsManagerResolver returns a SecretsManagerResolver using the given client.
+func NewSecretsManagerResolver(client SecretsManagerAPI) *SecretsManagerResolver {
+	return &SecretsManagerResolver{client: client}
+}
+
+// Resolve returns the string value of the secret. When the reference ends
+// with `#<key>`, the secret is decoded as a JSON object and the value of the
+// key is returned, as it is common for secrets holding credentials.
+func (r *SecretsManagerResolver) Resolve(ctx context.Context, reference string) (string, error) {
+	secretID, key, hasKey := strings.Cut(reference, secretJSONKeySeparator)
+
+	out, err := r.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretID),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	value := aws.ToString(out.SecretString)
+	if !hasKey {
+		return value, nil
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(value), &fields); err != nil {
+		return "", fmt.Errorf("secret %s is not a JSON object: %w", secretID, err)
+	}
+
+	field, ok := fields[key]
+	if !ok {
+		return "", fmt.Errorf("secret %s has no key %s", secretID, key)
+	}
+
+	if str, ok := field.(string); ok {
+		return str, nil
+	}
+
+	return fmt.Sprint(field), nil
+}
+
+// NewSSMResolver returns a SSMResolver using the given client.
+func NewSSMResolver(client SSMAPI) *SSMResolver {
+	return &SSMResolver{client: client}
+}
+
+// Resolve returns the decrypted value of the parameter.
+func (r *SSMResolver) Resolve(ctx context.Context, reference string) (string, error) {
+	out, err := r.client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(reference),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if out.Parameter == nil {
+		return "", fmt.Errorf("parameter %s not found", reference)
+	}
+
+	return aws.ToString(out.Parameter.Value), nil
+}
+
+// RegisterSecretResolvers creates the Secrets Manager and SSM clients
+// configured for serviceName and registers the resolvers for the
+// SecretsManagerScheme and SSMScheme schemes. A scheme is registered only
+// when its service is configured.
+//
+// It must be called before loading the configurations that reference
+// secrets.
+func (b *Builder) RegisterSecretResolvers(cfg aws.Config, serviceName string) error {
+	_, hasSecretsManager := b.config.SecretsManager[serviceName]
+	_, hasSSM := b.config.SSM[serviceName]
+
+	if !hasSecretsManager && !hasSSM {
+		return fmt.Errorf("secrets manager or ssm config for service %s not found", serviceName)
+	}
+
+	if hasSecretsManager {
+		client, err := b.NewSecretsManagerService(cfg, serviceName)
+		if err != nil {
+			return err
+		}
+		secrets.Register(SecretsManagerScheme, NewSecretsManagerResolver(client))
+	}
+
+	if hasSSM {
+		client, err := b.NewSSMService(cfg, serviceName)
+		if err != nil {
+			return err
+		}
+		secrets.Register(SSMScheme, NewSSMResolver(client))
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scribd/go-sdk/pkg/secrets"
+)
+
+// newSecretsStandIn returns a server answering the Secrets Manager and SSM
+// JSON APIs with the given secrets and parameters.
+func newSecretsStandIn(t *testing.T, secretValues, parameters map[string]string) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var input map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+
+		var (
+			value string
+			ok    bool
+			out   any
+		)
+		switch r.Header.Get("X-Amz-Target") {
+		case "secretsmanager.GetSecretValue":
+			value, ok = secretValues[input["SecretId"].(string)]
+			out = map[string]any{"Name": input["SecretId"], "SecretString": value}
+		case "AmazonSSM.GetParameter":
+			assert.Equal(t, true, input["WithDecryption"])
+			value, ok = parameters[input["Name"].(string)]
+			out = map[string]any{"Parameter": map[string]any{"Name": input["Name"], "Value": value}}
+		}
+
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"ResourceNotFoundException","message":"not found"}`))
+			return
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(out))
+	}))
+}
+
+func TestSecretResolvers(t *testing.T) {
+	server := newSecretsStandIn(t,
+		map[string]string{
+			"plain": "s3cr3t",
+			"arn:aws:secretsmanager:us-east-1:000000000000:secret:db": `{"username":"app","password":"db-s3cr3t","port":3306}`,
+		},
+		map[string]string{
+			"/app/redis/password": "redis-s3cr3t",
+		})
+	defer server.Close()
+
+	cfg := aws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("test", "test", ""),
+	}
+	builder := NewBuilder(&Config{
+		SecretsManager: map[string]SecretsManagerConfig{"default": {}},
+		SSM:            map[string]SSMConfig{"default": {}},
+	})
+
+	secretsManagerClient, err := builder.NewSecretsManagerService(cfg, "default", func(o *secretsmanager.Options) {
+		o.BaseEndpoint = aws.String(server.URL)
+	})
+	require.NoError(t, err)
+
+	ssmClient, err := builder.NewSSMService(cfg, "default", func(o *ssm.Options) {
+		o.BaseEndpoint = aws.String(server.URL)
+	})
+	require.NoError(t, err)
+
+	secrets.Register(SecretsManagerScheme, NewSecretsManagerResolver(secretsManagerClient))
+	defer secrets.Unregister(SecretsManagerScheme)
+	secrets.Register(SSMScheme, NewSSMResolver(ssmClient))
+	defer secrets.Unregister(SSMScheme)
+
+	testCases := []struct {
+		name      string
+		value     string
+		want      string
+		wantError bool
+	}{
+		{
+			name:  "SecretsManagerString",
+			value: "${awssm:plain}",
+			want:  "s3cr3t",
+		},
+		{
+			name:  "SecretsManagerJSONKey",
+			value: "${awssm:arn:aws:secretsmanager:us-east-1:000000000000:secret:db#password}",
+			want:  "db-s3cr3t",
+		},
+		{
+			name:  "SecretsManagerJSONNumber",
+			value: "${awssm:arn:aws:secretsmanager:us-east-1:000000000000:secret:db#port}",
+			want:  "3306",
+		},
+		{
+			name:      "SecretsManagerMissingJSONKey",
+			value:     "${awssm:arn:aws:secretsmanager:us-east-1:000000000000:secret:db#missing}",
+			wantError: true,
+		},
+		{
+			name:      "SecretsManagerNotJSON",
+			value:     "${awssm:plain#password}",
+			wantError: true,
+		},
+		{
+			name:      "SecretsManagerNotFound",
+			value:     "${awssm:missing}",
+			wantError: true,
+		},
+		{
+			name:  "SSMParameter",
+			value: "${ssm:/app/redis/password}",
+			want:  "redis-s3cr3t",
+		},
+		{
+			name:      "SSMParameterNotFound",
+			value:     "${ssm:/app/missing}",
+			wantError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := secrets.Resolve(context.Background(), tc.value)
+			if tc.wantError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRegisterSecretResolvers(t *testing.T) {
+	cfg := aws.Config{Region: "us-east-1"}
+
+	t.Run("NotConfigured", func(t *testing.T) {
+		err := NewBuilder(&Config{}).RegisterSecretResolvers(cfg, "default")
+		assert.Error(t, err)
+	})
+
+	t.Run("OnlyConfiguredSchemes", func(t *testing.T) {
+		defer secrets.Unregister(SSMScheme)
+
+		builder := NewBuilder(&Config{
+			SSM: map[string]SSMConfig{"default": {Region: "us-west-2"}},
+		})
+		require.NoError(t, builder.RegisterSecretResolvers(cfg, "default"))
+
+		_, err := secrets.Resolve(context.Background(), "${awssm:plain}")
+		assert.Error(t, err)
+	})
+}
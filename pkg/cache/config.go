@@ -1,12 +1,14 @@
 package cache
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 	"time"
 
 	cbuilder "github.com/scribd/go-sdk/internal/pkg/configuration/builder"
+	"github.com/scribd/go-sdk/internal/pkg/configuration/validation"
 )
 
 type (
@@ -26,10 +28,10 @@ type (
 
 		// Database to be selected after connecting to the server.
 		// Only single-node and failover clients.
-		DB int `mapstructure:"db"`
+		DB int `mapstructure:"db" validate:"gte=0"`
 
 		// Protocol 2 or 3. Use the version to negotiate RESP version with redis-server.
-		Protocol int `mapstructure:"protocol"`
+		Protocol int `mapstructure:"protocol" validate:"omitempty,oneof=2 3"`
 		// Use the specified Username to authenticate the current connection
 		// with one of the connections defined in the ACL list when connecting
 		// to a Redis 6.0 instance, or greater, that is using the Redis ACL system.
@@ -49,7 +51,7 @@ type (
 		SentinelPassword string `mapstructure:"sentinel_password"`
 
 		// Maximum number of retries before giving up.
-		MaxRetries int `mapstructure:"max_retries"`
+		MaxRetries int `mapstructure:"max_retries" validate:"gte=-1"`
 		// Minimum backoff between each retry.
 		MinRetryBackoff time.Duration `mapstructure:"min_retry_backoff"`
 		// Maximum backoff between each retry.
@@ -76,18 +78,18 @@ type (
 		// Base number of socket connections.
 		// If there is not enough connections in the pool, new connections will be allocated in excess of PoolSize,
 		// you can limit it through MaxActiveConns
-		PoolSize int `mapstructure:"pool_size"`
+		PoolSize int `mapstructure:"pool_size" validate:"gte=0"`
 		// Amount of time client waits for connection if all connections
 		// are busy before returning an error.
 		PoolTimeout time.Duration `mapstructure:"pool_timeout"`
 		// Maximum number of idle connections.
-		MaxIdleConns int `mapstructure:"max_idle_conns"`
+		MaxIdleConns int `mapstructure:"max_idle_conns" validate:"gte=0"`
 		// Minimum number of idle connections which is useful when establishing
 		// new connection is slow.
-		MinIdleConns int `mapstructure:"min_idle_conns"`
+		MinIdleConns int `mapstructure:"min_idle_conns" validate:"gte=0"`
 		// Maximum number of connections allocated by the pool at a given time.
 		// When zero, there is no limit on the number of connections in the pool.
-		MaxActiveConns int `mapstructure:"max_active_conns"`
+		MaxActiveConns int `mapstructure:"max_active_conns" validate:"gte=0"`
 		// ConnMaxIdleTime is the maximum amount of time a connection may be idle.
 		// Should be less than server's timeout.
 		//
@@ -148,7 +150,7 @@ type (
 
 	// Config provides configuration for cache.
 	Config struct {
-		Store string `mapstructure:"store"`
+		Store string `mapstructure:"store" validate:"required,oneof=redis"`
 		Redis Redis  `mapstructure:"redis"`
 	}
 )
@@ -157,9 +159,19 @@ const (
 	storeTypeRedisName = "redis"
 )
 
+func init() {
+	cbuilder.Register("cache", func(loader cbuilder.Loader) (any, error) {
+		return newConfig(loader)
+	})
+}
+
 func NewConfig() (*Config, error) {
+	return newConfig(cbuilder.Loader{})
+}
+
+func newConfig(loader cbuilder.Loader) (*Config, error) {
 	config := &Config{}
-	viperBuilder := cbuilder.New("cache")
+	viperBuilder := loader.New("cache")
 
 	appName := strings.ReplaceAll(os.Getenv("APP_SETTINGS_NAME"), "-", "_")
 	viperBuilder.SetDefault("cache", fmt.Sprintf("%s_%s", appName, os.Getenv("APP_ENV")))
@@ -175,25 +187,17 @@ func NewConfig() (*Config, error) {
 
 	config.Redis.Addrs = vConf.GetStringSlice("redis.addrs")
 
-	if err := config.validate(); err != nil {
+	if err := validation.Validate("cache", config); err != nil {
 		return config, err
 	}
 
 	return config, nil
 }
 
-func (c *Config) validate() error {
-	if c.Store == "" {
-		return fmt.Errorf("store is required")
-	}
-
-	switch c.Store {
-	case storeTypeRedisName:
-		if c.Redis.URL == "" && len(c.Redis.Addrs) == 0 {
-			return fmt.Errorf("url or addrs is required for redis")
-		}
-	default:
-		return fmt.Errorf("store %s is not supported", c.Store)
+// Validate checks the settings of the selected store.
+func (c *Config) Validate() error {
+	if c.Store == storeTypeRedisName && c.Redis.URL == "" && len(c.Redis.Addrs) == 0 {
+		return &validation.FieldError{Path: "redis", Err: errors.New("url or addrs is required for redis")}
 	}
 
 	return nil
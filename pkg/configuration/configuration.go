@@ -1,20 +1,122 @@
 package configuration
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+	"time"
 
+	cbuilder "github.com/scribd/go-sdk/internal/pkg/configuration/builder"
+	"github.com/scribd/go-sdk/internal/pkg/configuration/validation"
 	app "github.com/scribd/go-sdk/pkg/app"
 	"github.com/scribd/go-sdk/pkg/aws"
 	"github.com/scribd/go-sdk/pkg/cache"
+	"github.com/scribd/go-sdk/pkg/configuration/remote"
 	database "github.com/scribd/go-sdk/pkg/database"
 	instrumentation "github.com/scribd/go-sdk/pkg/instrumentation"
 	logger "github.com/scribd/go-sdk/pkg/logger"
+	"github.com/scribd/go-sdk/pkg/metrics"
 	"github.com/scribd/go-sdk/pkg/pubsub"
 	server "github.com/scribd/go-sdk/pkg/server"
 	"github.com/scribd/go-sdk/pkg/statsig"
 	tracking "github.com/scribd/go-sdk/pkg/tracking"
 )
 
+// Subsystem identifies a section of the app-wide configuration.
+type Subsystem string
+
+const (
+	App             Subsystem = "app"
+	Database        Subsystem = "database"
+	Instrumentation Subsystem = "instrumentation"
+	Logger          Subsystem = "logger"
+	Server          Subsystem = "server"
+	Tracking        Subsystem = "tracking"
+	PubSub          Subsystem = "pubsub"
+	Cache           Subsystem = "cache"
+	AWS             Subsystem = "aws"
+	Statsig         Subsystem = "statsig"
+	Metrics         Subsystem = "metrics"
+)
+
+// FieldError is the violation of a validation rule by a configuration value.
+// The configurations are validated when they are loaded, and every violation
+// is reported as a FieldError.
+type FieldError = validation.FieldError
+
+// Built is a configuration built by NewConfig, along with the sources of its
+// values, see OnBuild.
+type Built = cbuilder.Built
+
+// RemoteProvider provides the configurations from a remote store, see
+// FromRemote.
+type RemoteProvider = remote.Provider
+
+// ErrNotFound is wrapped by the errors of the subsystems without a
+// configuration file, or without a configuration for the current ENV.
+var ErrNotFound = cbuilder.ErrNotFound
+
+var (
+	// subsystems lists every subsystem in loading order.
+	subsystems = []Subsystem{
+		App, Database, Instrumentation, Logger, Server, Tracking, PubSub, Cache, AWS, Statsig, Metrics,
+	}
+
+	// optionalSubsystems are the subsystems loaded as optional without With
+	// or Optional options, the applications having done without their
+	// configuration file so far.
+	optionalSubsystems = []Subsystem{Metrics}
+
+	loaders = map[Subsystem]func(c *Config) error{
+		App: func(c *Config) (err error) {
+			c.App, err = load[app.Config](c, App)
+			return err
+		},
+		Database: func(c *Config) (err error) {
+			c.Database, err = load[database.Config](c, Database)
+			return err
+		},
+		Instrumentation: func(c *Config) (err error) {
+			c.Instrumentation, err = load[instrumentation.Config](c, Instrumentation)
+			return err
+		},
+		Logger: func(c *Config) (err error) {
+			c.Logger, err = load[logger.Config](c, Logger)
+			return err
+		},
+		Server: func(c *Config) (err error) {
+			c.Server, err = load[server.Config](c, Server)
+			return err
+		},
+		Tracking: func(c *Config) (err error) {
+			c.Tracking, err = load[tracking.Config](c, Tracking)
+			return err
+		},
+		PubSub: func(c *Config) (err error) {
+			c.PubSub, err = load[pubsub.Config](c, PubSub)
+			return err
+		},
+		Cache: func(c *Config) (err error) {
+			c.Cache, err = load[cache.Config](c, Cache)
+			return err
+		},
+		AWS: func(c *Config) (err error) {
+			c.AWS, err = load[aws.Config](c, AWS)
+			return err
+		},
+		Statsig: func(c *Config) (err error) {
+			c.Statsig, err = load[statsig.Config](c, Statsig)
+			return err
+		},
+		Metrics: func(c *Config) (err error) {
+			c.Metrics, err = load[metrics.Config](c, Metrics)
+			return err
+		},
+	}
+)
+
 // Config is an app-wide configuration
 type Config struct {
 	App             *app.Config
@@ -27,81 +129,225 @@ type Config struct {
 	Cache           *cache.Config
 	AWS             *aws.Config
 	Statsig         *statsig.Config
+	Metrics         *metrics.Config
+
+	// loader creates the builders of the configurations of the subsystems.
+	loader cbuilder.Loader
+}
+
+type options struct {
+	required []Subsystem
+	optional []Subsystem
+	loader   cbuilder.Loader
 }
 
-// NewConfig returns a new Config instance
-func NewConfig() (*Config, error) {
-	var errGroup error
-	config := &Config{}
+// Option configures which subsystems NewConfig loads, and where from.
+type Option func(*options)
 
-	appConfig, err := app.NewDefaultConfig()
-	if err != nil {
-		errGroup = wrapErrors(errGroup, fmt.Errorf("default config err: %w", err))
+// With loads the configuration of the given subsystems. A missing
+// configuration of any of them is an error.
+func With(subsystems ...Subsystem) Option {
+	return func(o *options) {
+		o.required = append(o.required, subsystems...)
 	}
+}
 
-	dbConfig, err := database.NewConfig()
-	if err != nil {
-		errGroup = wrapErrors(errGroup, fmt.Errorf("database config err: %w", err))
+// Optional loads the configuration of the given subsystems when it's
+// present. A missing configuration leaves the respective Config field nil,
+// while a malformed or invalid one is still an error.
+func Optional(subsystems ...Subsystem) Option {
+	return func(o *options) {
+		o.optional = append(o.optional, subsystems...)
 	}
+}
 
-	instrumentationConfig, err := instrumentation.NewConfig()
-	if err != nil {
-		errGroup = wrapErrors(errGroup, fmt.Errorf("instrumentation config err: %w", err))
+// FromFS reads the configuration files from fsys, such as an embed.FS,
+// instead of $APP_ROOT/config. The files are looked up in the "config"
+// directory of fsys.
+func FromFS(fsys fs.FS) Option {
+	return func(o *options) {
+		o.loader.FS = fsys
 	}
+}
 
-	loggerConfig, err := logger.NewConfig()
-	if err != nil {
-		errGroup = wrapErrors(errGroup, fmt.Errorf("logger config err: %w", err))
+// FromRemote merges the settings of the provider on top of the configuration
+// files, which become optional. The watched configurations are fetched again
+// every pollInterval, or every minute if it's zero.
+func FromRemote(provider RemoteProvider, pollInterval time.Duration) Option {
+	return func(o *options) {
+		o.loader.Remote = provider
+		o.loader.PollInterval = pollInterval
 	}
+}
 
-	serverConfig, err := server.NewConfig()
-	if err != nil {
-		errGroup = wrapErrors(errGroup, fmt.Errorf("server config err: %w", err))
+// OnBuild calls fn with every configuration built by NewConfig and Load,
+// along with the sources of its values. It allows tools to inspect the
+// configurations loaded by the SDK packages with their own defaults.
+func OnBuild(fn func(Built)) Option {
+	return func(o *options) {
+		o.loader.OnBuild = fn
 	}
+}
 
-	trackingConfig, err := tracking.NewConfig()
-	if err != nil {
-		errGroup = wrapErrors(errGroup, fmt.Errorf("tracking config err: %w", err))
+// NewConfig returns a new Config instance.
+//
+// Without With or Optional options, the configuration of every subsystem is
+// loaded and required, except the Metrics one, which is optional. With them,
+// only the selected subsystems are loaded; the others can be loaded later
+// with Load.
+//
+// The returned error, if any, is of type Errors and holds one SubsystemError
+// per subsystem that failed to load.
+func NewConfig(opts ...Option) (*Config, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
 	}
 
-	pubsubConfig, err := pubsub.NewConfig()
-	if err != nil {
-		errGroup = wrapErrors(errGroup, fmt.Errorf("pubsub config err: %w", err))
+	if len(o.required) == 0 && len(o.optional) == 0 {
+		for _, s := range subsystems {
+			if slices.Contains(optionalSubsystems, s) {
+				o.optional = append(o.optional, s)
+			} else {
+				o.required = append(o.required, s)
+			}
+		}
 	}
 
-	cacheConfig, err := cache.NewConfig()
-	if err != nil {
-		errGroup = wrapErrors(errGroup, fmt.Errorf("cache config err: %w", err))
+	config := &Config{loader: o.loader}
+	return config, config.load(o)
+}
+
+// Load loads the configuration of the given subsystems, which are required,
+// in addition to the ones already loaded, from where NewConfig read them.
+func (c *Config) Load(subsystems ...Subsystem) error {
+	return c.load(&options{required: subsystems})
+}
+
+func (c *Config) load(o *options) error {
+	var errs Errors
+
+	for _, s := range subsystems {
+		required := slices.Contains(o.required, s)
+		if !required && !slices.Contains(o.optional, s) {
+			continue
+		}
+
+		err := loaders[s](c)
+		if err == nil {
+			continue
+		}
+
+		if !required && errors.Is(err, ErrNotFound) {
+			c.reset(s)
+			continue
+		}
+
+		errs = append(errs, &SubsystemError{Subsystem: s, Err: err})
 	}
 
-	awsConfig, err := aws.NewConfig()
-	if err != nil {
-		errGroup = wrapErrors(errGroup, fmt.Errorf("aws config err: %w", err))
+	for _, s := range append(o.required, o.optional...) {
+		if _, ok := loaders[s]; !ok {
+			errs = append(errs, &SubsystemError{Subsystem: s, Err: errors.New("unknown subsystem")})
+		}
 	}
 
-	statsigConfig, err := statsig.NewConfig()
-	if err != nil {
-		errGroup = wrapErrors(errGroup, fmt.Errorf("statsig config err: %w", err))
+	if len(errs) == 0 {
+		return nil
 	}
 
-	config.App = appConfig
-	config.Database = dbConfig
-	config.Instrumentation = instrumentationConfig
-	config.Logger = loggerConfig
-	config.Server = serverConfig
-	config.Tracking = trackingConfig
-	config.PubSub = pubsubConfig
-	config.Cache = cacheConfig
-	config.AWS = awsConfig
-	config.Statsig = statsigConfig
+	return errs
+}
+
+// load loads the configuration of the subsystem, registered by its package,
+// with the loader of c.
+func load[T any](c *Config, s Subsystem) (*T, error) {
+	config, err := c.loader.Load(string(s))
+	t, _ := config.(*T)
+
+	return t, err
+}
+
+// reset clears the configuration of an optional subsystem that is missing.
+func (c *Config) reset(s Subsystem) {
+	switch s {
+	case App:
+		c.App = nil
+	case Database:
+		c.Database = nil
+	case Instrumentation:
+		c.Instrumentation = nil
+	case Logger:
+		c.Logger = nil
+	case Server:
+		c.Server = nil
+	case Tracking:
+		c.Tracking = nil
+	case PubSub:
+		c.PubSub = nil
+	case Cache:
+		c.Cache = nil
+	case AWS:
+		c.AWS = nil
+	case Statsig:
+		c.Statsig = nil
+	case Metrics:
+		c.Metrics = nil
+	}
+}
+
+// SubsystemError is the error loading the configuration of a subsystem.
+type SubsystemError struct {
+	Subsystem Subsystem
+	Err       error
+}
+
+func (e *SubsystemError) Error() string {
+	return fmt.Sprintf("%s config err: %s", e.Subsystem, e.Err)
+}
+
+func (e *SubsystemError) Unwrap() error {
+	return e.Err
+}
+
+// NotFound reports whether the configuration of the subsystem is missing, as
+// opposed to malformed or invalid.
+func (e *SubsystemError) NotFound() bool {
+	return errors.Is(e.Err, ErrNotFound)
+}
+
+// Errors holds the errors loading the configuration of the subsystems. It
+// can be extracted from the error returned by NewConfig with errors.As.
+type Errors []*SubsystemError
+
+func (e Errors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the errors of the subsystems, so that errors.Is and
+// errors.As inspect every one of them.
+func (e Errors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
 
-	return config, errGroup
+	return errs
 }
 
-func wrapErrors(baseError, err error) error {
-	if baseError == nil {
-		return err
+// Get returns the error loading the configuration of the subsystem, or nil
+// if it was loaded successfully or not requested.
+func (e Errors) Get(s Subsystem) *SubsystemError {
+	for _, err := range e {
+		if err.Subsystem == s {
+			return err
+		}
 	}
 
-	return fmt.Errorf("%s. %w", baseError.Error(), err)
+	return nil
 }
@@ -0,0 +1,233 @@
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scribd/go-sdk/pkg/configuration/remote"
+	serverpkg "github.com/scribd/go-sdk/pkg/server"
+)
+
+func setAppRoot(t *testing.T) {
+	t.Helper()
+
+	_, filename, _, _ := runtime.Caller(0)
+	t.Setenv("APP_ROOT", filepath.Join(filepath.Dir(filename), "testdata"))
+}
+
+func TestNewConfig(t *testing.T) {
+	setAppRoot(t)
+
+	testCases := []struct {
+		name        string
+		opts        []Option
+		wantLoaded  []Subsystem
+		wantErrors  []Subsystem
+		wantMissing []Subsystem
+	}{
+		{
+			name:       "Required",
+			opts:       []Option{With(App, Logger)},
+			wantLoaded: []Subsystem{App, Logger},
+		},
+		{
+			name:        "RequiredMissing",
+			opts:        []Option{With(Logger, Server, Cache)},
+			wantLoaded:  []Subsystem{Logger},
+			wantErrors:  []Subsystem{Server, Cache},
+			wantMissing: []Subsystem{Server, Cache},
+		},
+		{
+			name:       "OptionalMissing",
+			opts:       []Option{With(Logger), Optional(Server, Cache)},
+			wantLoaded: []Subsystem{Logger},
+		},
+		{
+			name:       "OptionalMalformed",
+			opts:       []Option{Optional(Logger, Database)},
+			wantLoaded: []Subsystem{Logger},
+			wantErrors: []Subsystem{Database},
+		},
+		{
+			name:        "All",
+			wantLoaded:  []Subsystem{App, Logger},
+			wantErrors:  []Subsystem{Database, Instrumentation, Server, Tracking, PubSub, Cache, AWS, Statsig},
+			wantMissing: []Subsystem{Instrumentation, Server, Tracking, PubSub, Cache, AWS, Statsig},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := NewConfig(tc.opts...)
+			require.NotNil(t, c)
+
+			if len(tc.wantErrors) == 0 {
+				require.NoError(t, err)
+			} else {
+				var errs Errors
+				require.True(t, errors.As(err, &errs))
+				assert.Len(t, errs, len(tc.wantErrors))
+
+				for _, s := range tc.wantErrors {
+					subsystemErr := errs.Get(s)
+					require.NotNil(t, subsystemErr, s)
+					assert.Equal(t, s, subsystemErr.Subsystem)
+					assert.Contains(t, err.Error(), string(s)+" config err")
+				}
+
+				for _, s := range tc.wantMissing {
+					assert.True(t, errs.Get(s).NotFound(), s)
+				}
+				assert.Equal(t, len(tc.wantMissing) > 0, errors.Is(err, ErrNotFound))
+			}
+
+			if slices.Contains(tc.wantLoaded, App) {
+				assert.Equal(t, "configuration", c.App.String("name"))
+			}
+			assert.Equal(t, slices.Contains(tc.wantLoaded, Logger), c.Logger != nil)
+			if !slices.Contains(tc.wantErrors, Server) {
+				assert.Equal(t, slices.Contains(tc.wantLoaded, Server), c.Server != nil)
+			}
+		})
+	}
+}
+
+func TestNewConfigMetricsOptional(t *testing.T) {
+	t.Setenv("APP_ROOT", t.TempDir())
+
+	c, err := NewConfig()
+	var errs Errors
+	require.ErrorAs(t, err, &errs)
+	assert.Nil(t, errs.Get(Metrics))
+	assert.Nil(t, c.Metrics)
+
+	c, err = NewConfig(FromFS(fstest.MapFS{
+		"config/metrics.yml": {Data: []byte("test:\n  backend: noop\n")},
+	}))
+	require.ErrorAs(t, err, &errs)
+	assert.Nil(t, errs.Get(Metrics))
+	require.NotNil(t, c.Metrics)
+	assert.Equal(t, "noop", c.Metrics.Backend)
+}
+
+func TestConfigLoad(t *testing.T) {
+	setAppRoot(t)
+
+	c, err := NewConfig(With(App))
+	require.NoError(t, err)
+	assert.Nil(t, c.Logger)
+
+	require.NoError(t, c.Load(Logger))
+	require.NotNil(t, c.Logger)
+	assert.Equal(t, "info", c.Logger.ConsoleLevel)
+
+	err = c.Load(Server)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	err = c.Load("unknown")
+	var errs Errors
+	require.True(t, errors.As(err, &errs))
+	assert.Equal(t, Subsystem("unknown"), errs[0].Subsystem)
+}
+
+func TestNewConfigValidation(t *testing.T) {
+	setAppRoot(t)
+	t.Setenv("APP_LOGGER_CONSOLE_LEVEL", "verbose")
+
+	_, err := NewConfig(With(Logger))
+
+	var fieldErr *FieldError
+	require.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "logger.console_level", fieldErr.Path)
+	assert.Equal(t, "APP_LOGGER_CONSOLE_LEVEL", fieldErr.Env)
+}
+
+func TestNewConfigOnBuild(t *testing.T) {
+	setAppRoot(t)
+
+	var built []string
+	c, err := NewConfig(With(App), OnBuild(func(b Built) { built = append(built, b.Name) }))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"settings"}, built)
+
+	require.NoError(t, c.Load(Logger))
+	assert.Equal(t, []string{"settings", "logger"}, built)
+}
+
+func TestNewConfigOrigin(t *testing.T) {
+	t.Setenv("APP_ROOT", t.TempDir())
+
+	fsys := fstest.MapFS{
+		"config/logger.json":   {Data: []byte(`{"test": {"console_level": "debug"}}`)},
+		"config/settings.toml": {Data: []byte("[test]\nname = \"embedded\"\n")},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/test/server" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"http_port": "9090"}`))
+	}))
+	defer server.Close()
+
+	c, err := NewConfig(
+		With(Logger, App, Server),
+		Optional(Cache),
+		FromFS(fsys),
+		FromRemote(remote.NewHTTPProvider(server.URL+"/{env}/{name}"), 0),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "debug", c.Logger.ConsoleLevel)
+	assert.Equal(t, "embedded", c.App.String("name"))
+	assert.Equal(t, "9090", c.Server.HTTPPort)
+	assert.Nil(t, c.Cache)
+
+	// Load keeps reading from the same origin.
+	err = c.Load(Database)
+	var errs Errors
+	require.ErrorAs(t, err, &errs)
+	assert.True(t, errs.Get(Database).NotFound())
+}
+
+func TestNewConfigOriginWatch(t *testing.T) {
+	t.Setenv("APP_ROOT", t.TempDir())
+
+	var port atomic.Int32
+	port.Store(9090)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"http_port": "%d"}`, port.Load())
+	}))
+	defer server.Close()
+
+	c, err := NewConfig(With(Server), FromRemote(remote.NewHTTPProvider(server.URL+"/{env}/{name}"), 10*time.Millisecond))
+	require.NoError(t, err)
+
+	changes := make(chan string, 10)
+	w, err := c.Server.Watch(func(_, new *serverpkg.Config) { changes <- new.HTTPPort }, nil)
+	require.NoError(t, err)
+	defer w.Close()
+
+	// The watcher reads from the origin of the configuration.
+	port.Store(9091)
+
+	select {
+	case got := <-changes:
+		assert.Equal(t, "9091", got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server configuration was not reloaded from its origin")
+	}
+}
@@ -0,0 +1,145 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+const (
+	// NamePlaceholder is replaced by the name of the configuration in the
+	// URL of an HTTPProvider.
+	NamePlaceholder = "{name}"
+	// EnvPlaceholder is replaced by the ENV in the URL of an HTTPProvider.
+	EnvPlaceholder = "{env}"
+
+	// maxResponseSize bounds the size of the configuration documents.
+	maxResponseSize = 10 << 20
+)
+
+// HTTPProvider fetches the configurations as JSON objects with HTTP GET
+// requests. A 404 response means the configuration is not found.
+//
+// The provider is meant to be polled: it sends the ETag of the last response
+// in the If-None-Match header, so that the server can answer with a 304 Not
+// Modified response when the configuration did not change.
+type HTTPProvider struct {
+	url    string
+	client *http.Client
+	header http.Header
+
+	mu    sync.Mutex
+	cache map[string]cachedResponse
+}
+
+type cachedResponse struct {
+	etag     string
+	settings map[string]any
+}
+
+// HTTPOption configures an HTTPProvider.
+type HTTPOption func(*HTTPProvider)
+
+// WithHTTPClient sets the client sending the requests. It defaults to
+// http.DefaultClient.
+func WithHTTPClient(client *http.Client) HTTPOption {
+	return func(p *HTTPProvider) {
+		p.client = client
+	}
+}
+
+// WithHeader adds a header to the requests, such as an Authorization
+// header.
+func WithHeader(key, value string) HTTPOption {
+	return func(p *HTTPProvider) {
+		p.header.Add(key, value)
+	}
+}
+
+// NewHTTPProvider returns an HTTPProvider fetching the configurations from
+// the URL template, whose {name} and {env} placeholders are replaced by the
+// name of the configuration and the ENV, for example
+// "https://config.example.com/v1/{env}/{name}.json".
+func NewHTTPProvider(urlTemplate string, opts ...HTTPOption) *HTTPProvider {
+	p := &HTTPProvider{
+		url:    urlTemplate,
+		client: http.DefaultClient,
+		header: http.Header{},
+		cache:  map[string]cachedResponse{},
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Fetch implements Provider.
+func (p *HTTPProvider) Fetch(ctx context.Context, name, env string) (map[string]any, error) {
+	target := strings.NewReplacer(
+		NamePlaceholder, url.PathEscape(name),
+		EnvPlaceholder, url.PathEscape(env),
+	).Replace(p.url)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = p.header.Clone()
+	req.Header.Set("Accept", "application/json")
+
+	p.mu.Lock()
+	cached, isCached := p.cache[target]
+	p.mu.Unlock()
+
+	if isCached && cached.etag != "" {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && isCached:
+		return cached.settings, nil
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, p.String())
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("fetching %s: unexpected status %s", p.String(), resp.Status)
+	}
+
+	var settings map[string]any
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&settings); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", p.String(), err)
+	}
+
+	p.mu.Lock()
+	p.cache[target] = cachedResponse{etag: resp.Header.Get("ETag"), settings: settings}
+	p.mu.Unlock()
+
+	return settings, nil
+}
+
+// String returns the URL template, without the user information.
+func (p *HTTPProvider) String() string {
+	scheme, rest, ok := strings.Cut(p.url, "://")
+	if !ok {
+		return p.url
+	}
+
+	host, _, _ := strings.Cut(rest, "/")
+	if i := strings.LastIndex(host, "@"); i >= 0 {
+		return scheme + "://" + rest[i+1:]
+	}
+
+	return p.url
+}
@@ -0,0 +1,34 @@
+/*
+Package remote provides the configurations of the SDK from a remote
+key-value store, such as a central configuration service.
+
+A Provider returns the settings of a configuration for an ENV. The settings
+it returns are merged on top of the configuration files, if any, and below
+the ENV variables. The HTTPProvider fetches them as JSON documents over HTTP.
+*/
+package remote
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned by a Provider that has no settings for the
+// requested configuration and ENV.
+var ErrNotFound = errors.New("remote configuration not found")
+
+// Provider provides configurations from a remote store.
+type Provider interface {
+	// Fetch returns the settings of the configuration with the given name,
+	// such as "database", for the ENV. It returns an error wrapping
+	// ErrNotFound when the store has no such settings.
+	Fetch(ctx context.Context, name, env string) (map[string]any, error)
+}
+
+// ProviderFunc is an adapter to use ordinary functions as Provider.
+type ProviderFunc func(ctx context.Context, name, env string) (map[string]any, error)
+
+// Fetch calls f(ctx, name, env).
+func (f ProviderFunc) Fetch(ctx context.Context, name, env string) (map[string]any, error) {
+	return f(ctx, name, env)
+}
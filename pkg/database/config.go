@@ -7,33 +7,49 @@ import (
 	"time"
 
 	cbuilder "github.com/scribd/go-sdk/internal/pkg/configuration/builder"
+	"github.com/scribd/go-sdk/internal/pkg/configuration/validation"
 )
 
 // Config is the database connection configuration.
 type Config struct {
 	Host     string `mapstructure:"host"`
-	Port     int    `mapstructure:"port"`
+	Port     int    `mapstructure:"port" validate:"gte=0,lte=65535"`
 	Username string `mapstructure:"username"`
 	Password string `mapstructure:"password"`
 	Database string `mapstructure:"database"`
-	Timeout  string `mapstructure:"timeout"`
+	Timeout  string `mapstructure:"timeout" validate:"omitempty,duration"`
 	// Connection settings
 	// TODO Pool field name must be modified in the next major change.
-	Pool                  int           `mapstructure:"pool"`
-	MaxOpenConnections    int           `mapstructure:"max_open_connections"`
-	ConnectionMaxIdleTime time.Duration `mapstructure:"connection_max_idle_time"`
-	ConnectionMaxLifetime time.Duration `mapstructure:"connection_max_lifetime"`
+	Pool                  int           `mapstructure:"pool" validate:"gte=0"`
+	MaxOpenConnections    int           `mapstructure:"max_open_connections" validate:"gte=0"`
+	ConnectionMaxIdleTime time.Duration `mapstructure:"connection_max_idle_time" validate:"gte=0"`
+	ConnectionMaxLifetime time.Duration `mapstructure:"connection_max_lifetime" validate:"gte=0"`
 
 	// Performance settings
 	DisableDefaultGormTransaction bool `mapstructure:"disable_default_gorm_transaction"`
 	CachePreparedStatements       bool `mapstructure:"cache_prepared_statements"`
 	MysqlInterpolateParams        bool `mapstructure:"mysql_interpolate_params"`
+
+	// Logging settings
+	LogLevel           string        `mapstructure:"log_level" validate:"omitempty,oneof=silent error warn info"`
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold" validate:"gte=0"`
+	LogRecordNotFound  bool          `mapstructure:"log_record_not_found"`
+}
+
+func init() {
+	cbuilder.Register("database", func(loader cbuilder.Loader) (any, error) {
+		return newConfig(loader)
+	})
 }
 
 // NewConfig returns a new Config instance.
 func NewConfig() (*Config, error) {
+	return newConfig(cbuilder.Loader{})
+}
+
+func newConfig(loader cbuilder.Loader) (*Config, error) {
 	config := &Config{}
-	viperBuilder := cbuilder.New("database")
+	viperBuilder := loader.New("database")
 
 	appName := strings.ReplaceAll(os.Getenv("APP_SETTINGS_NAME"), "-", "_")
 	viperBuilder.SetDefault("database", fmt.Sprintf("%s_%s", appName, os.Getenv("APP_ENV")))
@@ -47,5 +63,9 @@ func NewConfig() (*Config, error) {
 		return config, fmt.Errorf("unable to decode into struct: %s", err.Error())
 	}
 
+	if err = validation.Validate("database", config); err != nil {
+		return config, err
+	}
+
 	return config, nil
 }
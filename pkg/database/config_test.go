@@ -2,10 +2,12 @@ package database
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	assert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewConfig(t *testing.T) {
@@ -74,3 +76,28 @@ func TestNewConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestNewConfigValidation(t *testing.T) {
+	appRoot := t.TempDir()
+	t.Setenv("APP_ROOT", appRoot)
+	require.NoError(t, os.Mkdir(filepath.Join(appRoot, "config"), 0o700))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(appRoot, "config", "database.yml"),
+		[]byte("test:\n  host: localhost\n  port: 3306\n  pool: 5\n  timeout: 1s\n"),
+		0o600,
+	))
+
+	_, err := NewConfig()
+	require.NoError(t, err)
+
+	t.Setenv("APP_DATABASE_POOL", "-1")
+	t.Setenv("APP_DATABASE_TIMEOUT", "1")
+
+	_, err = NewConfig()
+	require.Error(t, err)
+
+	assert.Equal(t,
+		"database.timeout (APP_DATABASE_TIMEOUT): must be a duration such as 5s, got \"1\"\n"+
+			"database.pool (APP_DATABASE_POOL): must be >= 0",
+		err.Error())
+}
@@ -11,12 +11,47 @@ import (
 	mysqldriver "github.com/go-sql-driver/mysql"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	sdklogger "github.com/scribd/go-sdk/pkg/logger"
+	"github.com/scribd/go-sdk/pkg/metrics"
 )
 
 const testEnv = "test"
 
+// Option configures the connection returned by NewConnection.
+type Option func(*options)
+
+type options struct {
+	logger  sdklogger.Logger
+	metrics metrics.Metrics
+}
+
+// WithLogger logs the queries of the connection with l, following the
+// logging settings of the configuration. The DatabaseLogging middleware and
+// interceptors log the queries of the requests with the request loggers,
+// following the same settings. Without it, the connection keeps the default
+// gorm logger.
+func WithLogger(l sdklogger.Logger) Option {
+	return func(o *options) {
+		o.logger = l
+	}
+}
+
+// WithMetrics sends the metrics of the queries with m, see MetricsPlugin.
+func WithMetrics(m metrics.Metrics) Option {
+	return func(o *options) {
+		o.metrics = m
+	}
+}
+
 // NewConnection returns a new instrumented Gorm database connection.
-func NewConnection(config *Config, environment, appName string) (*gorm.DB, error) {
+func NewConnection(config *Config, environment, appName string, opts ...Option) (*gorm.DB, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	connectionDetails := NewConnectionDetails(config)
 	connectionString := connectionDetails.String()
 	driverName := connectionDetails.Dialect
@@ -43,6 +78,9 @@ func NewConnection(config *Config, environment, appName string) (*gorm.DB, error
 	dialector := mysql.New(mysql.Config{Conn: sqlDB})
 
 	gormConfig := &gorm.Config{}
+	if o.logger != nil {
+		gormConfig.Logger = sdklogger.NewGormLogger(o.logger, GormLoggerOptions(config)...)
+	}
 	if config.DisableDefaultGormTransaction {
 		gormConfig.SkipDefaultTransaction = true
 	}
@@ -55,9 +93,40 @@ func NewConnection(config *Config, environment, appName string) (*gorm.DB, error
 		return nil, err
 	}
 
+	if o.metrics != nil {
+		if err = db.Use(NewMetricsPlugin(o.metrics)); err != nil {
+			return nil, err
+		}
+	}
+
 	return db, nil
 }
 
+// GormLoggerOptions returns the options of the gorm loggers following the
+// logging settings of the configuration.
+func GormLoggerOptions(config *Config) []sdklogger.GormLoggerOption {
+	return []sdklogger.GormLoggerOption{
+		sdklogger.WithGormLogLevel(gormLogLevel(config.LogLevel)),
+		sdklogger.WithSlowQueryThreshold(config.SlowQueryThreshold),
+		sdklogger.WithRecordNotFoundError(config.LogRecordNotFound),
+	}
+}
+
+// gormLogLevel returns the gorm log level of its validated name, Info by
+// default.
+func gormLogLevel(name string) gormlogger.LogLevel {
+	switch name {
+	case "silent":
+		return gormlogger.Silent
+	case "error":
+		return gormlogger.Error
+	case "warn":
+		return gormlogger.Warn
+	default:
+		return gormlogger.Info
+	}
+}
+
 func databasePoolSettings(db *sql.DB, config *Config) {
 	db.SetMaxIdleConns(config.Pool)
 	db.SetMaxOpenConns(config.MaxOpenConnections)
@@ -0,0 +1,95 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/scribd/go-sdk/pkg/metrics"
+)
+
+const (
+	metricsPluginName = "sdk:metrics"
+	metricsStartKey   = "sdk:metrics_start"
+
+	queriesMetric      = "gorm.queries_total"
+	queryErrorsMetric  = "gorm.query_errors_total"
+	queryLatencyMetric = "gorm.query_latency"
+
+	unknownTable = "unknown"
+)
+
+// MetricsPlugin is a gorm plugin sending the count, the errors and the
+// latency, in seconds, of the queries, tagged with their table and their
+// operation: create, query, update, delete, row or raw. The queries finding
+// no record are not counted as errors.
+type MetricsPlugin struct {
+	metrics metrics.Metrics
+}
+
+var _ gorm.Plugin = (*MetricsPlugin)(nil)
+
+// NewMetricsPlugin returns a MetricsPlugin sending the metrics with m, to
+// register with db.Use. NewConnection registers it when given WithMetrics.
+func NewMetricsPlugin(m metrics.Metrics) *MetricsPlugin {
+	return &MetricsPlugin{metrics: m}
+}
+
+// Name implements gorm.Plugin.
+func (p *MetricsPlugin) Name() string {
+	return metricsPluginName
+}
+
+// Initialize implements gorm.Plugin, registering the callbacks measuring
+// the queries.
+func (p *MetricsPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+
+	return errors.Join(
+		cb.Create().Before("*").Register(metricsPluginName+":before_create", p.before),
+		cb.Create().After("*").Register(metricsPluginName+":after_create", p.after("create")),
+		cb.Query().Before("*").Register(metricsPluginName+":before_query", p.before),
+		cb.Query().After("*").Register(metricsPluginName+":after_query", p.after("query")),
+		cb.Update().Before("*").Register(metricsPluginName+":before_update", p.before),
+		cb.Update().After("*").Register(metricsPluginName+":after_update", p.after("update")),
+		cb.Delete().Before("*").Register(metricsPluginName+":before_delete", p.before),
+		cb.Delete().After("*").Register(metricsPluginName+":after_delete", p.after("delete")),
+		cb.Row().Before("*").Register(metricsPluginName+":before_row", p.before),
+		cb.Row().After("*").Register(metricsPluginName+":after_row", p.after("row")),
+		cb.Raw().Before("*").Register(metricsPluginName+":before_raw", p.before),
+		cb.Raw().After("*").Register(metricsPluginName+":after_raw", p.after("raw")),
+	)
+}
+
+func (p *MetricsPlugin) before(db *gorm.DB) {
+	db.InstanceSet(metricsStartKey, time.Now())
+}
+
+func (p *MetricsPlugin) after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(metricsStartKey)
+		if !ok {
+			return
+		}
+
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		table := db.Statement.Table
+		if table == "" {
+			table = unknownTable
+		}
+
+		tags := []string{"table:" + table, "operation:" + operation}
+
+		_ = p.metrics.Incr(queriesMetric, tags, 1)
+		_ = p.metrics.Histogram(queryLatencyMetric, time.Since(start).Seconds(), tags, 1)
+
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			_ = p.metrics.Incr(queryErrorsMetric, tags, 1)
+		}
+	}
+}
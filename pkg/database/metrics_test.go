@@ -0,0 +1,119 @@
+package database
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/scribd/go-sdk/pkg/metrics"
+)
+
+// recordingMetrics records the counts and histograms sent.
+type recordingMetrics struct {
+	metrics.Metrics
+
+	mu         sync.Mutex
+	counts     map[string]int
+	histograms map[string][]float64
+}
+
+func (m *recordingMetrics) Incr(name string, tags []string, _ float64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.counts[metricKey(name, tags)]++
+
+	return nil
+}
+
+func (m *recordingMetrics) Histogram(name string, value float64, tags []string, _ float64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := metricKey(name, tags)
+	m.histograms[key] = append(m.histograms[key], value)
+
+	return nil
+}
+
+func metricKey(name string, tags []string) string {
+	key := name
+	for _, tag := range tags {
+		key += "|" + tag
+	}
+
+	return key
+}
+
+type User struct {
+	ID   int
+	Name string
+}
+
+func TestMetricsPlugin(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	db, err := gorm.Open(
+		mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}),
+		&gorm.Config{Logger: gormlogger.Discard, SkipDefaultTransaction: true},
+	)
+	require.NoError(t, err)
+
+	m := &recordingMetrics{counts: map[string]int{}, histograms: map[string][]float64{}}
+	require.NoError(t, db.Use(NewMetricsPlugin(m)))
+
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Jane"))
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+	mock.ExpectExec("UPDATE").WillReturnError(errors.New("failed"))
+	mock.ExpectExec("DELETE").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, db.First(&User{}).Error)
+	require.ErrorIs(t, db.First(&User{}).Error, gorm.ErrRecordNotFound)
+	require.Error(t, db.Model(&User{ID: 1}).Update("name", "John").Error)
+	require.NoError(t, db.Exec("DELETE FROM users WHERE id = 1").Error)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	assert.Equal(t, map[string]int{
+		"gorm.queries_total|table:users|operation:query":       2,
+		"gorm.queries_total|table:users|operation:update":      1,
+		"gorm.query_errors_total|table:users|operation:update": 1,
+		"gorm.queries_total|table:unknown|operation:raw":       1,
+	}, m.counts)
+
+	assert.Len(t, m.histograms["gorm.query_latency|table:users|operation:query"], 2)
+	assert.Len(t, m.histograms["gorm.query_latency|table:users|operation:update"], 1)
+	assert.Len(t, m.histograms["gorm.query_latency|table:unknown|operation:raw"], 1)
+	for _, values := range m.histograms {
+		for _, value := range values {
+			assert.Positive(t, value)
+		}
+	}
+}
+
+func TestGormLogLevel(t *testing.T) {
+	testCases := []struct {
+		name     string
+		level    string
+		expected gormlogger.LogLevel
+	}{
+		{name: "Default", expected: gormlogger.Info},
+		{name: "Silent", level: "silent", expected: gormlogger.Silent},
+		{name: "Error", level: "error", expected: gormlogger.Error},
+		{name: "Warn", level: "warn", expected: gormlogger.Warn},
+		{name: "Info", level: "info", expected: gormlogger.Info},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, gormLogLevel(tc.level))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	cbuilder "github.com/scribd/go-sdk/internal/pkg/configuration/builder"
+	"github.com/scribd/go-sdk/internal/pkg/configuration/validation"
 )
 
 type Config struct {
@@ -18,10 +19,20 @@ type Config struct {
 	RuntimeMetricsEnabled bool `mapstructure:"runtime_metrics_enabled"`
 }
 
+func init() {
+	cbuilder.Register("instrumentation", func(loader cbuilder.Loader) (any, error) {
+		return newConfig(loader)
+	})
+}
+
 // NewConfig returns a new ServerConfig instance.
 func NewConfig() (*Config, error) {
+	return newConfig(cbuilder.Loader{})
+}
+
+func newConfig(loader cbuilder.Loader) (*Config, error) {
 	config := &Config{}
-	viperBuilder := cbuilder.New("datadog")
+	viperBuilder := loader.New("datadog")
 
 	vConf, err := viperBuilder.Build()
 	if err != nil {
@@ -32,6 +43,10 @@ func NewConfig() (*Config, error) {
 		return config, fmt.Errorf("unable to decode into struct: %s", err.Error())
 	}
 
+	if err = validation.Validate("datadog", config); err != nil {
+		return config, err
+	}
+
 	config.environment = vConf.GetString("ENV")
 
 	return config, nil
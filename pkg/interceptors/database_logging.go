@@ -34,7 +34,7 @@ func DatabaseLoggingUnaryServerInterceptor() grpc.UnaryServerInterceptor {
 		}
 
 		newDB := db.Session(&gorm.Session{
-			Logger: sdklogger.NewGormLogger(l),
+			Logger: sdklogger.NewGormLoggerFrom(db.Logger, l),
 			NewDB:  true,
 		})
 
@@ -66,7 +66,7 @@ func DatabaseLoggingStreamServerInterceptor() grpc.StreamServerInterceptor {
 		}
 
 		newDB := db.Session(&gorm.Session{
-			Logger: sdklogger.NewGormLogger(l),
+			Logger: sdklogger.NewGormLoggerFrom(db.Logger, l),
 			NewDB:  true,
 		})
 
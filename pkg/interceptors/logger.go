@@ -2,7 +2,6 @@ package interceptors
 
 import (
 	"context"
-	"path"
 	"time"
 
 	grpcmiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
@@ -11,9 +10,8 @@ import (
 	grpcstatus "google.golang.org/grpc/status"
 
 	sdkcontext "github.com/scribd/go-sdk/pkg/context/logger"
-	sdkrequestidcontext "github.com/scribd/go-sdk/pkg/context/requestid"
-	sdkinstrumentation "github.com/scribd/go-sdk/pkg/instrumentation"
 	sdklogger "github.com/scribd/go-sdk/pkg/logger"
+	"github.com/scribd/go-sdk/pkg/tracking"
 )
 
 // LoggerUnaryServerInterceptor returns a unary server interceptors that adds the sdklogger.Logger to the context.
@@ -63,27 +61,16 @@ func newLoggerForCall(
 	method string,
 	startTime time.Time,
 ) context.Context {
-	logContext := sdkinstrumentation.TraceLogs(ctx)
+	ctx = tracking.WithRequestHub(ctx, method)
 
-	requestID, err := sdkrequestidcontext.Extract(ctx)
-	if err != nil {
-		logger.WithFields(sdklogger.Fields{
-			"error": err.Error(),
-		}).Tracef("Could not retrieve request id from the context")
-	}
-
-	callLog := logger.WithFields(
+	service, m := splitMethod(method)
+	callLog := sdklogger.WithContext(logger, ctx).WithFields(
 		sdklogger.Fields{
 			"system":          "grpc",
 			"span.kind":       "server",
-			"grpc.service":    path.Dir(method)[1:],
-			"grpc.method":     path.Base(method),
-			"grpc.request_id": requestID,
+			"grpc.service":    service,
+			"grpc.method":     m,
 			"grpc.start_time": startTime.Format(time.RFC3339),
-			"dd": sdklogger.Fields{
-				"trace_id": logContext.TraceID,
-				"span_id":  logContext.SpanID,
-			},
 		})
 
 	if d, ok := ctx.Deadline(); ok {
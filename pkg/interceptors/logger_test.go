@@ -8,8 +8,10 @@ import (
 	golog "log"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/DataDog/dd-trace-go/v2/ddtrace/mocktracer"
+	"github.com/getsentry/sentry-go"
 	grpc_testing "github.com/grpc-ecosystem/go-grpc-middleware/testing"
 	mwitkow_testproto "github.com/grpc-ecosystem/go-grpc-middleware/testing/testproto"
 	"github.com/stretchr/testify/assert"
@@ -19,6 +21,7 @@ import (
 	"google.golang.org/grpc/test/bufconn"
 
 	sdklogger "github.com/scribd/go-sdk/pkg/logger"
+	"github.com/scribd/go-sdk/pkg/logger/loggertest"
 )
 
 var (
@@ -132,6 +135,44 @@ func TestLoggerStreamServerInterceptors(t *testing.T) {
 	checkLoggerFields(t, fieldsStream)
 }
 
+func TestLoggerUnaryServerInterceptorWithRecordingLogger(t *testing.T) {
+	l := loggertest.New()
+
+	lis := bufconn.Listen(bufSize)
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		RequestIDUnaryServerInterceptor(),
+		LoggerUnaryServerInterceptor(l),
+	))
+	mwitkow_testproto.RegisterTestServiceServer(s, &grpc_testing.TestPingService{T: t})
+	go func() {
+		if serveErr := s.Serve(lis); serveErr != nil {
+			golog.Fatalf("Server exited with error: %v", serveErr)
+		}
+	}()
+	defer s.Stop()
+
+	conn, err := grpc.NewClient("passthrough://bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := mwitkow_testproto.NewTestServiceClient(conn)
+	_, err = client.PingError(context.Background(), &mwitkow_testproto.PingRequest{Value: "test", ErrorCodeReturned: 5})
+	assert.Error(t, err)
+
+	l.AssertLogged(t, sdklogger.Info, "", sdklogger.Fields{
+		"span.kind":    "server",
+		"grpc.service": "mwitkow.testproto.TestService",
+		"grpc.method":  "PingError",
+		"grpc.code":    "NotFound",
+	})
+	require.Len(t, l.Entries(), 1)
+	assert.NotEmpty(t, l.Entries()[0].Fields["request_id"])
+}
+
 func getLogger(logLevel string, buf *bytes.Buffer) (sdklogger.Logger, error) {
 	config := &sdklogger.Config{
 		ConsoleEnabled:    true,
@@ -154,10 +195,21 @@ func checkLoggerFields(t *testing.T, fields map[string]any) {
 	assert.NotEmpty(t, fields["grpc.start_time"])
 	assert.NotEmpty(t, fields["grpc.code"])
 	assert.NotEmpty(t, fields["grpc.time_ms"])
-	assert.NotEmpty(t, fields["grpc.request_id"])
+	assert.NotEmpty(t, fields["request_id"])
 
 	var dd = (fields["dd"]).(map[string]any)
 
 	assert.NotEmpty(t, dd["trace_id"])
 	assert.NotEmpty(t, dd["span_id"])
 }
+
+func TestNewLoggerForCallRequestHub(t *testing.T) {
+	ctx := newLoggerForCall(context.Background(), loggertest.New(), "/mwitkow.testproto.TestService/Ping", time.Now())
+
+	hub := sentry.GetHubFromContext(ctx)
+	require.NotNil(t, hub)
+	assert.NotSame(t, sentry.CurrentHub(), hub)
+
+	event := hub.Scope().ApplyToEvent(&sentry.Event{}, nil, nil)
+	assert.Equal(t, "/mwitkow.testproto.TestService/Ping", event.Tags["route"])
+}
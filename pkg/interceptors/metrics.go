@@ -2,14 +2,32 @@ package interceptors
 
 import (
 	"context"
+	"errors"
+	"io"
+	"path"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	grpcmiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/status"
 
 	sdkcontext "github.com/scribd/go-sdk/pkg/context/metrics"
 	sdkmetrics "github.com/scribd/go-sdk/pkg/metrics"
 )
 
+const (
+	serverMetricsPrefix = "grpc.server"
+	clientMetricsPrefix = "grpc.client"
+
+	callsMetric            = ".calls_total"
+	callDurationMetric     = ".call_duration"
+	messagesSentMetric     = ".stream_messages_sent"
+	messagesReceivedMetric = ".stream_messages_received"
+)
+
 // MetricsUnaryServerInterceptor returns a unary server interceptors that adds sdkmetrics.Metrics to the context.
 func MetricsUnaryServerInterceptor(metrics sdkmetrics.Metrics) grpc.UnaryServerInterceptor {
 	return func(
@@ -38,3 +56,207 @@ func MetricsStreamServerInterceptor(metrics sdkmetrics.Metrics) grpc.StreamServe
 		return handler(srv, wrapped)
 	}
 }
+
+// CallMetricsUnaryServerInterceptor returns a unary server interceptor that records the
+// grpc.server.calls_total count and the grpc.server.call_duration distribution of the calls, see
+// callReporter.
+func CallMetricsUnaryServerInterceptor(metrics sdkmetrics.Metrics) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		reporter := newCallReporter(metrics, serverMetricsPrefix, info.FullMethod, false)
+
+		resp, err := handler(ctx, req)
+
+		reporter.report(err)
+
+		return resp, err
+	}
+}
+
+// CallMetricsStreamServerInterceptor returns a streaming server interceptor that records the
+// grpc.server.calls_total count, the grpc.server.call_duration distribution and the
+// grpc.server.stream_messages_sent and grpc.server.stream_messages_received histograms of the
+// streams, see callReporter.
+func CallMetricsStreamServerInterceptor(metrics sdkmetrics.Metrics) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		reporter := newCallReporter(metrics, serverMetricsPrefix, info.FullMethod, true)
+
+		err := handler(srv, &metricsServerStream{ServerStream: stream, reporter: reporter})
+
+		reporter.report(err)
+
+		return err
+	}
+}
+
+// CallMetricsUnaryClientInterceptor returns a unary client interceptor that records the
+// grpc.client.calls_total count and the grpc.client.call_duration distribution of the calls, see
+// callReporter.
+func CallMetricsUnaryClientInterceptor(metrics sdkmetrics.Metrics) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		reporter := newCallReporter(metrics, clientMetricsPrefix, method, false)
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		reporter.report(err)
+
+		return err
+	}
+}
+
+// CallMetricsStreamClientInterceptor returns a streaming client interceptor that records the
+// grpc.client.calls_total count, the grpc.client.call_duration distribution and the
+// grpc.client.stream_messages_sent and grpc.client.stream_messages_received histograms of the
+// streams, see callReporter. A stream is recorded once it's done: when it has received its last
+// message or failed.
+func CallMetricsStreamClientInterceptor(metrics sdkmetrics.Metrics) grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		reporter := newCallReporter(metrics, clientMetricsPrefix, method, true)
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			reporter.report(err)
+			return nil, err
+		}
+
+		return &metricsClientStream{ClientStream: stream, desc: desc, reporter: reporter}, nil
+	}
+}
+
+// callReporter records the metrics of a call, prefixed with grpc.server or grpc.client:
+//
+//   - calls_total, the count of the calls,
+//   - call_duration, the distribution of their duration, in seconds,
+//   - stream_messages_sent and stream_messages_received, the histograms of the count of the
+//     messages sent and received by the streams.
+//
+// They are tagged with the grpc_service and the grpc_method of the call, and the grpc_code of its
+// status, such as "NotFound".
+type callReporter struct {
+	metrics sdkmetrics.Metrics
+	prefix  string
+	tags    []string
+	stream  bool
+	start   time.Time
+
+	sent     atomic.Int64
+	received atomic.Int64
+	once     sync.Once
+}
+
+func newCallReporter(metrics sdkmetrics.Metrics, prefix, fullMethod string, stream bool) *callReporter {
+	service, method := splitMethod(fullMethod)
+
+	return &callReporter{
+		metrics: metrics,
+		prefix:  prefix,
+		tags:    []string{"grpc_service:" + service, "grpc_method:" + method},
+		stream:  stream,
+		start:   time.Now(),
+	}
+}
+
+// report records the metrics of the call, once, with the status of the error.
+func (r *callReporter) report(err error) {
+	r.once.Do(func() {
+		tags := append(slices.Clone(r.tags), "grpc_code:"+status.Code(err).String())
+
+		_ = r.metrics.Incr(r.prefix+callsMetric, tags, 1)
+		_ = r.metrics.Distribution(r.prefix+callDurationMetric, time.Since(r.start).Seconds(), tags, 1)
+
+		if r.stream {
+			_ = r.metrics.Histogram(r.prefix+messagesSentMetric, float64(r.sent.Load()), tags, 1)
+			_ = r.metrics.Histogram(r.prefix+messagesReceivedMetric, float64(r.received.Load()), tags, 1)
+		}
+	})
+}
+
+// metricsServerStream counts the messages sent and received by a server stream.
+type metricsServerStream struct {
+	grpc.ServerStream
+	reporter *callReporter
+}
+
+func (s *metricsServerStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.reporter.sent.Add(1)
+	}
+
+	return err
+}
+
+func (s *metricsServerStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.reporter.received.Add(1)
+	}
+
+	return err
+}
+
+// metricsClientStream counts the messages sent and received by a client stream, and records its
+// metrics once it's done.
+type metricsClientStream struct {
+	grpc.ClientStream
+	desc     *grpc.StreamDesc
+	reporter *callReporter
+}
+
+func (s *metricsClientStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		s.reporter.sent.Add(1)
+	}
+
+	return err
+}
+
+func (s *metricsClientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case errors.Is(err, io.EOF):
+		// The server closed the stream with an OK status.
+		s.reporter.report(nil)
+	case err != nil:
+		s.reporter.report(err)
+	default:
+		s.reporter.received.Add(1)
+
+		// The streams of a single response are done once it's received.
+		if !s.desc.ServerStreams {
+			s.reporter.report(nil)
+		}
+	}
+
+	return err
+}
+
+// splitMethod returns the service and the method of the full method of a call, such as
+// "/package.Service/Method".
+func splitMethod(fullMethod string) (string, string) {
+	return path.Dir(fullMethod)[1:], path.Base(fullMethod)
+}
@@ -0,0 +1,197 @@
+package interceptors
+
+import (
+	"context"
+	"io"
+	golog "log"
+	"net"
+	"testing"
+	"time"
+
+	grpc_testing "github.com/grpc-ecosystem/go-grpc-middleware/testing"
+	mwitkow_testproto "github.com/grpc-ecosystem/go-grpc-middleware/testing/testproto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/scribd/go-sdk/pkg/metrics/metricstest"
+)
+
+var (
+	pingTags       = []string{"grpc_service:mwitkow.testproto.TestService", "grpc_method:Ping", "grpc_code:OK"}
+	pingErrorTags  = []string{"grpc_service:mwitkow.testproto.TestService", "grpc_method:PingError", "grpc_code:NotFound"}
+	pingListTags   = []string{"grpc_service:mwitkow.testproto.TestService", "grpc_method:PingList", "grpc_code:OK"}
+	pingStreamTags = []string{"grpc_service:mwitkow.testproto.TestService", "grpc_method:PingStream", "grpc_code:OK"}
+)
+
+// newCallMetricsClient serves the ping service with the call metrics server
+// interceptors recording with the server metrics, and returns a client of it
+// with the call metrics client interceptors recording with the client metrics.
+func newCallMetricsClient(t *testing.T, server, client *metricstest.Recorder) mwitkow_testproto.TestServiceClient {
+	lis := bufconn.Listen(bufSize)
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(CallMetricsUnaryServerInterceptor(server)),
+		grpc.StreamInterceptor(CallMetricsStreamServerInterceptor(server)),
+	)
+	mwitkow_testproto.RegisterTestServiceServer(s, &grpc_testing.TestPingService{T: t})
+	go func() {
+		if serveErr := s.Serve(lis); serveErr != nil {
+			golog.Fatalf("Server exited with error: %v", serveErr)
+		}
+	}()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough://bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(CallMetricsUnaryClientInterceptor(client)),
+		grpc.WithStreamInterceptor(CallMetricsStreamClientInterceptor(client)))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return mwitkow_testproto.NewTestServiceClient(conn)
+}
+
+func TestCallMetricsUnaryInterceptors(t *testing.T) {
+	server, client := metricstest.New(), metricstest.New()
+	c := newCallMetricsClient(t, server, client)
+
+	_, err := c.Ping(context.Background(), goodPing)
+	require.NoError(t, err)
+
+	_, err = c.PingError(context.Background(), &mwitkow_testproto.PingRequest{
+		ErrorCodeReturned: uint32(codes.NotFound),
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	for prefix, metrics := range map[string]*metricstest.Recorder{"grpc.server": server, "grpc.client": client} {
+		t.Run(prefix, func(t *testing.T) {
+			assert.Eventually(t, func() bool {
+				return metrics.Sum(prefix+".calls_total", pingErrorTags...) == 1
+			}, time.Second, time.Millisecond)
+
+			metrics.AssertSum(t, prefix+".calls_total", pingTags, 1)
+			assert.Len(t, metrics.Values(prefix+".call_duration", pingTags...), 1)
+			assert.Len(t, metrics.Values(prefix+".call_duration", pingErrorTags...), 1)
+
+			// The messages are recorded for the streams only.
+			metrics.AssertNotEmitted(t, prefix+".stream_messages_sent", pingTags)
+		})
+	}
+}
+
+func TestCallMetricsStreamInterceptors(t *testing.T) {
+	server, client := metricstest.New(), metricstest.New()
+	c := newCallMetricsClient(t, server, client)
+
+	list, err := c.PingList(context.Background(), goodPing)
+	require.NoError(t, err)
+	for {
+		_, err = list.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+
+	stream, err := c.PingStream(context.Background())
+	require.NoError(t, err)
+	for range 3 {
+		require.NoError(t, stream.Send(goodPing))
+		_, err = stream.Recv()
+		require.NoError(t, err)
+	}
+	require.NoError(t, stream.CloseSend())
+	_, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+
+	assert.Eventually(t, func() bool {
+		return server.Sum("grpc.server.calls_total", pingListTags...) == 1 &&
+			server.Sum("grpc.server.calls_total", pingStreamTags...) == 1
+	}, time.Second, time.Millisecond)
+
+	testCases := []struct {
+		name         string
+		metrics      *metricstest.Recorder
+		prefix       string
+		tags         []string
+		wantSent     float64
+		wantReceived float64
+	}{
+		{
+			name:         "ServerList",
+			metrics:      server,
+			prefix:       "grpc.server",
+			tags:         pingListTags,
+			wantSent:     grpc_testing.ListResponseCount,
+			wantReceived: 1,
+		},
+		{
+			name:         "ServerStream",
+			metrics:      server,
+			prefix:       "grpc.server",
+			tags:         pingStreamTags,
+			wantSent:     3,
+			wantReceived: 3,
+		},
+		{
+			name:         "ClientList",
+			metrics:      client,
+			prefix:       "grpc.client",
+			tags:         pingListTags,
+			wantSent:     1,
+			wantReceived: grpc_testing.ListResponseCount,
+		},
+		{
+			name:         "ClientStream",
+			metrics:      client,
+			prefix:       "grpc.client",
+			tags:         pingStreamTags,
+			wantSent:     3,
+			wantReceived: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metrics.AssertSum(t, tc.prefix+".calls_total", tc.tags, 1)
+			assert.Len(t, tc.metrics.Values(tc.prefix+".call_duration", tc.tags...), 1)
+			assert.Equal(t, []float64{tc.wantSent}, tc.metrics.Values(tc.prefix+".stream_messages_sent", tc.tags...))
+			assert.Equal(t, []float64{tc.wantReceived},
+				tc.metrics.Values(tc.prefix+".stream_messages_received", tc.tags...))
+		})
+	}
+}
+
+func TestCallMetricsStreamClientInterceptorError(t *testing.T) {
+	server, client := metricstest.New(), metricstest.New()
+	c := newCallMetricsClient(t, server, client)
+
+	list, err := c.PingList(context.Background(), &mwitkow_testproto.PingRequest{
+		ErrorCodeReturned: uint32(codes.NotFound),
+	})
+	require.NoError(t, err)
+
+	_, err = list.Recv()
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// The stream is recorded once.
+	_, _ = list.Recv()
+
+	tags := []string{"grpc_service:mwitkow.testproto.TestService", "grpc_method:PingList", "grpc_code:NotFound"}
+	client.AssertSum(t, "grpc.client.calls_total", tags, 1)
+	assert.Equal(t, []float64{0}, client.Values("grpc.client.stream_messages_received", tags...))
+}
+
+func TestSplitMethod(t *testing.T) {
+	service, method := splitMethod("/mwitkow.testproto.TestService/Ping")
+
+	assert.Equal(t, "mwitkow.testproto.TestService", service)
+	assert.Equal(t, "Ping", method)
+}
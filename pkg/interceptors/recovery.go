@@ -2,42 +2,142 @@ package interceptors
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	stdlog "log"
 	"runtime/debug"
+	"time"
 
 	grpcrecovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
+	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	sdkloggercontext "github.com/scribd/go-sdk/pkg/context/logger"
+	sdkmetricscontext "github.com/scribd/go-sdk/pkg/context/metrics"
+	sdklogger "github.com/scribd/go-sdk/pkg/logger"
+	sdkmetrics "github.com/scribd/go-sdk/pkg/metrics"
+	"github.com/scribd/go-sdk/pkg/tracking"
 )
 
+const (
+	// recoveryFlushTimeout is the time given to the reporter to send the
+	// panic, before the service halts.
+	recoveryFlushTimeout = time.Second * 5
+
+	// panicsMetric counts the panics recovered, tagged with the service
+	// and the method of the call.
+	panicsMetric = "grpc.server.panics_total"
+)
+
+// recoveryOptions are the optional parameters of the recovery interceptors.
+type recoveryOptions struct {
+	reporter tracking.Reporter
+	metrics  sdkmetrics.Metrics
+	fatal    bool
+}
+
+// RecoveryOption sets an optional parameter for the recovery interceptors.
+type RecoveryOption func(*recoveryOptions)
+
+// RecoveryReporter is used to report the panics with the reporter, instead of
+// the tracking hook of the logger of the call.
+func RecoveryReporter(reporter tracking.Reporter) RecoveryOption {
+	return func(o *recoveryOptions) { o.reporter = reporter }
+}
+
+// RecoveryMetrics is used to count the panics with the metrics client,
+// instead of the one of the call context set by the metrics interceptors.
+func RecoveryMetrics(metrics sdkmetrics.Metrics) RecoveryOption {
+	return func(o *recoveryOptions) { o.metrics = metrics }
+}
+
+// RecoveryFatal makes the interceptors log the panics in fatal level, which
+// halts the service, instead of returning a codes.Internal error.
+func RecoveryFatal() RecoveryOption {
+	return func(o *recoveryOptions) { o.fatal = true }
+}
+
 // RecoveryUnaryServerInterceptor returns a unary server interceptor that recovers from panics,
-// sends a sentry event, log in fatal level and halts the service.
+// logs them in error level with their stack trace, which sends a sentry event, counts them and
+// returns a codes.Internal error. With RecoveryFatal, it logs in fatal level and halts the service.
 // IMPORTANT: This interceptor should be the last one in the interceptor chain.
-func RecoveryUnaryServerInterceptor() grpc.UnaryServerInterceptor {
-	return grpcrecovery.UnaryServerInterceptor(recoveryOption...)
+func RecoveryUnaryServerInterceptor(opts ...RecoveryOption) grpc.UnaryServerInterceptor {
+	return grpcrecovery.UnaryServerInterceptor(recoveryHandler(opts))
 }
 
 // RecoveryStreamServerInterceptor returns a streaming server interceptor that recovers from panics,
-// sends a sentry event, log in fatal level and halts the service.
+// logs them in error level with their stack trace, which sends a sentry event, counts them and
+// returns a codes.Internal error. With RecoveryFatal, it logs in fatal level and halts the service.
 // IMPORTANT: This interceptor should be the last one in the interceptor chain.
-func RecoveryStreamServerInterceptor() grpc.StreamServerInterceptor {
-	return grpcrecovery.StreamServerInterceptor(recoveryOption...)
+func RecoveryStreamServerInterceptor(opts ...RecoveryOption) grpc.StreamServerInterceptor {
+	return grpcrecovery.StreamServerInterceptor(recoveryHandler(opts))
 }
 
-var recoveryOption = []grpcrecovery.Option{
-	grpcrecovery.WithRecoveryHandlerContext(func(ctx context.Context, rec any) (err error) {
-		l, err := sdkloggercontext.Extract(ctx)
-		if err != nil {
+func recoveryHandler(opts []RecoveryOption) grpcrecovery.Option {
+	o := &recoveryOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return grpcrecovery.WithRecoveryHandlerContext(func(ctx context.Context, rec any) error {
+		stack := debug.Stack()
+		msg := fmt.Sprintf("panic error: %v", rec)
+
+		err, ok := rec.(error)
+		if !ok {
+			err = errors.New(fmt.Sprint(rec))
+		}
+
+		fullMethod, service, method := "unknown", "unknown", "unknown"
+		if m, ok := grpc.Method(ctx); ok {
+			fullMethod = m
+			service, method = splitMethod(m)
+		}
+
+		if o.reporter != nil {
+			level := logrus.ErrorLevel
+			if o.fatal {
+				level = logrus.FatalLevel
+			}
+
+			o.reporter.Capture(ctx, &tracking.EntryError{
+				Level:   level,
+				Message: msg,
+				Fields:  map[string]any{"stack": string(stack)},
+				Err:     err,
+			}, map[string]string{"route": fullMethod})
+
+			if o.fatal {
+				o.reporter.Flush(recoveryFlushTimeout)
+			}
+
+			err = tracking.Reported(err)
+		}
+
+		metrics := o.metrics
+		if metrics == nil {
+			metrics, _ = sdkmetricscontext.Extract(ctx)
+		}
+		if metrics != nil {
+			_ = metrics.Incr(panicsMetric, []string{"grpc_service:" + service, "grpc_method:" + method}, 1)
+		}
+
+		l, lerr := sdkloggercontext.Extract(ctx)
+		switch {
+		case lerr != nil && o.fatal:
 			debug.PrintStack()
-			stdlog.Printf("logger not found in context: %v\n", err)
-			stdlog.Fatalf("grpc: panic error: %v", rec)
+			stdlog.Printf("logger not found in context: %v\n", lerr)
+			stdlog.Fatalf("grpc: %s", msg)
+		case lerr != nil:
+			stdlog.Printf("grpc: %s\n%s", msg, stack)
+		case o.fatal:
+			l.WithError(err).Fatalf("%s", msg)
+		default:
+			l.WithFields(sdklogger.Fields{"stack": string(stack)}).WithError(err).Errorf("%s", msg)
 		}
 
-		l.WithError(fmt.Errorf("%v", rec)).Fatalf("panic error: %v", rec)
 		return status.Errorf(codes.Internal, "")
-	}),
+	})
 }
@@ -0,0 +1,134 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	golog "log"
+	"net"
+	"testing"
+
+	grpc_testing "github.com/grpc-ecosystem/go-grpc-middleware/testing"
+	mwitkow_testproto "github.com/grpc-ecosystem/go-grpc-middleware/testing/testproto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	sdklogger "github.com/scribd/go-sdk/pkg/logger"
+	"github.com/scribd/go-sdk/pkg/logger/loggertest"
+	"github.com/scribd/go-sdk/pkg/metrics/metricstest"
+	"github.com/scribd/go-sdk/pkg/tracking"
+	"github.com/scribd/go-sdk/pkg/tracking/trackingtest"
+)
+
+var errPanic = errors.New("nil map")
+
+// panickingPingService panics on Ping and PingStream.
+type panickingPingService struct {
+	grpc_testing.TestPingService
+}
+
+func (s *panickingPingService) Ping(context.Context, *mwitkow_testproto.PingRequest) (
+	*mwitkow_testproto.PingResponse, error) {
+	panic(errPanic)
+}
+
+func (s *panickingPingService) PingStream(mwitkow_testproto.TestService_PingStreamServer) error {
+	panic(errPanic)
+}
+
+// newRecoveryClient serves the panicking service with the logger and the
+// recovery interceptors, and returns a client of it.
+func newRecoveryClient(t *testing.T, l sdklogger.Logger, opts ...RecoveryOption) mwitkow_testproto.TestServiceClient {
+	lis := bufconn.Listen(bufSize)
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			LoggerUnaryServerInterceptor(l),
+			RecoveryUnaryServerInterceptor(opts...),
+		),
+		grpc.ChainStreamInterceptor(
+			LoggerStreamServerInterceptor(l),
+			RecoveryStreamServerInterceptor(opts...),
+		),
+	)
+	mwitkow_testproto.RegisterTestServiceServer(s, &panickingPingService{
+		TestPingService: grpc_testing.TestPingService{T: t},
+	})
+	go func() {
+		if serveErr := s.Serve(lis); serveErr != nil {
+			golog.Fatalf("Server exited with error: %v", serveErr)
+		}
+	}()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough://bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return mwitkow_testproto.NewTestServiceClient(conn)
+}
+
+func TestRecoveryUnaryServerInterceptor(t *testing.T) {
+	l := loggertest.New()
+	metrics := metricstest.New()
+	client := newRecoveryClient(t, l, RecoveryMetrics(metrics))
+
+	_, err := client.Ping(context.Background(), goodPing)
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	// The service keeps serving.
+	_, err = client.Ping(context.Background(), goodPing)
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	l.AssertLogged(t, sdklogger.Error, "^panic error: nil map$", sdklogger.Fields{"grpc.method": "Ping"})
+	l.AssertCount(t, sdklogger.Fatal, 0)
+
+	entries := l.FilterMessage("^panic error")
+	require.Len(t, entries, 2)
+	assert.ErrorIs(t, entries[0].Err, errPanic)
+	assert.Contains(t, entries[0].Fields["stack"], "panickingPingService")
+
+	metrics.AssertSum(t, "grpc.server.panics_total",
+		[]string{"grpc_service:mwitkow.testproto.TestService", "grpc_method:Ping"}, 2)
+}
+
+func TestRecoveryStreamServerInterceptor(t *testing.T) {
+	l := loggertest.New()
+	reporter := trackingtest.New()
+	client := newRecoveryClient(t, l, RecoveryReporter(reporter))
+
+	stream, err := client.PingStream(context.Background())
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	reporter.AssertCount(t, 1)
+	reporter.AssertCaptured(t, "^panic error: nil map$", map[string]string{
+		"route": "/mwitkow.testproto.TestService/PingStream",
+	})
+	reporter.AssertErrorCaptured(t, errPanic)
+
+	entries := l.FilterMessage("^panic error")
+	require.Len(t, entries, 1)
+	assert.Equal(t, tracking.Reported(errPanic), entries[0].Err)
+}
+
+func TestRecoveryServerInterceptorFatal(t *testing.T) {
+	l := loggertest.New()
+	reporter := trackingtest.New()
+	client := newRecoveryClient(t, l, RecoveryFatal(), RecoveryReporter(reporter))
+
+	_, err := client.Ping(context.Background(), goodPing)
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	l.AssertLogged(t, sdklogger.Fatal, "^panic error: nil map$", nil)
+	assert.Equal(t, 1, reporter.Flushes())
+}
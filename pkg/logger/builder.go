@@ -2,7 +2,9 @@ package logger
 
 import (
 	"bytes"
+	"log/slog"
 
+	"github.com/scribd/go-sdk/pkg/metrics"
 	"github.com/scribd/go-sdk/pkg/tracking"
 )
 
@@ -11,6 +13,11 @@ type Builder struct {
 	config         *Config
 	fields         Fields
 	trackingConfig *tracking.Config
+	reporter       tracking.Reporter
+	levels         *LevelController
+	name           string
+	kafkaProducer  KafkaProducer
+	metrics        metrics.Metrics
 }
 
 // NewBuilder initializes a Logger builder with the given configuration.
@@ -32,36 +39,186 @@ func (b *Builder) SetTracking(trackingConfig *tracking.Config) *Builder {
 	return b
 }
 
-// Build applies the given configuration and returns a Logger instance.
+// SetReporter sets the reporter of the errors, such as a
+// trackingtest.Recorder, instead of the Sentry one of the tracking
+// configuration. The levels and the breadcrumbs follow the tracking
+// configuration, if set. Unlike the Sentry one, the reporter is also used by
+// BuildTestLogger.
+func (b *Builder) SetReporter(reporter tracking.Reporter) *Builder {
+	b.reporter = reporter
+	return b
+}
+
+// SetLevelController sets the controller of the level of the Logger, so that
+// it can be changed at runtime. By default, the Logger has its own
+// controller, with the configured level.
+func (b *Builder) SetLevelController(levels *LevelController) *Builder {
+	b.levels = levels
+	return b
+}
+
+// SetName sets the name of the Logger, which can be given its own level
+// with the LevelController.
+func (b *Builder) SetName(name string) *Builder {
+	b.name = name
+	return b
+}
+
+// SetKafkaProducer sets the producer of the Kafka sinks, such as a
+// pubsub/kafka.Publisher. It's required by the configurations with a Kafka
+// sink.
+func (b *Builder) SetKafkaProducer(producer KafkaProducer) *Builder {
+	b.kafkaProducer = producer
+	return b
+}
+
+// SetMetrics sets the client sending the metrics of the Logger, such as the
+// number of entries suppressed by the sampling.
+func (b *Builder) SetMetrics(m metrics.Metrics) *Builder {
+	b.metrics = m
+	return b
+}
+
+// Build applies the given configuration and returns a Logger instance,
+// implemented with the configured backend. The sinks and the sampling of the
+// Logger are stopped with Close.
 func (b *Builder) Build() (Logger, error) {
-	lLogrus, err := newLogrusLogger(b.config)
+	levels, err := b.levelController()
+	if err != nil {
+		return nil, err
+	}
+
+	sinks, err := newSinks(b.config, b.kafkaProducer)
 	if err != nil {
 		return nil, err
 	}
 
-	logrusEntry := logrusLogEntry{
-		entry: lLogrus.WithFields(convertToLogrusFields(b.fields)),
+	hook, err := b.trackingHook(true)
+	if err != nil {
+		_ = sinks.Close()
+		return nil, err
 	}
 
-	if b.trackingConfig != nil {
-		if err := logrusEntry.setTracking(b.trackingConfig); err != nil {
-			return nil, err
-		}
+	l, err := b.build(levels, sinks, hook)
+	if err != nil {
+		_ = sinks.Close()
+		return nil, err
 	}
 
-	return &logrusEntry, nil
+	sampler := newSampler(b.config.Sampling, hook != nil, b.metrics)
+	if sampler == nil {
+		return l, nil
+	}
+
+	sampler.logger = l
+	sampler.start()
+
+	return l.(samplingLogger).withSampler(sampler), nil
 }
 
 // BuildTestLogger returns a Logger instance that will write into the bytes buffer
 // passed as parameter.
 // BuildTestLogger is only for testing.
 func (b *Builder) BuildTestLogger(out *bytes.Buffer) (Logger, error) {
-	lLogrus, err := newTestLogrusLogger(b.config, out)
+	levels, err := b.levelController()
+	if err != nil {
+		return nil, err
+	}
+
+	hook, err := b.trackingHook(false)
 	if err != nil {
 		return nil, err
 	}
 
-	return &logrusLogEntry{
-		entry: lLogrus.WithFields(convertToLogrusFields(b.fields)),
-	}, nil
+	l, err := b.build(levels, newTestSinks(b.config, out), hook)
+	if err != nil {
+		return nil, err
+	}
+
+	// The summary of the sampling is logged when the Logger is closed.
+	sampler := newSampler(b.config.Sampling, hook != nil, b.metrics)
+	if sampler == nil {
+		return l, nil
+	}
+
+	sampler.logger = l
+
+	return l.(samplingLogger).withSampler(sampler), nil
+}
+
+// samplingLogger is implemented by the loggers that can be sampled.
+type samplingLogger interface {
+	withSampler(s *sampler) Logger
+}
+
+// trackingHook returns the hook reporting the errors with the reporter of
+// the Builder or, if withSentry, with the Sentry reporter of the tracking
+// configuration. It returns nil if the errors are not reported.
+func (b *Builder) trackingHook(withSentry bool) (*tracking.Hook, error) {
+	config := b.trackingConfig
+	if config == nil {
+		config = &tracking.Config{}
+	}
+
+	switch {
+	case b.reporter != nil:
+		return tracking.NewHook(b.reporter, config)
+	case withSentry && b.trackingConfig != nil:
+		return tracking.NewSentryHook(b.trackingConfig)
+	default:
+		return nil, nil
+	}
+}
+
+func (b *Builder) build(levels *LevelController, sinks sinks, hook *tracking.Hook) (Logger, error) {
+	if b.config.Backend == BackendSlog {
+		return b.buildSlogLogger(levels, sinks, hook)
+	}
+
+	lLogrus, err := newLogrusLogger(b.config, sinks)
+	if err != nil {
+		return nil, err
+	}
+
+	logrusEntry := newLogrusLogEntry(lLogrus, b.fields, levels, b.name, sinks)
+
+	if hook != nil {
+		logrusEntry.setTracking(hook)
+	}
+
+	return logrusEntry, nil
+}
+
+func (b *Builder) buildSlogLogger(levels *LevelController, sinks sinks, hook *tracking.Hook) (Logger, error) {
+	var err error
+
+	handler := newSlogHandler(sinks)
+	if hook != nil {
+		handler = newTrackingHandler(handler, hook)
+	}
+
+	if handler, err = withRedaction(handler, b.config); err != nil {
+		return nil, err
+	}
+
+	return newSlogLogger(handler, b.fields, levels, b.name, sinks), nil
+}
+
+// withRedaction wraps the handler with the redaction handler, if the
+// configuration redacts anything.
+func withRedaction(handler slog.Handler, config *Config) (slog.Handler, error) {
+	redactor, err := newRedactor(config.Redaction)
+	if err != nil || redactor == nil {
+		return handler, err
+	}
+
+	return newRedactionHandler(handler, redactor), nil
+}
+
+func (b *Builder) levelController() (*LevelController, error) {
+	if b.levels != nil {
+		return b.levels, nil
+	}
+
+	return NewLevelController(b.config)
 }
@@ -2,11 +2,14 @@ package logger
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 
 	"github.com/scribd/go-sdk/pkg/tracking"
+	"github.com/scribd/go-sdk/pkg/tracking/trackingtest"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var testConfig = &Config{
@@ -151,3 +154,44 @@ func TestBuildTestLogger(t *testing.T) {
 		})
 	}
 }
+
+func TestSetReporter(t *testing.T) {
+	testCases := []struct {
+		name           string
+		trackingConfig *tracking.Config
+		captured       int
+	}{
+		{
+			name:     "WithoutATrackingConfigItReportsTheErrors",
+			captured: 1,
+		},
+		{
+			name:           "WithATrackingConfigItReportsTheConfiguredLevels",
+			trackingConfig: &tracking.Config{Levels: []string{"error", "warn"}},
+			captured:       2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := trackingtest.New()
+			errTimeout := errors.New("timeout")
+
+			var out bytes.Buffer
+			l, err := NewBuilder(testConfig).
+				SetTracking(tc.trackingConfig).
+				SetReporter(recorder).
+				BuildTestLogger(&out)
+			require.NoError(t, err)
+
+			l.Infof("not reported")
+			l.Warnf("slow query")
+			l.WithError(errTimeout).Errorf("query failed")
+
+			recorder.AssertCount(t, tc.captured)
+			recorder.AssertErrorCaptured(t, errTimeout)
+			recorder.AssertCaptured(t, "^query failed$", nil)
+			recorder.AssertNotCaptured(t, "^not reported$", nil)
+		})
+	}
+}
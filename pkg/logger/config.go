@@ -2,42 +2,136 @@ package logger
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path"
+	"reflect"
+	"regexp"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 
 	cbuilder "github.com/scribd/go-sdk/internal/pkg/configuration/builder"
+	"github.com/scribd/go-sdk/internal/pkg/configuration/validation"
+)
+
+// The logger backends.
+const (
+	// BackendLogrus logs with github.com/sirupsen/logrus. It's the default.
+	BackendLogrus = "logrus"
+	// BackendSlog logs with the log/slog package of the standard library.
+	BackendSlog = "slog"
 )
 
 // Config stores the configuration for the logger.
-// For some loggers there can only be one level across writers, for such
-// the level of Console is picked by default.
+//
+// The entries are written to the Sinks, each with its own level and format.
+// Without sinks, they are written to the console and to the file as set by
+// the console_* and file_* settings. The level of the loggers is then the
+// console level, else the file one; with sinks, it's the most verbose level
+// of the sinks.
 type Config struct {
-	ConsoleEnabled    bool   `mapstructure:"console_enabled"`
-	ConsoleJSONFormat bool   `mapstructure:"console_json_format"`
-	ConsoleLevel      string `mapstructure:"console_level"`
-	FileEnabled       bool   `mapstructure:"file_enabled"`
-	FileJSONFormat    bool   `mapstructure:"file_json_format"`
-	FileLevel         string `mapstructure:"file_level"`
-	FileLocation      string `mapstructure:"file_location"`
-	FileName          string `mapstructure:"file_name"`
+	Backend           string          `mapstructure:"backend" validate:"omitempty,oneof=logrus slog"`
+	ConsoleEnabled    bool            `mapstructure:"console_enabled"`
+	ConsoleJSONFormat bool            `mapstructure:"console_json_format"`
+	ConsoleLevel      string          `mapstructure:"console_level" validate:"omitempty,loglevel"`
+	FileEnabled       bool            `mapstructure:"file_enabled"`
+	FileJSONFormat    bool            `mapstructure:"file_json_format"`
+	FileLevel         string          `mapstructure:"file_level" validate:"omitempty,loglevel"`
+	FileLocation      string          `mapstructure:"file_location" validate:"when=FileEnabled,required"`
+	FileName          string          `mapstructure:"file_name" validate:"when=FileEnabled,required"`
+	LevelTTL          time.Duration   `mapstructure:"level_ttl"`
+	Redaction         RedactionConfig `mapstructure:"redaction"`
+	Sinks             []SinkConfig    `mapstructure:"sinks"`
+	Sampling          SamplingConfig  `mapstructure:"sampling"`
+
+	// loader is how the configuration is read, and read again when it's
+	// watched.
+	loader cbuilder.Loader
+}
+
+func init() {
+	validation.Register("loglevel", validateLevel)
+	validation.Register("regexp", validateRegexps)
+
+	cbuilder.Register("logger", func(loader cbuilder.Loader) (any, error) {
+		return newConfig(loader)
+	})
 }
 
 // NewConfig returns a new LoggerConfig instance
 func NewConfig() (*Config, error) {
-	config := &Config{}
-	viperBuilder := cbuilder.New("logger")
+	return newConfig(cbuilder.Loader{})
+}
+
+func newConfig(loader cbuilder.Loader) (*Config, error) {
+	vConf, err := newViperBuilder(loader).Build()
+	if err != nil {
+		return &Config{}, err
+	}
+
+	return decodeConfig(vConf, loader)
+}
+
+// Watch starts watching the logger configuration file. Every time a change
+// to it is loaded, onChange is called with the previous and the new
+// configuration. A change that cannot be loaded is passed to onError, which
+// can be nil, and the previous configuration stays in place.
+func (c *Config) Watch(onChange func(old, new *Config), onError func(error)) (io.Closer, error) {
+	viperBuilder := newViperBuilder(c.loader)
+	if _, err := viperBuilder.Build(); err != nil {
+		return nil, err
+	}
+
+	current := *c
+	return cbuilder.WatchDecoded(viperBuilder, &current, func(vConf *viper.Viper) (*Config, error) {
+		return decodeConfig(vConf, c.loader)
+	}, onChange, onError)
+}
+
+func newViperBuilder(loader cbuilder.Loader) *cbuilder.ViperBuilder {
+	viperBuilder := loader.New("logger")
 
 	viperBuilder.SetDefault("file_location", path.Join(os.Getenv("APP_ROOT"), "log"))
 	viperBuilder.SetDefault("file_name", fmt.Sprintf("%s.log", os.Getenv("APP_ENV")))
 
-	vConf, err := viperBuilder.Build()
-	if err != nil {
-		return config, err
-	}
+	return viperBuilder
+}
 
-	if err = vConf.Unmarshal(config); err != nil {
+func decodeConfig(vConf *viper.Viper, loader cbuilder.Loader) (*Config, error) {
+	// The build hook is for the initial load only, not for the reloads.
+	loader.OnBuild = nil
+
+	config := &Config{loader: loader}
+	if err := vConf.Unmarshal(config); err != nil {
 		return config, fmt.Errorf("unable to decode into struct: %s", err.Error())
 	}
 
+	if err := validation.Validate("logger", config); err != nil {
+		return config, err
+	}
+
 	return config, nil
 }
+
+// validateRegexps checks that the values of the list are valid regular
+// expressions.
+func validateRegexps(value reflect.Value, _ string) error {
+	for i := 0; i < value.Len(); i++ {
+		if _, err := regexp.Compile(value.Index(i).String()); err != nil {
+			return fmt.Errorf("invalid regular expression %q: %w", value.Index(i).String(), err)
+		}
+	}
+
+	return nil
+}
+
+// validateLevel checks that the value is a level known to the logger.
+func validateLevel(value reflect.Value, _ string) error {
+	if _, err := logrus.ParseLevel(value.String()); err != nil {
+		return fmt.Errorf("unknown log level %q", value.String())
+	}
+
+	return nil
+}
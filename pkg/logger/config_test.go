@@ -3,8 +3,13 @@ package logger
 import (
 	"os"
 	"testing"
+	"time"
 
+	"github.com/spf13/viper"
 	assert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cbuilder "github.com/scribd/go-sdk/internal/pkg/configuration/builder"
 )
 
 // TestNewConfig can only validate that, without a config file the configuration
@@ -60,3 +65,137 @@ func TestNewConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeConfigValidation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		settings map[string]any
+		want     string
+	}{
+		{
+			name:     "KnownLevel",
+			settings: map[string]any{"console_level": "warning"},
+		},
+		{
+			name:     "UnknownConsoleLevel",
+			settings: map[string]any{"console_level": "verbose"},
+			want:     `logger.console_level (APP_LOGGER_CONSOLE_LEVEL): unknown log level "verbose"`,
+		},
+		{
+			name:     "SlogBackend",
+			settings: map[string]any{"backend": "slog"},
+		},
+		{
+			name:     "UnknownBackend",
+			settings: map[string]any{"backend": "zap"},
+			want:     `logger.backend (APP_LOGGER_BACKEND): must be one of logrus, slog, got "zap"`,
+		},
+		{
+			name: "Redaction",
+			settings: map[string]any{"redaction": map[string]any{
+				"keys":     []string{"password"},
+				"patterns": []string{`\d{16}`},
+				"mode":     "hash",
+			}},
+		},
+		{
+			name:     "InvalidRedactionPattern",
+			settings: map[string]any{"redaction": map[string]any{"patterns": []string{"(["}}},
+			want:     `logger.redaction.patterns (APP_LOGGER_REDACTION_PATTERNS): invalid regular expression "([": error parsing regexp: missing closing ]: ` + "`[`",
+		},
+		{
+			name:     "UnknownRedactionMode",
+			settings: map[string]any{"redaction": map[string]any{"mode": "drop"}},
+			want:     `logger.redaction.mode (APP_LOGGER_REDACTION_MODE): must be one of mask, hash, got "drop"`,
+		},
+		{
+			name: "Sinks",
+			settings: map[string]any{"sinks": []map[string]any{
+				{"type": "console", "level": "info"},
+				{"type": "file", "json_format": true, "rotation": map[string]any{"max_size": 10, "compress": false}},
+				{"type": "syslog", "network": "tcp", "address": "localhost:514"},
+				{"type": "kafka", "topic": "logs", "buffer_size": 100},
+			}},
+		},
+		{
+			name:     "UnknownSinkType",
+			settings: map[string]any{"sinks": []map[string]any{{"type": "journald"}}},
+			want:     `logger.sinks[0].type: must be one of console, file, syslog, udp, unix, kafka, got "journald"`,
+		},
+		{
+			name:     "UnknownSinkLevel",
+			settings: map[string]any{"sinks": []map[string]any{{"type": "console", "level": "verbose"}}},
+			want:     `logger.sinks[0].level: unknown log level "verbose"`,
+		},
+		{
+			name:     "SinkWithoutAddress",
+			settings: map[string]any{"sinks": []map[string]any{{"type": "console"}, {"type": "udp"}}},
+			want:     "logger.sinks[1].address: is required for the udp sink",
+		},
+		{
+			name:     "SinkWithoutTopic",
+			settings: map[string]any{"sinks": []map[string]any{{"type": "kafka"}}},
+			want:     "logger.sinks[0].topic: is required for the kafka sink",
+		},
+		{
+			name:     "SinkWithUnsupportedNetwork",
+			settings: map[string]any{"sinks": []map[string]any{{"type": "unix", "network": "tcp", "address": "/tmp/log.sock"}}},
+			want:     `logger.sinks[0].network: unsupported network "tcp" for the unix sink`,
+		},
+		{
+			name: "Sampling",
+			settings: map[string]any{"sampling": map[string]any{
+				"logs":             map[string]any{"interval": "1s", "first": 100, "thereafter": 100, "rates": map[string]any{"debug": 0.1}},
+				"tracking":         map[string]any{"interval": "1m", "first": 10},
+				"summary_interval": "30s",
+			}},
+		},
+		{
+			name:     "SamplingRateOfUnknownLevel",
+			settings: map[string]any{"sampling": map[string]any{"logs": map[string]any{"rates": map[string]any{"verbose": 0.5}}}},
+			want:     `logger.sampling.logs.rates (APP_LOGGER_SAMPLING_LOGS_RATES): unknown log level "verbose"`,
+		},
+		{
+			name:     "SamplingRateAboveOne",
+			settings: map[string]any{"sampling": map[string]any{"tracking": map[string]any{"rates": map[string]any{"error": 2}}}},
+			want:     "logger.sampling.tracking.rates (APP_LOGGER_SAMPLING_TRACKING_RATES): rate of error must be between 0 and 1, got 2",
+		},
+		{
+			name:     "NegativeSamplingFirst",
+			settings: map[string]any{"sampling": map[string]any{"logs": map[string]any{"first": -1}}},
+			want:     "logger.sampling.logs.first (APP_LOGGER_SAMPLING_LOGS_FIRST): must be >= 0",
+		},
+		{
+			name:     "FileWithoutName",
+			settings: map[string]any{"console_level": "info", "file_enabled": true, "file_location": "/tmp"},
+			want:     "logger.file_name (APP_LOGGER_FILE_NAME): is required",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vConf := viper.New()
+			for k, v := range tc.settings {
+				vConf.Set(k, v)
+			}
+
+			_, err := decodeConfig(vConf, cbuilder.Loader{})
+			if tc.want == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Equal(t, tc.want, err.Error())
+		})
+	}
+}
+
+func TestDecodeConfigLevelTTL(t *testing.T) {
+	vConf := viper.New()
+	vConf.Set("level_ttl", "30m")
+
+	c, err := decodeConfig(vConf, cbuilder.Loader{})
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, c.LevelTTL)
+}
@@ -0,0 +1,168 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+
+	sdkrequestidcontext "github.com/scribd/go-sdk/pkg/context/requestid"
+	sdkinstrumentation "github.com/scribd/go-sdk/pkg/instrumentation"
+)
+
+const (
+	// TraceExtractor is the name of the extractor of the Datadog trace
+	// and span IDs, logged as dd.trace_id and dd.span_id.
+	TraceExtractor = "trace"
+	// RequestIDExtractor is the name of the extractor of the request ID,
+	// logged as request_id.
+	RequestIDExtractor = "request_id"
+)
+
+// ContextFieldExtractor returns the fields to log from a context, such as
+// the ID of the user or the tenant of the request. It returns nil when the
+// context has none.
+type ContextFieldExtractor func(ctx context.Context) Fields
+
+var (
+	extractorsMu sync.RWMutex
+	extractors   = map[string]ContextFieldExtractor{
+		TraceExtractor:     extractTrace,
+		RequestIDExtractor: extractRequestID,
+	}
+)
+
+// RegisterContextFieldExtractor registers the extractor under the given
+// name, replacing any extractor previously registered with it. The
+// extractors are called by the *Context methods of the loggers every time
+// an entry is logged.
+func RegisterContextFieldExtractor(name string, extractor ContextFieldExtractor) {
+	extractorsMu.Lock()
+	defer extractorsMu.Unlock()
+
+	extractors[name] = extractor
+}
+
+// UnregisterContextFieldExtractor removes the extractor registered under the
+// given name, if any.
+func UnregisterContextFieldExtractor(name string) {
+	extractorsMu.Lock()
+	defer extractorsMu.Unlock()
+
+	delete(extractors, name)
+}
+
+// ContextFields returns the fields of every registered extractor for the
+// context. The extractors are called in the lexical order of their names; a
+// field returned by several extractors gets the value of the last one.
+func ContextFields(ctx context.Context) Fields {
+	extractorsMu.RLock()
+	defer extractorsMu.RUnlock()
+
+	fields := Fields{}
+	for _, name := range slices.Sorted(maps.Keys(extractors)) {
+		maps.Copy(fields, extractors[name](ctx))
+	}
+
+	return fields
+}
+
+// WithContext returns a Logger that logs the fields extracted from ctx with
+// every entry. The fields are extracted when the entries are logged, so that
+// they reflect the values ctx holds at that time. The *Context methods of
+// the returned Logger extract the fields from the context they are given
+// instead.
+func WithContext(l Logger, ctx context.Context) Logger {
+	if cl, ok := l.(*contextLogger); ok {
+		l = cl.Logger
+	}
+
+	return &contextLogger{Logger: l, ctx: ctx}
+}
+
+// contextLogger is a Logger bound to a context.
+type contextLogger struct {
+	Logger
+	ctx context.Context
+}
+
+func (l *contextLogger) Tracef(format string, args ...any) {
+	l.TraceContext(l.ctx, format, args...)
+}
+
+func (l *contextLogger) Debugf(format string, args ...any) {
+	l.DebugContext(l.ctx, format, args...)
+}
+
+func (l *contextLogger) Infof(format string, args ...any) {
+	l.InfoContext(l.ctx, format, args...)
+}
+
+func (l *contextLogger) Warnf(format string, args ...any) {
+	l.WarnContext(l.ctx, format, args...)
+}
+
+func (l *contextLogger) Errorf(format string, args ...any) {
+	l.ErrorContext(l.ctx, format, args...)
+}
+
+func (l *contextLogger) Fatalf(format string, args ...any) {
+	l.Logger.WithFields(ContextFields(l.ctx)).Fatalf(format, args...)
+}
+
+func (l *contextLogger) Panicf(format string, args ...any) {
+	l.Logger.WithFields(ContextFields(l.ctx)).Panicf(format, args...)
+}
+
+func (l *contextLogger) WithFields(fields Fields) Logger {
+	return &contextLogger{Logger: l.Logger.WithFields(fields), ctx: l.ctx}
+}
+
+func (l *contextLogger) WithError(err error) Logger {
+	return &contextLogger{Logger: l.Logger.WithError(err), ctx: l.ctx}
+}
+
+func (l *contextLogger) named(name string) Logger {
+	if nl, ok := l.Logger.(namedLogger); ok {
+		return &contextLogger{Logger: nl.named(name), ctx: l.ctx}
+	}
+
+	return l
+}
+
+func (l *contextLogger) loggerName() string {
+	if nl, ok := l.Logger.(namedLogger); ok {
+		return nl.loggerName()
+	}
+
+	return ""
+}
+
+func (l *contextLogger) enabled(level slog.Level) bool {
+	if e, ok := l.Logger.(levelEnabler); ok {
+		return e.enabled(level)
+	}
+
+	return true
+}
+
+func extractTrace(ctx context.Context) Fields {
+	logContext := sdkinstrumentation.TraceLogs(ctx)
+
+	return Fields{
+		"dd": Fields{
+			"trace_id": logContext.TraceID,
+			"span_id":  logContext.SpanID,
+		},
+	}
+}
+
+func extractRequestID(ctx context.Context) Fields {
+	requestID, err := sdkrequestidcontext.Extract(ctx)
+	if err != nil {
+		return nil
+	}
+
+	return Fields{"request_id": requestID}
+}
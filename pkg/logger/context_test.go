@@ -0,0 +1,151 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/DataDog/dd-trace-go/v2/ddtrace/mocktracer"
+	"github.com/DataDog/dd-trace-go/v2/ddtrace/tracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkrequestidcontext "github.com/scribd/go-sdk/pkg/context/requestid"
+)
+
+type tenantKey struct{}
+
+func extractTenant(ctx context.Context) Fields {
+	tenant, ok := ctx.Value(tenantKey{}).(string)
+	if !ok {
+		return nil
+	}
+
+	return Fields{"tenant": tenant}
+}
+
+func TestContextFields(t *testing.T) {
+	RegisterContextFieldExtractor("tenant", extractTenant)
+	defer UnregisterContextFieldExtractor("tenant")
+
+	ctx := sdkrequestidcontext.ToContext(context.Background(), "request-id")
+	ctx = context.WithValue(ctx, tenantKey{}, "acme")
+
+	fields := ContextFields(ctx)
+
+	assert.Equal(t, "request-id", fields["request_id"])
+	assert.Equal(t, "acme", fields["tenant"])
+	assert.Contains(t, fields, "dd")
+
+	UnregisterContextFieldExtractor("tenant")
+	assert.NotContains(t, ContextFields(ctx), "tenant")
+}
+
+func TestContextFieldsOrder(t *testing.T) {
+	RegisterContextFieldExtractor("a", func(context.Context) Fields { return Fields{"key": "a"} })
+	defer UnregisterContextFieldExtractor("a")
+	RegisterContextFieldExtractor("b", func(context.Context) Fields { return Fields{"key": "b"} })
+	defer UnregisterContextFieldExtractor("b")
+
+	assert.Equal(t, "b", ContextFields(context.Background())["key"])
+}
+
+func TestLogContext(t *testing.T) {
+	mt := mocktracer.Start()
+	defer mt.Stop()
+
+	for _, backend := range []string{BackendLogrus, BackendSlog} {
+		t.Run(backend, func(t *testing.T) {
+			config := logConfigForTest(withJSON)
+			config.Backend = backend
+
+			var buffer bytes.Buffer
+			l, err := NewBuilder(config).BuildTestLogger(&buffer)
+			require.NoError(t, err)
+
+			span, ctx := tracer.StartSpanFromContext(context.Background(), "test")
+			defer span.Finish()
+			ctx = sdkrequestidcontext.ToContext(ctx, "request-id")
+
+			l.WithFields(Fields{"id": 42}).InfoContext(ctx, "test %s", "message")
+
+			var fields map[string]any
+			require.NoError(t, json.Unmarshal(buffer.Bytes(), &fields))
+
+			assert.Equal(t, "test message", fields[fieldKeyMsg])
+			assert.Equal(t, float64(42), fields["id"])
+			assert.Equal(t, "request-id", fields["request_id"])
+			require.IsType(t, map[string]any{}, fields["dd"])
+			dd := fields["dd"].(map[string]any)
+			assert.Equal(t, span.Context().TraceID(), dd["trace_id"])
+			assert.Equal(t, float64(span.Context().SpanID()), dd["span_id"])
+		})
+	}
+}
+
+func TestLogContextLevelDisabled(t *testing.T) {
+	RegisterContextFieldExtractor("tenant", func(context.Context) Fields {
+		t.Error("extractor called for a disabled level")
+		return nil
+	})
+	defer UnregisterContextFieldExtractor("tenant")
+
+	for _, backend := range []string{BackendLogrus, BackendSlog} {
+		t.Run(backend, func(t *testing.T) {
+			config := logConfigForTest(withJSON)
+			config.Backend = backend
+			config.ConsoleLevel = "info"
+
+			var buffer bytes.Buffer
+			l, err := NewBuilder(config).BuildTestLogger(&buffer)
+			require.NoError(t, err)
+
+			l.DebugContext(context.Background(), "test message")
+
+			assert.Empty(t, buffer.String())
+		})
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	mt := mocktracer.Start()
+	defer mt.Stop()
+
+	for _, backend := range []string{BackendLogrus, BackendSlog} {
+		t.Run(backend, func(t *testing.T) {
+			config := logConfigForTest(withJSON)
+			config.Backend = backend
+
+			var buffer bytes.Buffer
+			l, err := NewBuilder(config).BuildTestLogger(&buffer)
+			require.NoError(t, err)
+
+			ctx := sdkrequestidcontext.ToContext(context.Background(), "request-id")
+			l = WithContext(l, ctx).WithFields(Fields{"id": 42})
+
+			// The span starts after the logger is created: the *Context
+			// methods log its IDs, the other methods log the bound context.
+			span, spanCtx := tracer.StartSpanFromContext(ctx, "test")
+			defer span.Finish()
+
+			l.Infof("bound")
+			l.InfoContext(spanCtx, "span")
+
+			lines := bytes.Split(bytes.TrimSpace(buffer.Bytes()), []byte("\n"))
+			require.Len(t, lines, 2)
+
+			var bound, withSpan map[string]any
+			require.NoError(t, json.Unmarshal(lines[0], &bound))
+			require.NoError(t, json.Unmarshal(lines[1], &withSpan))
+
+			assert.Equal(t, "request-id", bound["request_id"])
+			assert.Equal(t, float64(42), bound["id"])
+			assert.Equal(t, float64(0), bound["dd"].(map[string]any)["span_id"])
+
+			assert.Equal(t, "request-id", withSpan["request_id"])
+			assert.Equal(t, float64(42), withSpan["id"])
+			assert.Equal(t, float64(span.Context().SpanID()), withSpan["dd"].(map[string]any)["span_id"])
+		})
+	}
+}
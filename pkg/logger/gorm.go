@@ -2,63 +2,194 @@ package logger
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
 	"time"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+
+	sdkrequestidcontext "github.com/scribd/go-sdk/pkg/context/requestid"
 )
 
 const (
 	gormLoggerTraceFieldKey = "sql"
 	gormLoggerMsg           = "gorm DB Logger"
+	gormLoggerSlowQueryMsg  = "gorm DB Logger: slow query"
 )
 
-func NewGormLogger(l Logger) gormLogger {
-	return gormLogger{l}
+// GormLoggerOption configures a gorm logger.
+type GormLoggerOption func(*gormLogger)
+
+// WithGormLogLevel sets the gorm log level of the logger, logger.Info by
+// default: logger.Silent logs nothing, logger.Error the failed queries,
+// logger.Warn the failed and the slow queries and logger.Info all the
+// queries.
+func WithGormLogLevel(level logger.LogLevel) GormLoggerOption {
+	return func(g *gormLogger) {
+		g.level = level
+	}
+}
+
+// WithSlowQueryThreshold logs the queries taking longer than the threshold
+// at Warn level, with the file and line of their caller. Zero, the default,
+// disables the slow query logs.
+func WithSlowQueryThreshold(threshold time.Duration) GormLoggerOption {
+	return func(g *gormLogger) {
+		g.slowThreshold = threshold
+	}
+}
+
+// WithRecordNotFoundError logs gorm.ErrRecordNotFound as the error of the
+// queries finding no record. It is not logged by default, such queries
+// being logged as successful ones.
+func WithRecordNotFoundError(enabled bool) GormLoggerOption {
+	return func(g *gormLogger) {
+		g.logRecordNotFound = enabled
+	}
+}
+
+// NewGormLogger returns a gorm logger logging the queries with l. The
+// queries are logged at Trace level, the slow ones at Warn level and the
+// failed ones at Error level. A nil l logs nothing.
+func NewGormLogger(l Logger, opts ...GormLoggerOption) gormLogger {
+	g := gormLogger{logger: l, level: logger.Info}
+	for _, opt := range opts {
+		opt(&g)
+	}
+
+	return g
+}
+
+// NewGormLoggerFrom returns a gorm logger logging the queries with l, with
+// the settings of base if it is a logger returned by NewGormLogger, such as
+// the logger of a connection returned by database.NewConnection.
+func NewGormLoggerFrom(base logger.Interface, l Logger) gormLogger {
+	g, ok := base.(gormLogger)
+	if !ok {
+		return NewGormLogger(l)
+	}
+
+	g.logger = l
+
+	return g
 }
 
 type gormLogger struct {
-	logger Logger
+	logger            Logger
+	level             logger.LogLevel
+	slowThreshold     time.Duration
+	logRecordNotFound bool
 }
 
-func (g gormLogger) LogMode(logger.LogLevel) logger.Interface {
-	// we ignore changes to the log level.
-	// making changes to logger level causes a change to the root logger.
+// LogMode returns a copy of the logger with the gorm log level changed,
+// leaving the level of the underlying logger unchanged.
+func (g gormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	g.level = level
 	return g
 }
 
 func (g gormLogger) Info(ctx context.Context, msg string, args ...any) {
-	g.logger.Infof(msg, args...)
+	if g.logger != nil && g.level >= logger.Info {
+		g.logger.Infof(msg, args...)
+	}
 }
 
 func (g gormLogger) Warn(ctx context.Context, msg string, args ...any) {
-	g.logger.Warnf(msg, args...)
+	if g.logger != nil && g.level >= logger.Warn {
+		g.logger.Warnf(msg, args...)
+	}
 }
 
 func (g gormLogger) Error(ctx context.Context, msg string, args ...any) {
-	g.logger.WithError(fmt.Errorf(msg, args...)).Errorf(gormLoggerMsg)
+	if g.logger != nil && g.level >= logger.Error {
+		g.logger.WithError(fmt.Errorf(msg, args...)).Errorf(gormLoggerMsg)
+	}
 }
 
+// Trace logs the query at Trace level or, if it is slow, at Warn level and,
+// if it failed, at Error level, depending on the gorm log level.
 func (g gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
-	sql, rows := fc()
+	if g.logger == nil || g.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
 
-	l := g.logger.WithFields(Fields{
-		gormLoggerTraceFieldKey: Fields{
-			"duration":      time.Since(begin),
-			"affected_rows": rows,
-			"sql":           sql,
-		},
-	})
+	if errors.Is(err, gorm.ErrRecordNotFound) && !g.logRecordNotFound {
+		err = nil
+	}
 
-	if err != nil {
-		l.WithError(err).Tracef(gormLoggerMsg)
+	slow := g.slowThreshold > 0 && elapsed > g.slowThreshold
 
+	switch {
+	case err != nil && g.level >= logger.Error:
+	case slow && g.level >= logger.Warn:
+	case g.level >= logger.Info:
+	default:
 		return
 	}
 
-	l.Tracef(gormLoggerMsg)
+	sql, rows := fc()
+
+	fields := Fields{
+		"duration":      elapsed,
+		"affected_rows": rows,
+		"sql":           sql,
+	}
+
+	if slow {
+		fields["caller"] = gormCaller()
+	}
+
+	l := g.logger.WithFields(Fields{gormLoggerTraceFieldKey: fields})
+
+	if slow {
+		if requestID, err := sdkrequestidcontext.Extract(ctx); err == nil {
+			l = l.WithFields(Fields{"request_id": requestID})
+		}
+	}
+
+	switch {
+	case err != nil:
+		l.WithError(err).Errorf(gormLoggerMsg)
+	case slow:
+		l.Warnf(gormLoggerSlowQueryMsg)
+	default:
+		l.Tracef(gormLoggerMsg)
+	}
+}
+
+// gormCallerDepth is the number of frames looked up for the caller of a
+// query, gorm and its plugins calling the logger from deep in the stack.
+const gormCallerDepth = 32
+
+// gormCaller returns the file and line of the caller of the query being
+// logged, the first frame outside of gorm, its drivers and plugins, and
+// this file.
+func gormCaller() string {
+	var pcs [gormCallerDepth]uintptr
+	n := runtime.Callers(2, pcs[:])
+
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.Contains(frame.File, "gorm.io/") && !strings.HasSuffix(frame.File, "/pkg/logger/gorm.go") {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+
+		if !more {
+			return ""
+		}
+	}
 }
 
+// ParamsFilter strips the bind values of the queries, which gorm would
+// otherwise interpolate into the logged SQL. The values inlined in the SQL
+// are redacted like the other fields, see RedactionConfig.
 func (g gormLogger) ParamsFilter(ctx context.Context, sql string, params ...any) (string, []any) {
 	return sql, nil
 }
@@ -5,12 +5,16 @@ import (
 	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	sdkrequestidcontext "github.com/scribd/go-sdk/pkg/context/requestid"
 )
 
 func TestNewGormLogger(t *testing.T) {
@@ -60,8 +64,8 @@ func TestNewGormLogger(t *testing.T) {
 			},
 		},
 		{
-			name:        "Empty on error log level with error",
-			isLogged:    false,
+			name:        "Print on error log level with error",
+			isLogged:    true,
 			resultError: true,
 			cfg: Config{
 				ConsoleEnabled:    true,
@@ -80,8 +84,8 @@ func TestNewGormLogger(t *testing.T) {
 			},
 		},
 		{
-			name:        "Empty on debug log level with error",
-			isLogged:    false,
+			name:        "Print on debug log level with error",
+			isLogged:    true,
 			resultError: true,
 			cfg: Config{
 				ConsoleEnabled:    true,
@@ -146,7 +150,7 @@ func TestNewGormLogger(t *testing.T) {
 				assert.Contains(t, fields["sql"], "affected_rows")
 
 				if tt.resultError {
-					assert.Equal(t, fields["level"], "trace")
+					assert.Equal(t, fields["level"], "error")
 					assert.Contains(t, buffer.String(), "error")
 					assert.Equal(t, fields["error"], testDBErrorMsg)
 				} else {
@@ -164,7 +168,7 @@ func TestNewGormLogger(t *testing.T) {
 	}
 }
 
-func mockGormConnectionWithLogger(t *testing.T, l Logger) (*gorm.DB, sqlmock.Sqlmock) {
+func mockGormConnectionWithLogger(t *testing.T, l Logger, opts ...GormLoggerOption) (*gorm.DB, sqlmock.Sqlmock) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Error(err)
@@ -175,7 +179,7 @@ func mockGormConnectionWithLogger(t *testing.T, l Logger) (*gorm.DB, sqlmock.Sql
 			Conn:                      db,
 			SkipInitializeWithVersion: true,
 		}),
-		&gorm.Config{Logger: NewGormLogger(l)},
+		&gorm.Config{Logger: NewGormLogger(l, opts...)},
 	)
 	if err != nil {
 		t.Error(err)
@@ -183,3 +187,167 @@ func mockGormConnectionWithLogger(t *testing.T, l Logger) (*gorm.DB, sqlmock.Sql
 
 	return mockedDB, mock
 }
+
+func TestGormLoggerRedaction(t *testing.T) {
+	var buffer bytes.Buffer
+
+	l, err := NewBuilder(&Config{
+		ConsoleEnabled:    true,
+		ConsoleJSONFormat: true,
+		ConsoleLevel:      "trace",
+		Redaction:         RedactionConfig{Patterns: []string{`[\w.+-]+@[\w-]+\.\w+`}},
+	}).BuildTestLogger(&buffer)
+	require.NoError(t, err)
+
+	gormDB, mock := mockGormConnectionWithLogger(t, l)
+	mock.ExpectExec("UPDATE users").WillReturnResult(sqlmock.NewResult(1, 1))
+
+	gormDB.Exec("UPDATE users SET name = ? WHERE email = 'jane@example.com'", "Jane")
+
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &fields))
+
+	require.IsType(t, map[string]any{}, fields["sql"])
+	assert.Equal(t,
+		"UPDATE users SET name = ? WHERE email = '[REDACTED]'",
+		fields["sql"].(map[string]any)["sql"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGormLoggerSlowQuery(t *testing.T) {
+	var buffer bytes.Buffer
+
+	l, err := NewBuilder(&Config{
+		ConsoleEnabled:    true,
+		ConsoleJSONFormat: true,
+		ConsoleLevel:      "warn",
+	}).BuildTestLogger(&buffer)
+	require.NoError(t, err)
+
+	gormDB, mock := mockGormConnectionWithLogger(t, l, WithSlowQueryThreshold(time.Nanosecond))
+	mock.ExpectExec("UPDATE users").WillReturnResult(sqlmock.NewResult(1, 2))
+
+	ctx := sdkrequestidcontext.ToContext(t.Context(), "request-1")
+	gormDB.WithContext(ctx).Exec("UPDATE users SET name = 'Jane'")
+
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &fields))
+
+	assert.Equal(t, "warning", fields["level"])
+	assert.Equal(t, gormLoggerSlowQueryMsg, fields["message"])
+	assert.Equal(t, "request-1", fields["request_id"])
+
+	require.IsType(t, map[string]any{}, fields["sql"])
+	sqlFields := fields["sql"].(map[string]any)
+	assert.Equal(t, "UPDATE users SET name = 'Jane'", sqlFields["sql"])
+	assert.EqualValues(t, 2, sqlFields["affected_rows"])
+	assert.Contains(t, sqlFields, "duration")
+	assert.Contains(t, sqlFields["caller"], "gorm_test.go:")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGormLoggerLogMode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		level    logger.LogLevel
+		logSlow  bool
+		logError bool
+	}{
+		{name: "Silent", level: logger.Silent},
+		{name: "Error", level: logger.Error, logError: true},
+		{name: "Warn", level: logger.Warn, logSlow: true, logError: true},
+		{name: "Info", level: logger.Info, logSlow: true, logError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+
+			l, err := NewBuilder(&Config{
+				ConsoleEnabled:    true,
+				ConsoleJSONFormat: true,
+				ConsoleLevel:      "warn",
+			}).BuildTestLogger(&buffer)
+			require.NoError(t, err)
+
+			gormDB, mock := mockGormConnectionWithLogger(t, l, WithSlowQueryThreshold(time.Nanosecond))
+			gormDB = gormDB.Session(&gorm.Session{Logger: gormDB.Logger.LogMode(tc.level)})
+
+			mock.ExpectExec("UPDATE users").WillReturnResult(sqlmock.NewResult(1, 1))
+			gormDB.Exec("UPDATE users SET name = 'Jane'")
+			assert.Equal(t, tc.logSlow, buffer.Len() > 0, "slow query")
+
+			buffer.Reset()
+			mock.ExpectExec("UPDATE users").WillReturnError(errors.New("failed"))
+			gormDB.Exec("UPDATE users SET name = 'Jane'")
+			assert.Equal(t, tc.logError, buffer.Len() > 0, "failed query")
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGormLoggerRecordNotFound(t *testing.T) {
+	type User struct {
+		ID   int
+		Name string
+	}
+
+	testCases := []struct {
+		name          string
+		opts          []GormLoggerOption
+		expectedLevel string
+		expected      any
+	}{
+		{name: "Ignored", expectedLevel: "trace"},
+		{
+			name:          "Logged",
+			opts:          []GormLoggerOption{WithRecordNotFoundError(true)},
+			expectedLevel: "error",
+			expected:      gorm.ErrRecordNotFound.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+
+			l, err := NewBuilder(&Config{
+				ConsoleEnabled:    true,
+				ConsoleJSONFormat: true,
+				ConsoleLevel:      "trace",
+			}).BuildTestLogger(&buffer)
+			require.NoError(t, err)
+
+			gormDB, mock := mockGormConnectionWithLogger(t, l, tc.opts...)
+			mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+			err = gormDB.First(&User{}).Error
+			require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+			var fields map[string]any
+			require.NoError(t, json.Unmarshal(buffer.Bytes(), &fields))
+
+			assert.Equal(t, tc.expectedLevel, fields["level"])
+			assert.Equal(t, tc.expected, fields["error"])
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestNewGormLoggerFrom(t *testing.T) {
+	l, err := NewBuilder(&Config{ConsoleEnabled: true, ConsoleLevel: "info"}).BuildTestLogger(&bytes.Buffer{})
+	require.NoError(t, err)
+
+	base := NewGormLogger(nil, WithSlowQueryThreshold(time.Second)).LogMode(logger.Warn)
+
+	g := NewGormLoggerFrom(base, l)
+	assert.Equal(t, l, g.logger)
+	assert.Equal(t, logger.Warn, g.level)
+	assert.Equal(t, time.Second, g.slowThreshold)
+
+	g = NewGormLoggerFrom(logger.Default, l)
+	assert.Equal(t, l, g.logger)
+	assert.Equal(t, logger.Info, g.level)
+	assert.Zero(t, g.slowThreshold)
+}
@@ -0,0 +1,119 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+
+	"github.com/sirupsen/logrus"
+)
+
+// slogHandler is a slog.Handler writing the records into a Logger.
+type slogHandler struct {
+	logger Logger
+	fields Fields
+	// group is the prefix of the keys of the attributes, with the names of
+	// the open groups joined by dots.
+	group string
+}
+
+// NewSlogHandler returns a slog.Handler that logs the records with l, so
+// that the libraries logging with log/slog share the outputs, the format and
+// the error reporting of the SDK logger:
+//
+//	slog.SetDefault(slog.New(logger.NewSlogHandler(l)))
+//
+// The attributes become fields, with the keys of the attributes of a group
+// prefixed by the group name and a dot. An error attribute with the "error"
+// key is set with WithError. The fields of the registered
+// ContextFieldExtractor are extracted from the context passed to the slog
+// *Context methods. The slog levels are mapped to the closest
+// level at or below them; the levels above Error are logged as Error, so
+// that a library never exits nor panics through the handler.
+func NewSlogHandler(l Logger) slog.Handler {
+	return &slogHandler{logger: l, fields: Fields{}}
+}
+
+// levelEnabler is implemented by the loggers able to tell whether a level is
+// enabled.
+type levelEnabler interface {
+	enabled(level slog.Level) bool
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	if l, ok := h.logger.(levelEnabler); ok {
+		return l.enabled(level)
+	}
+
+	return true
+}
+
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	fields := maps.Clone(h.fields)
+	record.Attrs(func(a slog.Attr) bool {
+		addAttr(fields, h.group, a)
+		return true
+	})
+
+	l := h.logger
+	if err, ok := fields[logrus.ErrorKey].(error); ok {
+		delete(fields, logrus.ErrorKey)
+		l = l.WithError(err)
+	}
+	if len(fields) > 0 {
+		l = l.WithFields(fields)
+	}
+
+	switch toLogrusLevel(record.Level) {
+	case logrus.TraceLevel:
+		l.TraceContext(ctx, "%s", record.Message)
+	case logrus.DebugLevel:
+		l.DebugContext(ctx, "%s", record.Message)
+	case logrus.InfoLevel:
+		l.InfoContext(ctx, "%s", record.Message)
+	case logrus.WarnLevel:
+		l.WarnContext(ctx, "%s", record.Message)
+	default:
+		l.ErrorContext(ctx, "%s", record.Message)
+	}
+
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := maps.Clone(h.fields)
+	for _, a := range attrs {
+		addAttr(fields, h.group, a)
+	}
+
+	return &slogHandler{logger: h.logger, fields: fields, group: h.group}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return &slogHandler{logger: h.logger, fields: h.fields, group: h.group + name + "."}
+}
+
+// addAttr sets the attribute in the fields, flattening the groups.
+func addAttr(fields Fields, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		// The attributes of a group without a key are inlined.
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			addAttr(fields, prefix, ga)
+		}
+		return
+	}
+
+	fields[prefix+a.Key] = a.Value.Any()
+}
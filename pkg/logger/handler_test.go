@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlogHandler(t *testing.T) {
+	for _, backend := range []string{BackendLogrus, BackendSlog} {
+		t.Run(backend, func(t *testing.T) {
+			var buffer bytes.Buffer
+			config := &Config{
+				Backend:           backend,
+				ConsoleEnabled:    true,
+				ConsoleJSONFormat: withJSON,
+				ConsoleLevel:      "info",
+			}
+			l, err := NewBuilder(config).SetFields(Fields{"role": "test"}).BuildTestLogger(&buffer)
+			require.NoError(t, err)
+
+			logger := slog.New(NewSlogHandler(l)).
+				With("component", "library", "error", errors.New("failed")).
+				WithGroup("request")
+
+			logger.Debug("ignored")
+			assert.Empty(t, buffer.String())
+
+			logger.Warn("test %s message", "id", 42, slog.Group("user", "name", "jane"))
+
+			var fields Fields
+			require.NoError(t, json.Unmarshal(buffer.Bytes(), &fields))
+
+			assert.Equal(t, "test %s message", fields[fieldKeyMsg])
+			assert.Equal(t, "warning", fields["level"])
+			assert.NotEmpty(t, fields[fieldKeyTime])
+			assert.Equal(t, "test", fields["role"])
+			assert.Equal(t, "library", fields["component"])
+			assert.Equal(t, float64(42), fields["request.id"])
+			assert.Equal(t, "jane", fields["request.user.name"])
+			assert.Equal(t, "failed", fields["error"])
+		})
+	}
+}
+
+func TestSlogHandlerLevels(t *testing.T) {
+	testCases := []struct {
+		level slog.Level
+		want  string
+	}{
+		{level: slog.LevelDebug - 4, want: "trace"},
+		{level: slog.LevelDebug, want: "debug"},
+		{level: slog.LevelInfo + 1, want: "info"},
+		{level: slog.LevelWarn, want: "warning"},
+		{level: slog.LevelError, want: "error"},
+		// Levels above Error never exit nor panic.
+		{level: slog.LevelError + 8, want: "error"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.want, func(t *testing.T) {
+			var buffer bytes.Buffer
+			l, err := NewBuilder(logConfigForTest(withJSON)).BuildTestLogger(&buffer)
+			require.NoError(t, err)
+
+			slog.New(NewSlogHandler(l)).Log(t.Context(), tc.level, "test message")
+
+			var fields Fields
+			require.NoError(t, json.Unmarshal(buffer.Bytes(), &fields))
+			assert.Equal(t, tc.want, fields["level"])
+		})
+	}
+}
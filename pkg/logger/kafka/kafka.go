@@ -44,20 +44,30 @@ func WithLevelFn(fn func() kgo.LogLevel) Opt {
 
 // WithLevel sets a static level for the kgo.Logger Level function.
 func WithLevel(level logger.Level) Opt {
-	kgoLevel := kgo.LogLevelNone
+	kgoLevel := toKgoLevel(level)
 
+	return WithLevelFn(func() kgo.LogLevel { return kgoLevel })
+}
+
+// WithLevelController makes the kgo.Logger Level function follow the level
+// of the logger of the given name, so that it can be changed at runtime.
+func WithLevelController(levels *logger.LevelController, name string) Opt {
+	return WithLevelFn(func() kgo.LogLevel { return toKgoLevel(levels.NamedLevel(name)) })
+}
+
+func toKgoLevel(level logger.Level) kgo.LogLevel {
 	switch level {
 	case logger.Panic, logger.Error, logger.Fatal:
-		kgoLevel = kgo.LogLevelError
+		return kgo.LogLevelError
 	case logger.Warn:
-		kgoLevel = kgo.LogLevelWarn
+		return kgo.LogLevelWarn
 	case logger.Info:
-		kgoLevel = kgo.LogLevelInfo
+		return kgo.LogLevelInfo
 	case logger.Trace, logger.Debug:
-		kgoLevel = kgo.LogLevelDebug
+		return kgo.LogLevelDebug
+	default:
+		return kgo.LogLevelNone
 	}
-
-	return WithLevelFn(func() kgo.LogLevel { return kgoLevel })
 }
 
 // Level is for the kgo.Logger interface.
@@ -109,3 +109,17 @@ func TestNewKafkaLogger(t *testing.T) {
 		})
 	}
 }
+
+func TestKafkaLoggerLevelController(t *testing.T) {
+	levels, err := logger.NewLevelController(&logger.Config{ConsoleLevel: "warn"})
+	require.NoError(t, err)
+
+	kl := NewKafkaLogger(nil, WithLevelController(levels, "kafka"))
+	assert.Equal(t, kgo.LogLevelWarn, kl.Level())
+
+	require.NoError(t, levels.SetLevel("kafka", logger.Debug, 0))
+	assert.Equal(t, kgo.LogLevelDebug, kl.Level())
+
+	levels.ResetLevel("kafka")
+	assert.Equal(t, kgo.LogLevelWarn, kl.Level())
+}
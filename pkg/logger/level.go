@@ -0,0 +1,264 @@
+package logger
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultLevelTTL is the time after which the levels changed at runtime go
+// back to the configured level, unless the configuration sets another one.
+const defaultLevelTTL = 15 * time.Minute
+
+// LevelController holds the level of the loggers, which can be changed at
+// runtime, for instance by the handlers of the leveladmin package. The
+// loggers built with the same controller share its levels.
+//
+// The level applies to every logger. A named level overrides it for the
+// loggers of that name and of the names below it: a level set for "kafka"
+// applies to the "kafka.consumer" logger too. The changes expire after a
+// TTL, the loggers then going back to the configured level.
+type LevelController struct {
+	// mu serializes the changes; the state is read without locking.
+	mu    sync.Mutex
+	state atomic.Pointer[levelState]
+
+	configured logrus.Level
+	ttl        time.Duration
+	now        func() time.Time
+}
+
+// levelState is an immutable snapshot of the levels set at runtime.
+type levelState struct {
+	level     *levelOverride
+	overrides map[string]levelOverride
+}
+
+type levelOverride struct {
+	level     logrus.Level
+	expiresAt time.Time
+}
+
+func (o *levelOverride) active(now time.Time) bool {
+	return o != nil && now.Before(o.expiresAt)
+}
+
+// LevelOverride is a level set at runtime.
+type LevelOverride struct {
+	Level     Level
+	ExpiresAt time.Time
+}
+
+// LevelSettings describes the levels of a LevelController.
+type LevelSettings struct {
+	// Configured is the level of the configuration.
+	Configured Level
+	// Level is the level of the loggers without a named level. ExpiresAt
+	// is when it goes back to the configured level, zero if it is the
+	// configured level.
+	Level     Level
+	ExpiresAt time.Time
+	// Overrides are the named levels.
+	Overrides map[string]LevelOverride
+}
+
+// NewLevelController returns a LevelController with the level of the
+// configuration, the console one by default.
+func NewLevelController(config *Config) (*LevelController, error) {
+	level, err := logLevel(config)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := config.LevelTTL
+	if ttl <= 0 {
+		ttl = defaultLevelTTL
+	}
+
+	c := &LevelController{configured: level, ttl: ttl, now: time.Now}
+	c.state.Store(&levelState{})
+
+	return c, nil
+}
+
+// Level returns the level of the loggers without a named level.
+func (c *LevelController) Level() Level {
+	return toLevel(c.level(""))
+}
+
+// NamedLevel returns the level of the logger of the given name.
+func (c *LevelController) NamedLevel(name string) Level {
+	return toLevel(c.level(name))
+}
+
+// Enabled reports whether the logger of the given name logs the entries of
+// the level.
+func (c *LevelController) Enabled(name string, level Level) bool {
+	lvl, err := logrus.ParseLevel(string(level))
+	if err != nil {
+		return false
+	}
+
+	return c.enabled(name, lvl)
+}
+
+// SetLevel sets the level of the logger of the given name, or of all the
+// loggers without a named level if name is empty, for ttl. A ttl of zero
+// sets it for the TTL of the configuration.
+func (c *LevelController) SetLevel(name string, level Level, ttl time.Duration) error {
+	lvl, err := logrus.ParseLevel(string(level))
+	if err != nil {
+		return fmt.Errorf("unknown log level %q", level)
+	}
+
+	if ttl <= 0 {
+		ttl = c.ttl
+	}
+
+	override := levelOverride{level: lvl, expiresAt: c.now().Add(ttl)}
+
+	c.update(func(state *levelState) {
+		if name == "" {
+			state.level = &override
+		} else {
+			state.overrides[name] = override
+		}
+	})
+
+	return nil
+}
+
+// ResetLevel sets the logger of the given name, or all the loggers without
+// a named level if name is empty, back to the configured level.
+func (c *LevelController) ResetLevel(name string) {
+	c.update(func(state *levelState) {
+		if name == "" {
+			state.level = nil
+		} else {
+			delete(state.overrides, name)
+		}
+	})
+}
+
+// Settings returns the current levels, the expired ones left out.
+func (c *LevelController) Settings() LevelSettings {
+	now := c.now()
+	state := c.state.Load()
+
+	settings := LevelSettings{
+		Configured: toLevel(c.configured),
+		Level:      toLevel(c.configured),
+		Overrides:  map[string]LevelOverride{},
+	}
+
+	if state.level.active(now) {
+		settings.Level = toLevel(state.level.level)
+		settings.ExpiresAt = state.level.expiresAt
+	}
+
+	for name, override := range state.overrides {
+		if override.active(now) {
+			settings.Overrides[name] = LevelOverride{
+				Level:     toLevel(override.level),
+				ExpiresAt: override.expiresAt,
+			}
+		}
+	}
+
+	return settings
+}
+
+// update applies the change to a copy of the state, dropping the expired
+// overrides.
+func (c *LevelController) update(change func(state *levelState)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	current := c.state.Load()
+
+	state := &levelState{overrides: maps.Clone(current.overrides)}
+	if state.overrides == nil {
+		state.overrides = map[string]levelOverride{}
+	}
+	if current.level.active(now) {
+		state.level = current.level
+	}
+	maps.DeleteFunc(state.overrides, func(_ string, o levelOverride) bool {
+		return !o.active(now)
+	})
+
+	change(state)
+	c.state.Store(state)
+}
+
+func (c *LevelController) enabled(name string, level logrus.Level) bool {
+	return level <= c.level(name)
+}
+
+// level returns the level of the logger of the given name: its named level
+// or the one of the closest name above it, else the level of all loggers.
+func (c *LevelController) level(name string) logrus.Level {
+	state := c.state.Load()
+	if state.level == nil && len(state.overrides) == 0 {
+		return c.configured
+	}
+
+	now := c.now()
+
+	for name != "" && len(state.overrides) > 0 {
+		if override, ok := state.overrides[name]; ok && override.active(now) {
+			return override.level
+		}
+
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+
+	if state.level.active(now) {
+		return state.level.level
+	}
+
+	return c.configured
+}
+
+// toLevel returns the Level of a logrus level, whose name of the Warn level
+// is "warning".
+func toLevel(level logrus.Level) Level {
+	if level == logrus.WarnLevel {
+		return Warn
+	}
+
+	return Level(level.String())
+}
+
+// namedLogger is implemented by the loggers that have a name.
+type namedLogger interface {
+	named(name string) Logger
+	loggerName() string
+}
+
+// Named returns a logger, with the fields of l, that is named name below the
+// name of l: Named(Named(l, "kafka"), "consumer") is named "kafka.consumer".
+// The levels of the named loggers can be set with the LevelController. A
+// logger not built by this package is returned as is.
+func Named(l Logger, name string) Logger {
+	nl, ok := l.(namedLogger)
+	if !ok {
+		return l
+	}
+
+	if parent := nl.loggerName(); parent != "" {
+		name = parent + "." + name
+	}
+
+	return nl.named(name)
+}
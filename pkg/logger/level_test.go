@@ -0,0 +1,193 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLevelController(t *testing.T, level string) (*LevelController, *time.Time) {
+	t.Helper()
+
+	levels, err := NewLevelController(&Config{ConsoleLevel: level, LevelTTL: time.Minute})
+	require.NoError(t, err)
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	levels.now = func() time.Time { return now }
+
+	return levels, &now
+}
+
+func TestNewLevelController(t *testing.T) {
+	t.Run("WithConsoleLevel", func(t *testing.T) {
+		levels, err := NewLevelController(&Config{ConsoleLevel: "warn", FileLevel: "debug"})
+		require.NoError(t, err)
+
+		assert.Equal(t, Warn, levels.Level())
+		assert.Equal(t, defaultLevelTTL, levels.ttl)
+	})
+
+	t.Run("WithFileLevel", func(t *testing.T) {
+		levels, err := NewLevelController(&Config{FileLevel: "debug", LevelTTL: time.Hour})
+		require.NoError(t, err)
+
+		assert.Equal(t, Debug, levels.Level())
+		assert.Equal(t, time.Hour, levels.ttl)
+	})
+
+	t.Run("WithUnknownLevel", func(t *testing.T) {
+		_, err := NewLevelController(&Config{ConsoleLevel: "verbose"})
+		assert.Error(t, err)
+	})
+}
+
+func TestLevelControllerSetLevel(t *testing.T) {
+	levels, now := newTestLevelController(t, "info")
+
+	require.NoError(t, levels.SetLevel("", Debug, 0))
+
+	assert.Equal(t, Debug, levels.Level())
+	assert.Equal(t, Debug, levels.NamedLevel("kafka"))
+	assert.True(t, levels.Enabled("", Debug))
+	assert.False(t, levels.Enabled("", Trace))
+
+	settings := levels.Settings()
+	assert.Equal(t, Info, settings.Configured)
+	assert.Equal(t, Debug, settings.Level)
+	assert.Equal(t, now.Add(time.Minute), settings.ExpiresAt)
+
+	levels.ResetLevel("")
+	assert.Equal(t, Info, levels.Level())
+	assert.True(t, levels.Settings().ExpiresAt.IsZero())
+
+	assert.Error(t, levels.SetLevel("", Level("verbose"), 0))
+}
+
+func TestLevelControllerNamedLevels(t *testing.T) {
+	levels, _ := newTestLevelController(t, "info")
+
+	require.NoError(t, levels.SetLevel("kafka", Trace, 0))
+	require.NoError(t, levels.SetLevel("kafka.producer", Error, 0))
+
+	testCases := []struct {
+		name     string
+		expected Level
+	}{
+		{name: "", expected: Info},
+		{name: "kafka", expected: Trace},
+		{name: "kafka.consumer", expected: Trace},
+		{name: "kafka.producer", expected: Error},
+		{name: "kafka.producer.batch", expected: Error},
+		{name: "kafkaesque", expected: Info},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, levels.NamedLevel(tc.name))
+		})
+	}
+
+	assert.Equal(t, map[string]LevelOverride{
+		"kafka":          {Level: Trace, ExpiresAt: levels.now().Add(time.Minute)},
+		"kafka.producer": {Level: Error, ExpiresAt: levels.now().Add(time.Minute)},
+	}, levels.Settings().Overrides)
+
+	levels.ResetLevel("kafka.producer")
+	assert.Equal(t, Trace, levels.NamedLevel("kafka.producer"))
+}
+
+func TestLevelControllerTTL(t *testing.T) {
+	levels, now := newTestLevelController(t, "info")
+
+	require.NoError(t, levels.SetLevel("", Debug, 0))
+	require.NoError(t, levels.SetLevel("kafka", Trace, time.Hour))
+
+	*now = now.Add(time.Minute)
+
+	assert.Equal(t, Info, levels.Level())
+	assert.Equal(t, Trace, levels.NamedLevel("kafka"))
+	assert.Equal(t, Info, levels.Settings().Level)
+	assert.Len(t, levels.Settings().Overrides, 1)
+
+	*now = now.Add(time.Hour)
+
+	assert.Equal(t, Info, levels.NamedLevel("kafka"))
+	assert.Empty(t, levels.Settings().Overrides)
+}
+
+func TestLevelControllerConcurrency(t *testing.T) {
+	levels, err := NewLevelController(&Config{ConsoleLevel: "info"})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, levels.SetLevel("kafka", Debug, 0))
+			levels.ResetLevel("kafka")
+		}()
+		go func() {
+			defer wg.Done()
+			levels.Enabled("kafka.consumer", Debug)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestLoggerLevelController(t *testing.T) {
+	for _, backend := range []string{BackendLogrus, BackendSlog} {
+		t.Run(backend, func(t *testing.T) {
+			config := logConfigForTest(withJSON)
+			config.Backend = backend
+			config.ConsoleLevel = "info"
+
+			levels, err := NewLevelController(config)
+			require.NoError(t, err)
+
+			var buffer bytes.Buffer
+			l, err := NewBuilder(config).
+				SetLevelController(levels).
+				SetName("app").
+				BuildTestLogger(&buffer)
+			require.NoError(t, err)
+
+			consumer := Named(Named(l, "kafka"), "consumer").WithFields(Fields{"id": 42})
+
+			l.Debugf("app debug")
+			consumer.Debugf("consumer debug")
+			assert.Empty(t, buffer.String())
+
+			require.NoError(t, levels.SetLevel("app.kafka", Debug, 0))
+
+			l.Debugf("app debug")
+			consumer.Debugf("consumer debug")
+			assert.NotContains(t, buffer.String(), "app debug")
+			assert.Contains(t, buffer.String(), "consumer debug")
+
+			buffer.Reset()
+			require.NoError(t, levels.SetLevel("", Error, 0))
+
+			l.Infof("app info")
+			consumer.Infof("consumer info")
+			assert.Equal(t, 1, strings.Count(buffer.String(), "\n"))
+			assert.Contains(t, buffer.String(), "consumer info")
+		})
+	}
+}
+
+func TestNamed(t *testing.T) {
+	l, err := NewBuilder(logConfigForTest(withJSON)).BuildTestLogger(&bytes.Buffer{})
+	require.NoError(t, err)
+
+	named := Named(l, "kafka")
+	assert.Equal(t, "kafka", named.(namedLogger).loggerName())
+
+	named = Named(WithContext(named, t.Context()), "consumer")
+	assert.Equal(t, "kafka.consumer", named.(namedLogger).loggerName())
+}
@@ -0,0 +1,106 @@
+package leveladmin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	sdklogger "github.com/scribd/go-sdk/pkg/logger"
+)
+
+type (
+	// levelJSON and levelsJSON are the JSON representations of Level and
+	// Levels.
+	levelJSON struct {
+		Level     sdklogger.Level `json:"level"`
+		ExpiresAt *time.Time      `json:"expires_at,omitempty"`
+	}
+
+	levelsJSON struct {
+		Configured sdklogger.Level      `json:"configured"`
+		Level      levelJSON            `json:"level"`
+		Overrides  map[string]levelJSON `json:"overrides"`
+	}
+
+	setLevelJSON struct {
+		Name  string          `json:"name"`
+		Level sdklogger.Level `json:"level"`
+		TTL   string          `json:"ttl"`
+	}
+)
+
+// NewHandler returns an HTTP handler of the levels, which responds with the
+// levels as JSON to the following methods:
+//
+//   - GET returns the current levels;
+//   - PUT sets the level of the loggers from a JSON body such as
+//     {"name": "kafka", "level": "debug", "ttl": "10m"}, the name and the
+//     TTL being optional;
+//   - DELETE sets the loggers of the name query parameter, or all the
+//     loggers without a named level if it is not set, back to the
+//     configured level.
+func NewHandler(levels *sdklogger.LevelController) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			if err := setLevel(levels, r); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		case http.MethodDelete:
+			levels.ResetLevel(r.URL.Query().Get("name"))
+		default:
+			w.Header().Set("Allow", "GET, PUT, DELETE")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(toLevelsJSON(levels.Settings()))
+	})
+}
+
+func setLevel(levels *sdklogger.LevelController, r *http.Request) error {
+	var req setLevelJSON
+	if err := json.NewDecoder(http.MaxBytesReader(nil, r.Body, 1<<10)).Decode(&req); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+
+	var ttl time.Duration
+	if req.TTL != "" {
+		var err error
+		if ttl, err = time.ParseDuration(req.TTL); err != nil {
+			return fmt.Errorf("invalid TTL: %w", err)
+		}
+		if ttl < 0 {
+			return fmt.Errorf("negative TTL %s", ttl)
+		}
+	}
+
+	return levels.SetLevel(req.Name, req.Level, ttl)
+}
+
+func toLevelsJSON(settings sdklogger.LevelSettings) levelsJSON {
+	levels := levelsJSON{
+		Configured: settings.Configured,
+		Level:      toLevelJSON(settings.Level, settings.ExpiresAt),
+		Overrides:  make(map[string]levelJSON, len(settings.Overrides)),
+	}
+
+	for name, override := range settings.Overrides {
+		levels.Overrides[name] = toLevelJSON(override.Level, override.ExpiresAt)
+	}
+
+	return levels
+}
+
+func toLevelJSON(level sdklogger.Level, expiresAt time.Time) levelJSON {
+	l := levelJSON{Level: level}
+	if !expiresAt.IsZero() {
+		l.ExpiresAt = &expiresAt
+	}
+
+	return l
+}
@@ -0,0 +1,94 @@
+package leveladmin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdklogger "github.com/scribd/go-sdk/pkg/logger"
+)
+
+func serveLevels(t *testing.T, handler http.Handler, method, target, body string) (int, levelsJSON) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+
+	var levels levelsJSON
+	if rec.Code == http.StatusOK {
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &levels))
+	}
+
+	return rec.Code, levels
+}
+
+func TestHandler(t *testing.T) {
+	levels, err := sdklogger.NewLevelController(&sdklogger.Config{ConsoleLevel: "info"})
+	require.NoError(t, err)
+
+	handler := NewHandler(levels)
+
+	code, got := serveLevels(t, handler, http.MethodGet, "/", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, sdklogger.Info, got.Configured)
+	assert.Equal(t, levelJSON{Level: sdklogger.Info}, got.Level)
+	assert.Empty(t, got.Overrides)
+
+	start := time.Now()
+	code, got = serveLevels(t, handler, http.MethodPut, "/", `{"level": "debug"}`)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, sdklogger.Debug, got.Level.Level)
+	require.NotNil(t, got.Level.ExpiresAt)
+	assert.WithinDuration(t, start.Add(15*time.Minute), *got.Level.ExpiresAt, time.Minute)
+
+	code, got = serveLevels(t, handler, http.MethodPut, "/", `{"name": "kafka", "level": "trace", "ttl": "1h"}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Contains(t, got.Overrides, "kafka")
+	assert.Equal(t, sdklogger.Trace, got.Overrides["kafka"].Level)
+	assert.WithinDuration(t, start.Add(time.Hour), *got.Overrides["kafka"].ExpiresAt, time.Minute)
+	assert.Equal(t, sdklogger.Trace, levels.NamedLevel("kafka"))
+
+	code, got = serveLevels(t, handler, http.MethodDelete, "/?name=kafka", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Empty(t, got.Overrides)
+	assert.Equal(t, sdklogger.Debug, got.Level.Level)
+
+	code, got = serveLevels(t, handler, http.MethodDelete, "/", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, levelJSON{Level: sdklogger.Info}, got.Level)
+}
+
+func TestHandlerErrors(t *testing.T) {
+	levels, err := sdklogger.NewLevelController(&sdklogger.Config{ConsoleLevel: "info"})
+	require.NoError(t, err)
+
+	handler := NewHandler(levels)
+
+	testCases := []struct {
+		name         string
+		method       string
+		body         string
+		expectedCode int
+	}{
+		{name: "InvalidJSON", method: http.MethodPut, body: `{`, expectedCode: http.StatusBadRequest},
+		{name: "UnknownLevel", method: http.MethodPut, body: `{"level": "verbose"}`, expectedCode: http.StatusBadRequest},
+		{name: "InvalidTTL", method: http.MethodPut, body: `{"level": "debug", "ttl": "soon"}`, expectedCode: http.StatusBadRequest},
+		{name: "NegativeTTL", method: http.MethodPut, body: `{"level": "debug", "ttl": "-1m"}`, expectedCode: http.StatusBadRequest},
+		{name: "MethodNotAllowed", method: http.MethodPost, body: `{"level": "debug"}`, expectedCode: http.StatusMethodNotAllowed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, _ := serveLevels(t, handler, tc.method, "/", tc.body)
+			assert.Equal(t, tc.expectedCode, code)
+			assert.Equal(t, sdklogger.Info, levels.Level())
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Package leveladmin exposes a logger.LevelController over HTTP and gRPC,
+// so that the log levels can be changed at runtime. The handlers are not
+// authenticated: they are meant to be served on an internal port only.
+package leveladmin
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	sdklogger "github.com/scribd/go-sdk/pkg/logger"
+)
+
+// Server implements the LevelService with a LevelController.
+type Server struct {
+	UnimplementedLevelServiceServer
+	levels *sdklogger.LevelController
+}
+
+// NewServer returns a LevelService server of the levels. Register it with
+// RegisterLevelServiceServer.
+func NewServer(levels *sdklogger.LevelController) *Server {
+	return &Server{levels: levels}
+}
+
+// GetLevels returns the current levels.
+func (s *Server) GetLevels(context.Context, *GetLevelsRequest) (*Levels, error) {
+	return toLevels(s.levels.Settings()), nil
+}
+
+// SetLevel sets the level of the loggers for the TTL of the request, or
+// the TTL of the configuration if it is not set.
+func (s *Server) SetLevel(_ context.Context, req *SetLevelRequest) (*Levels, error) {
+	var ttl time.Duration
+	if req.GetTtl() != nil {
+		if err := req.GetTtl().CheckValid(); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if ttl = req.GetTtl().AsDuration(); ttl < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "negative TTL %s", ttl)
+		}
+	}
+
+	if err := s.levels.SetLevel(req.GetName(), sdklogger.Level(req.GetLevel()), ttl); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return toLevels(s.levels.Settings()), nil
+}
+
+// ResetLevel sets the loggers back to the configured level.
+func (s *Server) ResetLevel(_ context.Context, req *ResetLevelRequest) (*Levels, error) {
+	s.levels.ResetLevel(req.GetName())
+
+	return toLevels(s.levels.Settings()), nil
+}
+
+func toLevels(settings sdklogger.LevelSettings) *Levels {
+	levels := &Levels{
+		Configured: string(settings.Configured),
+		Level:      toLevel(settings.Level, settings.ExpiresAt),
+		Overrides:  make(map[string]*Level, len(settings.Overrides)),
+	}
+
+	for name, override := range settings.Overrides {
+		levels.Overrides[name] = toLevel(override.Level, override.ExpiresAt)
+	}
+
+	return levels
+}
+
+func toLevel(level sdklogger.Level, expiresAt time.Time) *Level {
+	l := &Level{Level: string(level)}
+	if !expiresAt.IsZero() {
+		l.ExpiresAt = timestamppb.New(expiresAt)
+	}
+
+	return l
+}
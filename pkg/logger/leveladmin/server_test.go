@@ -0,0 +1,99 @@
+package leveladmin
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	sdklogger "github.com/scribd/go-sdk/pkg/logger"
+)
+
+func newTestClient(t *testing.T, levels *sdklogger.LevelController) LevelServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	RegisterLevelServiceServer(s, NewServer(levels))
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough://bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return NewLevelServiceClient(conn)
+}
+
+func TestServer(t *testing.T) {
+	levels, err := sdklogger.NewLevelController(&sdklogger.Config{ConsoleLevel: "info"})
+	require.NoError(t, err)
+
+	client := newTestClient(t, levels)
+	ctx := context.Background()
+
+	got, err := client.GetLevels(ctx, &GetLevelsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "info", got.GetConfigured())
+	assert.Equal(t, "info", got.GetLevel().GetLevel())
+	assert.Nil(t, got.GetLevel().GetExpiresAt())
+	assert.Empty(t, got.GetOverrides())
+
+	start := time.Now()
+	got, err = client.SetLevel(ctx, &SetLevelRequest{Level: "debug"})
+	require.NoError(t, err)
+	assert.Equal(t, "debug", got.GetLevel().GetLevel())
+	assert.WithinDuration(t, start.Add(15*time.Minute), got.GetLevel().GetExpiresAt().AsTime(), time.Minute)
+
+	got, err = client.SetLevel(ctx, &SetLevelRequest{Name: "kafka", Level: "trace", Ttl: durationpb.New(time.Hour)})
+	require.NoError(t, err)
+	require.Contains(t, got.GetOverrides(), "kafka")
+	assert.Equal(t, "trace", got.GetOverrides()["kafka"].GetLevel())
+	assert.WithinDuration(t, start.Add(time.Hour), got.GetOverrides()["kafka"].GetExpiresAt().AsTime(), time.Minute)
+	assert.Equal(t, sdklogger.Trace, levels.NamedLevel("kafka.consumer"))
+
+	got, err = client.ResetLevel(ctx, &ResetLevelRequest{Name: "kafka"})
+	require.NoError(t, err)
+	assert.Empty(t, got.GetOverrides())
+	assert.Equal(t, "debug", got.GetLevel().GetLevel())
+
+	got, err = client.ResetLevel(ctx, &ResetLevelRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "info", got.GetLevel().GetLevel())
+}
+
+func TestServerInvalidArgument(t *testing.T) {
+	levels, err := sdklogger.NewLevelController(&sdklogger.Config{ConsoleLevel: "info"})
+	require.NoError(t, err)
+
+	client := newTestClient(t, levels)
+
+	testCases := []struct {
+		name string
+		req  *SetLevelRequest
+	}{
+		{name: "UnknownLevel", req: &SetLevelRequest{Level: "verbose"}},
+		{name: "NegativeTTL", req: &SetLevelRequest{Level: "debug", Ttl: durationpb.New(-time.Minute)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := client.SetLevel(context.Background(), tc.req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Equal(t, sdklogger.Info, levels.Level())
+		})
+	}
+}
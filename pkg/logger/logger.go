@@ -3,6 +3,8 @@
 
 package logger
 
+import "context"
+
 type Level string
 
 const (
@@ -46,6 +48,21 @@ type Logger interface {
 	Debugf(format string, args ...any)
 	// Trace logs a message at level Trace.
 	Tracef(format string, args ...any)
+	// ErrorContext logs a message at level Error, with the fields
+	// extracted from ctx by the registered ContextFieldExtractor.
+	ErrorContext(ctx context.Context, format string, args ...any)
+	// WarnContext logs a message at level Warning, with the fields
+	// extracted from ctx by the registered ContextFieldExtractor.
+	WarnContext(ctx context.Context, format string, args ...any)
+	// InfoContext logs a message at level Info, with the fields
+	// extracted from ctx by the registered ContextFieldExtractor.
+	InfoContext(ctx context.Context, format string, args ...any)
+	// DebugContext logs a message at level Debug, with the fields
+	// extracted from ctx by the registered ContextFieldExtractor.
+	DebugContext(ctx context.Context, format string, args ...any)
+	// TraceContext logs a message at level Trace, with the fields
+	// extracted from ctx by the registered ContextFieldExtractor.
+	TraceContext(ctx context.Context, format string, args ...any)
 	// WithFields creates an entry from the logger and adds multiple
 	// fields to it. This is simply a helper for `WithField`,
 	// invoking it once for each field.
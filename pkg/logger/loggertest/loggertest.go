@@ -0,0 +1,341 @@
+/*
+Package loggertest provides a Logger recording its entries, to assert on them
+in the tests instead of parsing the output of a logger.
+
+	l := loggertest.New()
+	ctx := sdkloggercontext.ToContext(context.Background(), l)
+
+	handler(ctx)
+
+	l.AssertLogged(t, sdklogger.Info, "^GET /", sdklogger.Fields{"user_id": 42})
+
+The loggers derived from a recording Logger, with WithFields and WithError,
+record their entries with it. It's safe for concurrent use.
+*/
+package loggertest
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	sdklogger "github.com/scribd/go-sdk/pkg/logger"
+)
+
+// Entry is an entry recorded by a Logger.
+type Entry struct {
+	Time    time.Time
+	Level   sdklogger.Level
+	Message string
+	// Fields are the fields of the logger and, for the entries logged with
+	// a context, the fields extracted from it.
+	Fields sdklogger.Fields
+	// Err is the error set with WithError, if any.
+	Err error
+}
+
+// recording holds the entries of a Logger and of the loggers derived from
+// it.
+type recording struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// Logger is a sdklogger.Logger recording its entries. Its Fatalf records the
+// entry without exiting; its Panicf records the entry then panics, like the
+// other loggers.
+type Logger struct {
+	recording *recording
+	fields    sdklogger.Fields
+	err       error
+}
+
+var _ sdklogger.Logger = (*Logger)(nil)
+
+// New returns a Logger recording the entries of all the levels.
+func New() *Logger {
+	return &Logger{recording: &recording{}, fields: sdklogger.Fields{}}
+}
+
+func (l *Logger) Panicf(format string, args ...any) {
+	l.log(sdklogger.Panic, format, args...)
+	panic(fmt.Sprintf(format, args...))
+}
+
+func (l *Logger) Fatalf(format string, args ...any) {
+	l.log(sdklogger.Fatal, format, args...)
+}
+
+func (l *Logger) Errorf(format string, args ...any) {
+	l.log(sdklogger.Error, format, args...)
+}
+
+func (l *Logger) Warnf(format string, args ...any) {
+	l.log(sdklogger.Warn, format, args...)
+}
+
+func (l *Logger) Infof(format string, args ...any) {
+	l.log(sdklogger.Info, format, args...)
+}
+
+func (l *Logger) Debugf(format string, args ...any) {
+	l.log(sdklogger.Debug, format, args...)
+}
+
+func (l *Logger) Tracef(format string, args ...any) {
+	l.log(sdklogger.Trace, format, args...)
+}
+
+func (l *Logger) ErrorContext(ctx context.Context, format string, args ...any) {
+	l.logContext(ctx, sdklogger.Error, format, args...)
+}
+
+func (l *Logger) WarnContext(ctx context.Context, format string, args ...any) {
+	l.logContext(ctx, sdklogger.Warn, format, args...)
+}
+
+func (l *Logger) InfoContext(ctx context.Context, format string, args ...any) {
+	l.logContext(ctx, sdklogger.Info, format, args...)
+}
+
+func (l *Logger) DebugContext(ctx context.Context, format string, args ...any) {
+	l.logContext(ctx, sdklogger.Debug, format, args...)
+}
+
+func (l *Logger) TraceContext(ctx context.Context, format string, args ...any) {
+	l.logContext(ctx, sdklogger.Trace, format, args...)
+}
+
+// WithFields returns a Logger with the fields added, recording with l.
+func (l *Logger) WithFields(fields sdklogger.Fields) sdklogger.Logger {
+	merged := maps.Clone(l.fields)
+	maps.Copy(merged, fields)
+
+	return &Logger{recording: l.recording, fields: merged, err: l.err}
+}
+
+// WithError returns a Logger with the error set, recording with l.
+func (l *Logger) WithError(err error) sdklogger.Logger {
+	return &Logger{recording: l.recording, fields: l.fields, err: err}
+}
+
+func (l *Logger) log(level sdklogger.Level, format string, args ...any) {
+	l.record(level, fmt.Sprintf(format, args...), maps.Clone(l.fields))
+}
+
+// logContext logs with the fields extracted from ctx.
+func (l *Logger) logContext(ctx context.Context, level sdklogger.Level, format string, args ...any) {
+	fields := maps.Clone(l.fields)
+	maps.Copy(fields, sdklogger.ContextFields(ctx))
+
+	l.record(level, fmt.Sprintf(format, args...), fields)
+}
+
+func (l *Logger) record(level sdklogger.Level, message string, fields sdklogger.Fields) {
+	entry := Entry{
+		Time:    time.Now(),
+		Level:   level,
+		Message: message,
+		Fields:  fields,
+		Err:     l.err,
+	}
+
+	l.recording.mu.Lock()
+	defer l.recording.mu.Unlock()
+
+	l.recording.entries = append(l.recording.entries, entry)
+}
+
+// Entries returns the entries recorded, in the order they were logged.
+func (l *Logger) Entries() []Entry {
+	l.recording.mu.Lock()
+	defer l.recording.mu.Unlock()
+
+	return append([]Entry(nil), l.recording.entries...)
+}
+
+// Reset forgets the entries recorded.
+func (l *Logger) Reset() {
+	l.recording.mu.Lock()
+	defer l.recording.mu.Unlock()
+
+	l.recording.entries = nil
+}
+
+// FilterLevel returns the entries of the level.
+func (l *Logger) FilterLevel(level sdklogger.Level) []Entry {
+	return l.filter(func(e Entry) bool { return e.Level == level })
+}
+
+// FilterField returns the entries with the field of the key. A dotted key,
+// such as "http.response_status", is the path of a nested field.
+func (l *Logger) FilterField(key string, value any) []Entry {
+	return l.filter(func(e Entry) bool {
+		actual, ok := lookup(e.Fields, key)
+		return ok && assert.ObjectsAreEqualValues(value, actual)
+	})
+}
+
+// FilterMessage returns the entries whose message matches the regular
+// expression.
+func (l *Logger) FilterMessage(msgRegex string) []Entry {
+	re := regexp.MustCompile(msgRegex)
+	return l.filter(func(e Entry) bool { return re.MatchString(e.Message) })
+}
+
+// Find returns the entries of the level, whose message matches the regular
+// expression and whose fields include the fields given. The values of the
+// nested fields are matched the same way, so that a subset of them can be
+// given.
+func (l *Logger) Find(level sdklogger.Level, msgRegex string, fields sdklogger.Fields) []Entry {
+	re := regexp.MustCompile(msgRegex)
+
+	return l.filter(func(e Entry) bool {
+		return e.Level == level && re.MatchString(e.Message) && includes(e.Fields, fields)
+	})
+}
+
+func (l *Logger) filter(match func(Entry) bool) []Entry {
+	var entries []Entry
+	for _, e := range l.Entries() {
+		if match(e) {
+			entries = append(entries, e)
+		}
+	}
+
+	return entries
+}
+
+// AssertLogged asserts that an entry was logged at the level, with a message
+// matching the regular expression and with the fields given, as Find finds
+// it.
+func (l *Logger) AssertLogged(t assert.TestingT, level sdklogger.Level, msgRegex string, fields sdklogger.Fields, msgAndArgs ...any) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	if len(l.Find(level, msgRegex, fields)) > 0 {
+		return true
+	}
+
+	return assert.Fail(t, fmt.Sprintf("No %s entry matching %q with the fields %v, the entries being:\n%s",
+		level, msgRegex, fields, l.dump()), msgAndArgs...)
+}
+
+// AssertNotLogged asserts that no entry was logged at the level, with a
+// message matching the regular expression and with the fields given.
+func (l *Logger) AssertNotLogged(t assert.TestingT, level sdklogger.Level, msgRegex string, fields sdklogger.Fields, msgAndArgs ...any) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	found := l.Find(level, msgRegex, fields)
+	if len(found) == 0 {
+		return true
+	}
+
+	return assert.Fail(t, fmt.Sprintf("Unexpected %s entry matching %q with the fields %v: %q",
+		level, msgRegex, fields, found[0].Message), msgAndArgs...)
+}
+
+// AssertCount asserts that n entries were logged at the level.
+func (l *Logger) AssertCount(t assert.TestingT, level sdklogger.Level, n int, msgAndArgs ...any) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	return assert.Len(t, l.FilterLevel(level), n, msgAndArgs...)
+}
+
+// dump returns the entries, one per line, for the failure messages.
+func (l *Logger) dump() string {
+	var b strings.Builder
+	for _, e := range l.Entries() {
+		fmt.Fprintf(&b, "\t%s %q %v", e.Level, e.Message, e.Fields)
+		if e.Err != nil {
+			fmt.Fprintf(&b, " error=%q", e.Err)
+		}
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+// lookup returns the field at the dotted path of key. A key with a dot is
+// looked up as is first.
+func lookup(fields map[string]any, key string) (any, bool) {
+	if value, ok := fields[key]; ok {
+		return value, true
+	}
+
+	for i := 0; i < len(key); i++ {
+		if key[i] != '.' {
+			continue
+		}
+
+		nested, ok := asMap(fields[key[:i]])
+		if !ok {
+			continue
+		}
+
+		if value, ok := lookup(nested, key[i+1:]); ok {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+// includes reports whether the fields include the expected ones.
+func includes(fields, expected map[string]any) bool {
+	for key, want := range expected {
+		got, ok := fields[key]
+		if !ok || !matches(got, want) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matches reports whether a value matches the expected one: the expected
+// maps match the maps including them, the other values the equal ones.
+func matches(got, want any) bool {
+	if wantMap, ok := asMap(want); ok {
+		gotMap, ok := asMap(got)
+		return ok && includes(gotMap, wantMap)
+	}
+
+	return assert.ObjectsAreEqualValues(want, got)
+}
+
+// asMap returns the value as a map of fields, if it is a map with string
+// keys, such as sdklogger.Fields.
+func asMap(value any) (map[string]any, bool) {
+	switch v := value.(type) {
+	case sdklogger.Fields:
+		return v, true
+	case map[string]any:
+		return v, true
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+
+	m := make(map[string]any, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		m[iter.Key().String()] = iter.Value().Interface()
+	}
+
+	return m, true
+}
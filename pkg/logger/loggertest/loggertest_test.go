@@ -0,0 +1,203 @@
+package loggertest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkloggercontext "github.com/scribd/go-sdk/pkg/context/logger"
+	sdklogger "github.com/scribd/go-sdk/pkg/logger"
+)
+
+func TestLoggerRecords(t *testing.T) {
+	l := New()
+	err := errors.New("failed")
+
+	l.WithFields(sdklogger.Fields{"id": 42}).WithError(err).Errorf("query %s", "failed")
+	l.Infof("started")
+
+	require.Len(t, l.Entries(), 2)
+
+	entry := l.Entries()[0]
+	assert.Equal(t, sdklogger.Error, entry.Level)
+	assert.Equal(t, "query failed", entry.Message)
+	assert.Equal(t, sdklogger.Fields{"id": 42}, entry.Fields)
+	assert.Equal(t, err, entry.Err)
+	assert.False(t, entry.Time.IsZero())
+
+	entry = l.Entries()[1]
+	assert.Equal(t, sdklogger.Info, entry.Level)
+	assert.Empty(t, entry.Fields)
+	assert.NoError(t, entry.Err)
+
+	l.Reset()
+	assert.Empty(t, l.Entries())
+}
+
+func TestLoggerLevels(t *testing.T) {
+	l := New()
+	ctx := context.Background()
+
+	logs := []struct {
+		level sdklogger.Level
+		log   func()
+	}{
+		{sdklogger.Fatal, func() { l.Fatalf("m") }},
+		{sdklogger.Error, func() { l.Errorf("m") }},
+		{sdklogger.Error, func() { l.ErrorContext(ctx, "m") }},
+		{sdklogger.Warn, func() { l.Warnf("m") }},
+		{sdklogger.Warn, func() { l.WarnContext(ctx, "m") }},
+		{sdklogger.Info, func() { l.Infof("m") }},
+		{sdklogger.Info, func() { l.InfoContext(ctx, "m") }},
+		{sdklogger.Debug, func() { l.Debugf("m") }},
+		{sdklogger.Debug, func() { l.DebugContext(ctx, "m") }},
+		{sdklogger.Trace, func() { l.Tracef("m") }},
+		{sdklogger.Trace, func() { l.TraceContext(ctx, "m") }},
+	}
+
+	for _, log := range logs {
+		log.log()
+	}
+
+	entries := l.Entries()
+	require.Len(t, entries, len(logs))
+	for i, log := range logs {
+		assert.Equal(t, log.level, entries[i].Level)
+	}
+
+	assert.PanicsWithValue(t, "panic 1", func() { l.Panicf("panic %d", 1) })
+	l.AssertLogged(t, sdklogger.Panic, "^panic 1$", nil)
+}
+
+func TestLoggerContextFields(t *testing.T) {
+	sdklogger.RegisterContextFieldExtractor("loggertest", func(ctx context.Context) sdklogger.Fields {
+		if user, ok := ctx.Value(userKey{}).(string); ok {
+			return sdklogger.Fields{"user": user}
+		}
+		return nil
+	})
+	defer sdklogger.UnregisterContextFieldExtractor("loggertest")
+
+	l := New()
+	ctx := context.WithValue(context.Background(), userKey{}, "jane")
+
+	sdklogger.WithContext(l, ctx).Infof("bound")
+	l.InfoContext(ctx, "explicit")
+	l.Infof("without")
+
+	assert.Len(t, l.FilterField("user", "jane"), 2)
+	l.AssertNotLogged(t, sdklogger.Info, "without", sdklogger.Fields{"user": "jane"})
+}
+
+type userKey struct{}
+
+func TestLoggerFilters(t *testing.T) {
+	l := New()
+
+	l.WithFields(sdklogger.Fields{
+		"http": sdklogger.Fields{"request_method": "GET", "response_status": 200},
+	}).Infof("GET /")
+	l.WithFields(sdklogger.Fields{
+		"http": sdklogger.Fields{"request_method": "POST", "response_status": 500},
+	}).Errorf("POST /")
+	l.WithFields(sdklogger.Fields{"grpc.code": "OK"}).Infof("finished")
+
+	assert.Len(t, l.FilterLevel(sdklogger.Info), 2)
+	assert.Len(t, l.FilterMessage("^(GET|POST) "), 2)
+	assert.Len(t, l.FilterField("http.response_status", 500), 1)
+	assert.Len(t, l.FilterField("http.response_status", int64(200)), 1)
+	assert.Len(t, l.FilterField("grpc.code", "OK"), 1)
+	assert.Empty(t, l.FilterField("http.request_path", "/"))
+
+	found := l.Find(sdklogger.Error, "^POST", sdklogger.Fields{"http": sdklogger.Fields{"response_status": 500}})
+	require.Len(t, found, 1)
+	assert.Equal(t, "POST /", found[0].Message)
+
+	assert.Empty(t, l.Find(sdklogger.Info, "^POST", nil))
+}
+
+func TestFindNestedMaps(t *testing.T) {
+	l := New()
+
+	l.WithFields(sdklogger.Fields{
+		"http": sdklogger.Fields{"request_params": url.Values{"page": {"1"}}},
+	}).Infof("GET /")
+
+	assert.Len(t, l.Find(sdklogger.Info, "", sdklogger.Fields{
+		"http": map[string]any{"request_params": map[string]any{"page": []string{"1"}}},
+	}), 1)
+	assert.Empty(t, l.Find(sdklogger.Info, "", sdklogger.Fields{
+		"http": sdklogger.Fields{"request_params": "page=1"},
+	}))
+}
+
+// recordingT records the failures of the assertions.
+type recordingT struct {
+	errors []string
+}
+
+func (t *recordingT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertions(t *testing.T) {
+	l := New()
+	l.WithFields(sdklogger.Fields{"id": 42}).Warnf("slow query")
+
+	rt := &recordingT{}
+
+	assert.True(t, l.AssertLogged(rt, sdklogger.Warn, "slow", sdklogger.Fields{"id": 42}))
+	assert.True(t, l.AssertNotLogged(rt, sdklogger.Error, "slow", nil))
+	assert.True(t, l.AssertCount(rt, sdklogger.Warn, 1))
+	assert.Empty(t, rt.errors)
+
+	assert.False(t, l.AssertLogged(rt, sdklogger.Warn, "slow", sdklogger.Fields{"id": 43}))
+	require.Len(t, rt.errors, 1)
+	assert.Contains(t, rt.errors[0], `No warn entry matching "slow" with the fields map[id:43]`)
+	assert.Contains(t, rt.errors[0], `warn "slow query" map[id:42]`)
+
+	assert.False(t, l.AssertNotLogged(rt, sdklogger.Warn, "query", nil))
+	assert.False(t, l.AssertCount(rt, sdklogger.Warn, 2))
+	assert.Len(t, rt.errors, 3)
+}
+
+func TestLoggerConcurrency(t *testing.T) {
+	l := New()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+
+			wl := l.WithFields(sdklogger.Fields{"worker": worker})
+			for j := 0; j < 100; j++ {
+				wl.Infof("job %d", j)
+			}
+			l.FilterField("worker", worker)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Len(t, l.Entries(), 1000)
+	assert.Len(t, l.FilterField("worker", 3), 100)
+}
+
+func TestLoggerInContext(t *testing.T) {
+	l := New()
+
+	ctx := sdkloggercontext.ToContext(context.Background(), l)
+	sdkloggercontext.AddFields(ctx, sdklogger.Fields{"user_id": 42})
+
+	extracted, err := sdkloggercontext.Extract(ctx)
+	require.NoError(t, err)
+	extracted.Infof("handled")
+
+	l.AssertLogged(t, sdklogger.Info, "^handled$", sdklogger.Fields{"user_id": 42})
+}
@@ -1,15 +1,13 @@
 package logger
 
 import (
-	"bytes"
+	"context"
 	"io"
-	"os"
-	"path"
+	"log/slog"
 
 	"github.com/scribd/go-sdk/pkg/tracking"
 
 	"github.com/sirupsen/logrus"
-	lumberjack "gopkg.in/natefinch/lumberjack.v2"
 )
 
 const (
@@ -20,22 +18,6 @@ const (
 	fieldKeyMsg = "message"
 )
 
-const (
-	// fileMaxSize is the maximum size in megabytes of the log file
-	// before it gets rotated. It defaults to 100 megabytes.
-	fileMaxSize = 100
-	// fileWillCompress determines if the rotated log files should
-	// be compressed using gzip.
-	fileWillCompress = true
-	// fileMaxAge is the maximum number of days to retain old log
-	// files based on the timestamp encoded in their filename. Note
-	// that a day is defined as 24 hours and may not exactly
-	// correspond to calendar days due to daylight savings, leap
-	// seconds, etc. The default is not to remove old log files
-	// based on age.
-	fileMaxAge = 28
-)
-
 func getFormatter(isJSON bool) logrus.Formatter {
 	fieldMap := logrus.FieldMap{
 		logrus.FieldKeyTime: fieldKeyTime,
@@ -55,56 +37,100 @@ func getFormatter(isJSON bool) logrus.Formatter {
 	}
 }
 
-func newLogrusLogger(config *Config) (*logrus.Logger, error) {
+// logLevel returns the level of the logger: the most verbose level of the
+// sinks, else the console one, else the file one.
+func logLevel(config *Config) (logrus.Level, error) {
+	var (
+		level   logrus.Level
+		leveled bool
+	)
+	for _, sink := range config.Sinks {
+		if sink.Level == "" {
+			continue
+		}
+
+		sinkLevel, err := logrus.ParseLevel(sink.Level)
+		if err != nil {
+			return level, err
+		}
+		if !leveled || sinkLevel > level {
+			level, leveled = sinkLevel, true
+		}
+	}
+	if leveled {
+		return level, nil
+	}
+
 	logLevel := config.ConsoleLevel
 	if logLevel == "" {
 		logLevel = config.FileLevel
 	}
 
-	level, err := logrus.ParseLevel(logLevel)
+	return logrus.ParseLevel(logLevel)
+}
+
+// newLogrusLogger returns the logrus logger writing the entries to the
+// sinks, with a hook per sink. It logs all the levels, the entries being
+// filtered by the level controller, then by the level of each sink.
+func newLogrusLogger(config *Config, sinks sinks) (*logrus.Logger, error) {
+	lLogger := &logrus.Logger{
+		Out:       io.Discard,
+		Formatter: discardFormatter{},
+		Hooks:     make(logrus.LevelHooks),
+		Level:     logrus.TraceLevel,
+		ExitFunc:  sinks.closeAndExit,
+	}
+
+	redactor, err := newRedactor(config.Redaction)
 	if err != nil {
 		return nil, err
 	}
-
-	stdOutHandler := os.Stdout
-	fileHandler := &lumberjack.Logger{
-		Filename: path.Join(config.FileLocation, config.FileName),
-		MaxSize:  fileMaxSize,
-		Compress: fileWillCompress,
-		MaxAge:   fileMaxAge,
+	if redactor != nil {
+		// The redaction hook is added first, for the next hooks to get the
+		// redacted entries.
+		lLogger.Hooks.Add(&redactionHook{redactor: redactor})
 	}
 
-	lLogger := &logrus.Logger{
-		Hooks: make(logrus.LevelHooks),
-		Level: level,
+	for _, sink := range sinks {
+		lLogger.Hooks.Add(&sinkHook{sink: sink, formatter: getFormatter(sink.json)})
 	}
 
-	if config.ConsoleEnabled && config.FileEnabled {
-		lLogger.SetOutput(io.MultiWriter(stdOutHandler, fileHandler))
+	return lLogger, nil
+}
 
-		// Logrus can handle MultiWriter but not (easily) MultiFormat.
-		// In this case the JSON format wins to ease the log processing.
-		lLogger.SetFormatter(getFormatter(true))
-	}
+// discardFormatter formats nothing, the entries being written by the sink
+// hooks.
+type discardFormatter struct{}
 
-	if config.ConsoleEnabled && !config.FileEnabled {
-		lLogger.SetOutput(stdOutHandler)
-		lLogger.SetFormatter(getFormatter(config.ConsoleJSONFormat))
-	}
+func (discardFormatter) Format(*logrus.Entry) ([]byte, error) {
+	return nil, nil
+}
 
-	if !config.ConsoleEnabled && config.FileEnabled {
-		lLogger.SetOutput(fileHandler)
-		lLogger.SetFormatter(getFormatter(config.FileJSONFormat))
-	}
+// sinkHook writes the entries of the levels of a sink to it, in its format.
+type sinkHook struct {
+	sink      *sink
+	formatter logrus.Formatter
+}
 
-	return lLogger, nil
+func (h *sinkHook) Levels() []logrus.Level {
+	return logrus.AllLevels[:h.sink.level+1]
 }
 
-func newTestLogrusLogger(config *Config, out *bytes.Buffer) (*logrus.Logger, error) {
-	lLogger, err := newLogrusLogger(config)
-	lLogger.Out = out
+// Fire writes the entry to the sink, unless it is sampled out of the logs.
+// Its write errors are reported by the sink, not by logrus for every entry.
+func (h *sinkHook) Fire(entry *logrus.Entry) error {
+	if sampledOutOf(entry.Context)&sampledOutLogs != 0 {
+		return nil
+	}
+
+	formatted, err := h.formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+
+	_ = h.sink.writer.writeEntry(entry.Level, formatted)
 
-	return lLogger, err
+	return nil
 }
 
 // An entry is the final or intermediate Logrus logging entry. It
@@ -113,28 +139,46 @@ func newTestLogrusLogger(config *Config, out *bytes.Buffer) (*logrus.Logger, err
 // on it.
 //
 // logrusEntry implements the `Logger` interface.
+//
+// The entries are filtered by the level controller, the logrus logger
+// logging all the levels.
 type logrusLogEntry struct {
-	entry *logrus.Entry
+	entry   *logrus.Entry
+	levels  *LevelController
+	name    string
+	sinks   sinks
+	sampler *sampler
+}
+
+func newLogrusLogEntry(lLogrus *logrus.Logger, fields Fields, levels *LevelController, name string, sinks sinks) *logrusLogEntry {
+	lLogrus.SetLevel(logrus.TraceLevel)
+
+	return &logrusLogEntry{
+		entry:  lLogrus.WithFields(convertToLogrusFields(fields)),
+		levels: levels,
+		name:   name,
+		sinks:  sinks,
+	}
 }
 
 func (l *logrusLogEntry) Tracef(format string, args ...any) {
-	l.entry.Tracef(format, args...)
+	l.log(logrus.TraceLevel, format, args...)
 }
 
 func (l *logrusLogEntry) Debugf(format string, args ...any) {
-	l.entry.Debugf(format, args...)
+	l.log(logrus.DebugLevel, format, args...)
 }
 
 func (l *logrusLogEntry) Infof(format string, args ...any) {
-	l.entry.Infof(format, args...)
+	l.log(logrus.InfoLevel, format, args...)
 }
 
 func (l *logrusLogEntry) Warnf(format string, args ...any) {
-	l.entry.Warnf(format, args...)
+	l.log(logrus.WarnLevel, format, args...)
 }
 
 func (l *logrusLogEntry) Errorf(format string, args ...any) {
-	l.entry.Errorf(format, args...)
+	l.log(logrus.ErrorLevel, format, args...)
 }
 
 func (l *logrusLogEntry) Fatalf(format string, args ...any) {
@@ -145,27 +189,115 @@ func (l *logrusLogEntry) Panicf(format string, args ...any) {
 	l.entry.Panicf(format, args...)
 }
 
+func (l *logrusLogEntry) TraceContext(ctx context.Context, format string, args ...any) {
+	l.logContext(ctx, logrus.TraceLevel, format, args...)
+}
+
+func (l *logrusLogEntry) DebugContext(ctx context.Context, format string, args ...any) {
+	l.logContext(ctx, logrus.DebugLevel, format, args...)
+}
+
+func (l *logrusLogEntry) InfoContext(ctx context.Context, format string, args ...any) {
+	l.logContext(ctx, logrus.InfoLevel, format, args...)
+}
+
+func (l *logrusLogEntry) WarnContext(ctx context.Context, format string, args ...any) {
+	l.logContext(ctx, logrus.WarnLevel, format, args...)
+}
+
+func (l *logrusLogEntry) ErrorContext(ctx context.Context, format string, args ...any) {
+	l.logContext(ctx, logrus.ErrorLevel, format, args...)
+}
+
+func (l *logrusLogEntry) log(level logrus.Level, format string, args ...any) {
+	if !l.isLevelEnabled(level) {
+		return
+	}
+
+	out, ok := l.sampler.sample(level, format)
+	if !ok {
+		return
+	}
+
+	entry := l.entry
+	if out != 0 {
+		entry = entry.WithContext(withSampledOut(context.Background(), out))
+	}
+
+	entry.Logf(level, format, args...)
+}
+
+// logContext logs with the context fields, extracted only if the level is
+// enabled and the entry is not sampled out.
+func (l *logrusLogEntry) logContext(ctx context.Context, level logrus.Level, format string, args ...any) {
+	if !l.isLevelEnabled(level) {
+		return
+	}
+
+	out, ok := l.sampler.sample(level, format)
+	if !ok {
+		return
+	}
+
+	if out != 0 {
+		ctx = withSampledOut(ctx, out)
+	}
+
+	l.entry.WithContext(ctx).WithFields(convertToLogrusFields(ContextFields(ctx))).Logf(level, format, args...)
+}
+
+func (l *logrusLogEntry) isLevelEnabled(level logrus.Level) bool {
+	if l.levels == nil {
+		return l.entry.Logger.IsLevelEnabled(level)
+	}
+
+	return l.levels.enabled(l.name, level)
+}
+
 func (l *logrusLogEntry) WithFields(fields Fields) Logger {
 	return &logrusLogEntry{
-		entry: l.entry.WithFields(convertToLogrusFields(fields)),
+		entry:   l.entry.WithFields(convertToLogrusFields(fields)),
+		levels:  l.levels,
+		name:    l.name,
+		sinks:   l.sinks,
+		sampler: l.sampler,
 	}
 }
 
 // WithError sets an error field on logrus logger.
 func (l *logrusLogEntry) WithError(err error) Logger {
 	return &logrusLogEntry{
-		entry: l.entry.WithError(err),
+		entry:   l.entry.WithError(err),
+		levels:  l.levels,
+		name:    l.name,
+		sinks:   l.sinks,
+		sampler: l.sampler,
 	}
 }
 
-// SetTracking configures and enables the error reporting.
-func (l *logrusLogEntry) setTracking(trackingConfig *tracking.Config) error {
-	hook, err := tracking.NewSentryHook(trackingConfig)
-	if err != nil {
-		return err
-	}
+func (l *logrusLogEntry) named(name string) Logger {
+	return &logrusLogEntry{entry: l.entry, levels: l.levels, name: name, sinks: l.sinks, sampler: l.sampler}
+}
 
-	l.entry.Logger.Hooks.Add(hook)
+func (l *logrusLogEntry) loggerName() string {
+	return l.name
+}
 
-	return nil
+func (l *logrusLogEntry) withSampler(s *sampler) Logger {
+	return &logrusLogEntry{entry: l.entry, levels: l.levels, name: l.name, sinks: l.sinks, sampler: s}
+}
+
+func (l *logrusLogEntry) close() error {
+	l.sampler.stop()
+	return l.sinks.Close()
+}
+
+func (l *logrusLogEntry) enabled(level slog.Level) bool {
+	return l.isLevelEnabled(toLogrusLevel(level))
+}
+
+// SetTracking enables the error reporting with the hook.
+func (l *logrusLogEntry) setTracking(hook *tracking.Hook) {
+	l.entry.Logger.Hooks.Add(&trackingSamplingHook{Hook: hook, out: sampledOutTracking})
+	l.entry.Logger.Hooks.Add(&trackingSamplingHook{Hook: hook.BreadcrumbHook(), out: sampledOutLogs})
 }
@@ -0,0 +1,319 @@
+package logger
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// The redaction modes.
+const (
+	// RedactionMask replaces the redacted values with the mask. It's the
+	// default.
+	RedactionMask = "mask"
+	// RedactionHash replaces the redacted values with a hash of them, so
+	// that the entries of a same value can still be correlated.
+	RedactionHash = "hash"
+)
+
+const (
+	defaultRedactionMask = "[REDACTED]"
+	// redactionHashPrefix prefixes the hashes, truncated to
+	// redactionHashLength hexadecimal digits.
+	redactionHashPrefix = "sha256:"
+	redactionHashLength = 16
+)
+
+// RedactionConfig configures the redaction of the log entries. The fields
+// whose key is one of Keys, at any depth, or whose path is one of Paths are
+// redacted, as are the parts of the messages and of the string values that
+// match one of Patterns.
+type RedactionConfig struct {
+	// Keys are field keys, matched case-insensitively, such as "password".
+	Keys []string `mapstructure:"keys"`
+	// Paths are dotted paths of fields from the root of the entry, such
+	// as "http.request_params.email". A "*" matches any key.
+	Paths []string `mapstructure:"paths"`
+	// Patterns are regular expressions, such as an email address pattern.
+	Patterns []string `mapstructure:"patterns" validate:"regexp"`
+	// Mode is RedactionMask or RedactionHash.
+	Mode string `mapstructure:"mode" validate:"omitempty,oneof=mask hash"`
+	// Mask replaces the masked values, "[REDACTED]" by default.
+	Mask string `mapstructure:"mask"`
+	// HashKey is the key of the HMAC hashing the values, so that the
+	// hashes of guessable values cannot be reversed.
+	HashKey string `mapstructure:"hash_key"`
+}
+
+// redactor redacts the fields and the messages of the log entries. The
+// redacted values are copies: the fields given are never modified.
+type redactor struct {
+	keys     map[string]struct{}
+	paths    [][]string
+	patterns []*regexp.Regexp
+	hash     bool
+	mask     string
+	hashKey  []byte
+}
+
+// newRedactor returns the redactor of the configuration, or nil if the
+// configuration redacts nothing.
+func newRedactor(config RedactionConfig) (*redactor, error) {
+	if len(config.Keys) == 0 && len(config.Paths) == 0 && len(config.Patterns) == 0 {
+		return nil, nil
+	}
+
+	r := &redactor{
+		keys:    make(map[string]struct{}, len(config.Keys)),
+		hash:    config.Mode == RedactionHash,
+		mask:    config.Mask,
+		hashKey: []byte(config.HashKey),
+	}
+
+	if r.mask == "" {
+		r.mask = defaultRedactionMask
+	}
+
+	for _, key := range config.Keys {
+		r.keys[strings.ToLower(key)] = struct{}{}
+	}
+
+	for _, path := range config.Paths {
+		r.paths = append(r.paths, strings.Split(path, "."))
+	}
+
+	for _, pattern := range config.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", pattern, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+
+	return r, nil
+}
+
+// redact returns the value of the field at path redacted, and whether it
+// was.
+func (r *redactor) redact(path []string, value any) (any, bool) {
+	if r.matches(path) {
+		return r.replace(fmt.Sprint(value)), true
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return value, false
+	case string:
+		redacted := r.redactString(v)
+		return redacted, redacted != v
+	case error:
+		msg := v.Error()
+		if redacted := r.redactString(msg); redacted != msg {
+			return &redactedError{msg: redacted, err: v}, true
+		}
+		return value, false
+	case []byte:
+		return value, false
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() == reflect.String {
+			return r.redactMap(path, rv)
+		}
+	case reflect.Slice, reflect.Array:
+		return r.redactSlice(path, rv)
+	}
+
+	return value, false
+}
+
+// redactMap redacts the values of a map, returning them as Fields if any
+// is redacted.
+func (r *redactor) redactMap(path []string, rv reflect.Value) (any, bool) {
+	fields := make(Fields, rv.Len())
+	redacted := false
+
+	iter := rv.MapRange()
+	for iter.Next() {
+		key := iter.Key().String()
+		value, ok := r.redact(append(path[:len(path):len(path)], key), iter.Value().Interface())
+		fields[key] = value
+		redacted = redacted || ok
+	}
+
+	if !redacted {
+		return rv.Interface(), false
+	}
+
+	return fields, true
+}
+
+// redactSlice redacts the items of a slice, the path of which is the path
+// of the slice, returning them as a []any if any is redacted.
+func (r *redactor) redactSlice(path []string, rv reflect.Value) (any, bool) {
+	items := make([]any, rv.Len())
+	redacted := false
+
+	for i := range items {
+		value, ok := r.redact(path, rv.Index(i).Interface())
+		items[i] = value
+		redacted = redacted || ok
+	}
+
+	if !redacted {
+		return rv.Interface(), false
+	}
+
+	return items, true
+}
+
+// redactString redacts the parts of s that match the patterns.
+func (r *redactor) redactString(s string) string {
+	for _, re := range r.patterns {
+		s = re.ReplaceAllStringFunc(s, r.replace)
+	}
+
+	return s
+}
+
+func (r *redactor) replace(s string) string {
+	if !r.hash {
+		return r.mask
+	}
+
+	mac := hmac.New(sha256.New, r.hashKey)
+	mac.Write([]byte(s))
+
+	return redactionHashPrefix + hex.EncodeToString(mac.Sum(nil))[:redactionHashLength]
+}
+
+// matches reports whether the field at path is redacted as a whole.
+func (r *redactor) matches(path []string) bool {
+	if _, ok := r.keys[strings.ToLower(path[len(path)-1])]; ok {
+		return true
+	}
+
+	return slices.ContainsFunc(r.paths, func(p []string) bool {
+		return slices.EqualFunc(p, path, func(pattern, key string) bool {
+			return pattern == "*" || strings.EqualFold(pattern, key)
+		})
+	})
+}
+
+// redactedError is an error whose message is redacted.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string { return e.msg }
+func (e *redactedError) Unwrap() error { return e.err }
+
+// redactionHook redacts the logrus entries. It's added before the other
+// hooks, so that they get the redacted entries, like the Sentry hook.
+type redactionHook struct {
+	redactor *redactor
+}
+
+func (h *redactionHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire redacts the entry in place: logrus fires the hooks with a copy of
+// the entry, whose fields are replaced, never modified.
+func (h *redactionHook) Fire(entry *logrus.Entry) error {
+	entry.Message = h.redactor.redactString(entry.Message)
+
+	for key, value := range entry.Data {
+		if redacted, ok := h.redactor.redact([]string{key}, value); ok {
+			entry.Data[key] = redacted
+		}
+	}
+
+	return nil
+}
+
+// redactionHandler redacts the slog records, then passes them to the next
+// handler.
+type redactionHandler struct {
+	next     slog.Handler
+	redactor *redactor
+	groups   []string
+}
+
+func newRedactionHandler(next slog.Handler, redactor *redactor) slog.Handler {
+	return &redactionHandler{next: next, redactor: redactor}
+}
+
+func (h *redactionHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *redactionHandler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, h.redactor.redactString(record.Message), record.PC)
+	record.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(h.redactAttr(h.groups, a))
+		return true
+	})
+
+	return h.next.Handle(ctx, redacted)
+}
+
+func (h *redactionHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = h.redactAttr(h.groups, a)
+	}
+
+	return &redactionHandler{next: h.next.WithAttrs(redacted), redactor: h.redactor, groups: h.groups}
+}
+
+func (h *redactionHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return &redactionHandler{
+		next:     h.next.WithGroup(name),
+		redactor: h.redactor,
+		groups:   append(h.groups[:len(h.groups):len(h.groups)], name),
+	}
+}
+
+// redactAttr redacts an attribute, the path of which is the groups and the
+// attribute key.
+func (h *redactionHandler) redactAttr(groups []string, a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+
+	if a.Value.Kind() == slog.KindGroup {
+		path := groups
+		if a.Key != "" {
+			path = append(groups[:len(groups):len(groups)], a.Key)
+		}
+
+		attrs := a.Value.Group()
+		redacted := make([]any, len(attrs))
+		for i, attr := range attrs {
+			redacted[i] = h.redactAttr(path, attr)
+		}
+
+		return slog.Group(a.Key, redacted...)
+	}
+
+	if value, ok := h.redactor.redact(append(groups[:len(groups):len(groups)], a.Key), a.Value.Any()); ok {
+		return slog.Any(a.Key, value)
+	}
+
+	return a
+}
@@ -0,0 +1,278 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const emailPattern = `[\w.+-]+@[\w-]+\.\w+`
+
+func TestNewRedactor(t *testing.T) {
+	t.Run("WithoutRules", func(t *testing.T) {
+		r, err := newRedactor(RedactionConfig{Mode: RedactionHash})
+		require.NoError(t, err)
+		assert.Nil(t, r)
+	})
+
+	t.Run("WithInvalidPattern", func(t *testing.T) {
+		_, err := newRedactor(RedactionConfig{Patterns: []string{"(["}})
+		assert.Error(t, err)
+	})
+}
+
+func TestRedactorRedact(t *testing.T) {
+	r, err := newRedactor(RedactionConfig{
+		Keys:     []string{"password", "Authorization"},
+		Paths:    []string{"user.email", "http.*.token"},
+		Patterns: []string{emailPattern},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		key      string
+		value    any
+		expected any
+		redacted bool
+	}{
+		{
+			name:     "Key",
+			key:      "password",
+			value:    "secret",
+			expected: "[REDACTED]",
+			redacted: true,
+		},
+		{
+			name:     "KeyCaseInsensitive",
+			key:      "authorization",
+			value:    "Bearer token",
+			expected: "[REDACTED]",
+			redacted: true,
+		},
+		{
+			name:     "NonStringKey",
+			key:      "password",
+			value:    42,
+			expected: "[REDACTED]",
+			redacted: true,
+		},
+		{
+			name:     "NestedKey",
+			key:      "request",
+			value:    Fields{"id": 42, "body": map[string]any{"password": "secret"}},
+			expected: Fields{"id": 42, "body": Fields{"password": "[REDACTED]"}},
+			redacted: true,
+		},
+		{
+			name:     "Path",
+			key:      "user",
+			value:    Fields{"email": "jane", "name": "Jane"},
+			expected: Fields{"email": "[REDACTED]", "name": "Jane"},
+			redacted: true,
+		},
+		{
+			name:     "PathWildcard",
+			key:      "http",
+			value:    Fields{"request": Fields{"token": "abc"}, "token": "def"},
+			expected: Fields{"request": Fields{"token": "[REDACTED]"}, "token": "def"},
+			redacted: true,
+		},
+		{
+			name:     "PathOfAnotherRoot",
+			key:      "admin",
+			value:    Fields{"email": "jane"},
+			expected: Fields{"email": "jane"},
+		},
+		{
+			name:     "Pattern",
+			key:      "message",
+			value:    "sent to jane@example.com and john@example.com",
+			expected: "sent to [REDACTED] and [REDACTED]",
+			redacted: true,
+		},
+		{
+			name:     "PatternInURLValues",
+			key:      "request_params",
+			value:    url.Values{"to": {"jane@example.com"}, "page": {"1"}},
+			expected: Fields{"to": []any{"[REDACTED]"}, "page": []string{"1"}},
+			redacted: true,
+		},
+		{
+			name:     "PatternInError",
+			key:      "error",
+			value:    errors.New("no user jane@example.com"),
+			expected: "no user [REDACTED]",
+			redacted: true,
+		},
+		{
+			name:     "NothingToRedact",
+			key:      "request",
+			value:    Fields{"id": 42, "tags": []string{"a"}},
+			expected: Fields{"id": 42, "tags": []string{"a"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, redacted := r.redact([]string{tc.key}, tc.value)
+
+			assert.Equal(t, tc.redacted, redacted)
+			if err, ok := value.(error); ok {
+				assert.Equal(t, tc.expected, err.Error())
+				assert.ErrorIs(t, err, tc.value.(error))
+				return
+			}
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+}
+
+func TestRedactorDoesNotModifyFields(t *testing.T) {
+	r, err := newRedactor(RedactionConfig{Keys: []string{"password"}})
+	require.NoError(t, err)
+
+	fields := Fields{"user": Fields{"password": "secret"}}
+	_, redacted := r.redact([]string{"user"}, fields["user"])
+
+	assert.True(t, redacted)
+	assert.Equal(t, Fields{"user": Fields{"password": "secret"}}, fields)
+}
+
+func TestRedactorHash(t *testing.T) {
+	r, err := newRedactor(RedactionConfig{
+		Keys:     []string{"email"},
+		Patterns: []string{emailPattern},
+		Mode:     RedactionHash,
+		HashKey:  "key",
+	})
+	require.NoError(t, err)
+
+	hashed, _ := r.redact([]string{"email"}, "jane@example.com")
+	require.IsType(t, "", hashed)
+	assert.True(t, strings.HasPrefix(hashed.(string), redactionHashPrefix))
+	assert.Len(t, hashed, len(redactionHashPrefix)+redactionHashLength)
+
+	again, _ := r.redact([]string{"email"}, "jane@example.com")
+	assert.Equal(t, hashed, again)
+
+	other, _ := r.redact([]string{"email"}, "john@example.com")
+	assert.NotEqual(t, hashed, other)
+
+	assert.Equal(t, "sent to "+hashed.(string), r.redactString("sent to jane@example.com"))
+
+	otherKey, err := newRedactor(RedactionConfig{Keys: []string{"email"}, Mode: RedactionHash})
+	require.NoError(t, err)
+	unkeyed, _ := otherKey.redact([]string{"email"}, "jane@example.com")
+	assert.NotEqual(t, hashed, unkeyed)
+}
+
+func TestLoggerRedaction(t *testing.T) {
+	for _, backend := range []string{BackendLogrus, BackendSlog} {
+		t.Run(backend, func(t *testing.T) {
+			config := logConfigForTest(withJSON)
+			config.Backend = backend
+			config.Redaction = RedactionConfig{
+				Keys:     []string{"password"},
+				Paths:    []string{"http.request_params.card"},
+				Patterns: []string{emailPattern},
+				Mask:     "***",
+			}
+
+			var buffer bytes.Buffer
+			l, err := NewBuilder(config).
+				SetFields(Fields{"password": "builder"}).
+				BuildTestLogger(&buffer)
+			require.NoError(t, err)
+
+			params := url.Values{"card": {"4242"}, "page": {"1"}}
+			l.WithFields(Fields{"http": Fields{"request_params": params}}).
+				WithError(errors.New("no user jane@example.com")).
+				Errorf("login of %s", "jane@example.com")
+
+			var fields map[string]any
+			require.NoError(t, json.Unmarshal(buffer.Bytes(), &fields))
+
+			assert.Equal(t, "login of ***", fields[fieldKeyMsg])
+			assert.Equal(t, "***", fields["password"])
+			assert.Equal(t, "no user ***", fields["error"])
+			assert.Equal(t, map[string]any{
+				"request_params": map[string]any{"card": "***", "page": []any{"1"}},
+			}, fields["http"])
+
+			assert.Equal(t, url.Values{"card": {"4242"}, "page": {"1"}}, params)
+		})
+	}
+}
+
+// recordingHook records the entries, like the Sentry hook gets them.
+type recordingHook struct {
+	entries []*logrus.Entry
+}
+
+func (h *recordingHook) Levels() []logrus.Level { return logrus.AllLevels }
+
+func (h *recordingHook) Fire(entry *logrus.Entry) error {
+	h.entries = append(h.entries, entry)
+	return nil
+}
+
+func TestRedactionHookBeforeTracking(t *testing.T) {
+	config := logConfigForTest(withJSON)
+	config.Redaction = RedactionConfig{Keys: []string{"password"}}
+
+	var buffer bytes.Buffer
+	l, err := NewBuilder(config).BuildTestLogger(&buffer)
+	require.NoError(t, err)
+
+	hook := &recordingHook{}
+	l.(*logrusLogEntry).entry.Logger.AddHook(hook)
+
+	l.WithFields(Fields{"password": "secret"}).WithError(errors.New("failed")).Errorf("test")
+
+	require.Len(t, hook.entries, 1)
+	assert.Equal(t, "[REDACTED]", hook.entries[0].Data["password"])
+}
+
+// recordingHandler records the attributes of the records, like the
+// tracking handler gets them.
+type recordingHandler struct {
+	attrs map[string]any
+}
+
+func (h *recordingHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *recordingHandler) Handle(_ context.Context, record slog.Record) error {
+	record.Attrs(func(a slog.Attr) bool {
+		h.attrs[a.Key] = a.Value.Any()
+		return true
+	})
+	return nil
+}
+
+func (h *recordingHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h *recordingHandler) WithGroup(string) slog.Handler      { return h }
+
+func TestRedactionHandlerGroups(t *testing.T) {
+	r, err := newRedactor(RedactionConfig{Paths: []string{"request.user.email"}})
+	require.NoError(t, err)
+
+	next := &recordingHandler{attrs: map[string]any{}}
+	l := slog.New(newRedactionHandler(next, r)).WithGroup("request")
+
+	l.Info("test", slog.Group("user", slog.String("email", "jane"), slog.String("name", "Jane")), slog.String("email", "john"))
+
+	require.Contains(t, next.attrs, "user")
+	assert.Equal(t, "[REDACTED]", next.attrs["user"].([]slog.Attr)[0].Value.String())
+	assert.Equal(t, "Jane", next.attrs["user"].([]slog.Attr)[1].Value.String())
+	assert.Equal(t, "john", next.attrs["email"])
+}
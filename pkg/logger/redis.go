@@ -4,8 +4,6 @@ import (
 	"context"
 
 	"github.com/redis/go-redis/v9"
-
-	"github.com/scribd/go-sdk/pkg/instrumentation"
 )
 
 type (
@@ -19,14 +17,7 @@ func NewRedisLogger(l Logger) *RedisLogger {
 }
 
 func (r *RedisLogger) Printf(ctx context.Context, format string, v ...any) {
-	logContext := instrumentation.TraceLogs(ctx)
-
-	r.logger.WithFields(Fields{
-		"dd": Fields{
-			"trace_id": logContext.TraceID,
-			"span_id":  logContext.SpanID,
-		},
-	}).Errorf(format, v...)
+	r.logger.ErrorContext(ctx, format, v...)
 }
 
 func SetRedisLogger(logger Logger) {
@@ -0,0 +1,420 @@
+package logger
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/scribd/go-sdk/internal/pkg/configuration/validation"
+	"github.com/scribd/go-sdk/pkg/metrics"
+)
+
+const (
+	// defaultSamplingInterval is the interval of the sampling counters,
+	// unless the configuration sets another one.
+	defaultSamplingInterval = time.Second
+	// defaultSamplingSummaryInterval is the interval of the summary of the
+	// suppressed entries, unless the configuration sets another one.
+	defaultSamplingSummaryInterval = time.Minute
+
+	// samplingTemplates is the number of templates counted by a policy.
+	// Beyond it, the counter of the least recently logged template is
+	// evicted, so that the counters take a bounded amount of memory.
+	samplingTemplates = 1024
+
+	// samplingSuppressedMetric counts the suppressed entries, tagged with
+	// their level and with the output they were suppressed from, "logs"
+	// or "tracking".
+	samplingSuppressedMetric = "logger.sampling.suppressed"
+)
+
+// SamplingConfig configures the sampling of the log entries, so that the
+// hot paths logging the same entries over and over do not flood the logs.
+// The entries sampled out of the logs and of the error reports are counted,
+// and the counts are logged and sent as a metric every SummaryInterval.
+type SamplingConfig struct {
+	// Logs samples the entries written to the sinks.
+	Logs SamplingPolicy `mapstructure:"logs"`
+	// Tracking samples the errors reported to Sentry, independently of
+	// the logs.
+	Tracking SamplingPolicy `mapstructure:"tracking"`
+	// SummaryInterval is the interval of the summary, a minute by default.
+	SummaryInterval time.Duration `mapstructure:"summary_interval"`
+}
+
+// SamplingPolicy samples the entries of each level and message template.
+// In each Interval, the First entries of a template are kept, then every
+// Thereafter-th one; the others are dropped. The kept entries are then kept
+// at the rate of their level, if it has one. The Fatal and Panic entries are
+// never sampled.
+type SamplingPolicy struct {
+	// Interval is the interval of the counters, a second by default.
+	Interval time.Duration `mapstructure:"interval"`
+	// First is the number of entries of a template kept in each interval.
+	// Zero disables the sampling by template.
+	First int `mapstructure:"first" validate:"gte=0"`
+	// Thereafter keeps every Thereafter-th entry after the First ones.
+	// Zero drops them all.
+	Thereafter int `mapstructure:"thereafter" validate:"gte=0"`
+	// Rates are the fractions of the entries kept by level, such as
+	// {"debug": 0.1}. The levels without a rate are all kept.
+	Rates map[string]float64 `mapstructure:"rates"`
+}
+
+// Validate checks that the rates are between 0 and 1, for known levels.
+func (p *SamplingPolicy) Validate() error {
+	var errs validation.Errors
+
+	for level, rate := range p.Rates {
+		if _, err := logrus.ParseLevel(level); err != nil {
+			errs = append(errs, &validation.FieldError{Path: "rates", Err: fmt.Errorf("unknown log level %q", level)})
+		} else if rate < 0 || rate > 1 {
+			errs = append(errs, &validation.FieldError{Path: "rates", Err: fmt.Errorf("rate of %s must be between 0 and 1, got %v", level, rate)})
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+func (p *SamplingPolicy) enabled() bool {
+	return p.First > 0 || len(p.Rates) > 0
+}
+
+// sampledOut is the set of outputs an entry is sampled out of.
+type sampledOut uint8
+
+const (
+	sampledOutLogs sampledOut = 1 << iota
+	sampledOutTracking
+)
+
+type sampledOutKey struct{}
+
+// withSampledOut returns a context carrying the outputs the entry logged
+// with it is sampled out of, for the sinks and the tracking hook to skip it.
+func withSampledOut(ctx context.Context, out sampledOut) context.Context {
+	return context.WithValue(ctx, sampledOutKey{}, out)
+}
+
+// sampledOutOf returns the outputs an entry logged with ctx is sampled out
+// of.
+func sampledOutOf(ctx context.Context) sampledOut {
+	if ctx == nil {
+		return 0
+	}
+
+	out, _ := ctx.Value(sampledOutKey{}).(sampledOut)
+	return out
+}
+
+// sampler samples the entries of the loggers built together.
+type sampler struct {
+	logs     *samplingPolicy
+	tracking *samplingPolicy
+	// tracked is whether the errors are reported, the entries sampled out
+	// of the logs being dropped otherwise.
+	tracked bool
+
+	suppressedLogs     [logrus.TraceLevel + 1]atomic.Int64
+	suppressedTracking [logrus.TraceLevel + 1]atomic.Int64
+
+	// logger logs the summary, without sampling it.
+	logger          Logger
+	metrics         metrics.Metrics
+	summaryInterval time.Duration
+
+	stopOnce sync.Once
+	done     chan struct{}
+	stopped  chan struct{}
+}
+
+// newSampler returns the sampler of the configuration, or nil if it samples
+// nothing.
+func newSampler(config SamplingConfig, tracked bool, m metrics.Metrics) *sampler {
+	if !config.Logs.enabled() && !(tracked && config.Tracking.enabled()) {
+		return nil
+	}
+
+	s := &sampler{
+		logs:            newSamplingPolicy(config.Logs),
+		tracked:         tracked,
+		metrics:         m,
+		summaryInterval: config.SummaryInterval,
+	}
+
+	if tracked {
+		s.tracking = newSamplingPolicy(config.Tracking)
+	}
+
+	if s.summaryInterval <= 0 {
+		s.summaryInterval = defaultSamplingSummaryInterval
+	}
+
+	return s
+}
+
+// sample returns the outputs the entry is sampled out of, and whether it is
+// logged at all.
+func (s *sampler) sample(level logrus.Level, template string) (sampledOut, bool) {
+	if s == nil || level <= logrus.FatalLevel {
+		return 0, true
+	}
+
+	var out sampledOut
+
+	if !s.logs.keep(level, template) {
+		out |= sampledOutLogs
+		s.suppressedLogs[level].Add(1)
+	}
+
+	// Only the errors are reported.
+	reported := s.tracked && level == logrus.ErrorLevel
+	if reported && !s.tracking.keep(level, template) {
+		out |= sampledOutTracking
+		s.suppressedTracking[level].Add(1)
+	}
+
+	if out&sampledOutLogs != 0 && (!reported || out&sampledOutTracking != 0) {
+		return out, false
+	}
+
+	return out, true
+}
+
+// start logs the summary every summary interval, until the sampler stops.
+func (s *sampler) start() {
+	s.done = make(chan struct{})
+	s.stopped = make(chan struct{})
+
+	go func() {
+		defer close(s.stopped)
+
+		ticker := time.NewTicker(s.summaryInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.summarize()
+			case <-s.done:
+				s.summarize()
+				return
+			}
+		}
+	}()
+}
+
+// stop logs the last summary and stops the sampler.
+func (s *sampler) stop() {
+	if s == nil {
+		return
+	}
+
+	if s.done == nil {
+		s.summarize()
+		return
+	}
+
+	s.stopOnce.Do(func() {
+		close(s.done)
+		<-s.stopped
+	})
+}
+
+// summarize logs the numbers of entries suppressed since the last summary,
+// and sends them as a metric.
+func (s *sampler) summarize() {
+	logs, logsTotal := s.collect(&s.suppressedLogs, "logs")
+	reports, reportsTotal := s.collect(&s.suppressedTracking, "tracking")
+
+	if logsTotal == 0 && reportsTotal == 0 {
+		return
+	}
+
+	if s.logger != nil {
+		s.logger.WithFields(Fields{
+			"suppressed_entries": logs,
+			"suppressed_reports": reports,
+		}).Warnf("Log sampling suppressed %d entries and %d error reports", logsTotal, reportsTotal)
+	}
+}
+
+// collect resets the counts of an output, returning them by level, and
+// sends them as a metric.
+func (s *sampler) collect(counts *[logrus.TraceLevel + 1]atomic.Int64, output string) (Fields, int64) {
+	fields := Fields{}
+	total := int64(0)
+
+	for level := range counts {
+		n := counts[level].Swap(0)
+		if n == 0 {
+			continue
+		}
+
+		name := string(toLevel(logrus.Level(level)))
+		fields[name] = n
+		total += n
+
+		if s.metrics != nil {
+			_ = s.metrics.Count(samplingSuppressedMetric, n, []string{"level:" + name, "output:" + output}, 1)
+		}
+	}
+
+	return fields, total
+}
+
+// samplingPolicy is a SamplingPolicy ready to sample.
+type samplingPolicy struct {
+	interval   time.Duration
+	first      uint64
+	thereafter uint64
+	counters   *samplingCounters
+	// rates are the rates by level, negative for the levels without one.
+	rates  [logrus.TraceLevel + 1]float64
+	now    func() time.Time
+	random func() float64
+}
+
+// newSamplingPolicy returns the policy of the configuration, or nil if it
+// samples nothing.
+func newSamplingPolicy(config SamplingPolicy) *samplingPolicy {
+	if !config.enabled() {
+		return nil
+	}
+
+	p := &samplingPolicy{
+		interval:   config.Interval,
+		first:      uint64(config.First),
+		thereafter: uint64(config.Thereafter),
+		now:        time.Now,
+		random:     rand.Float64,
+	}
+
+	if p.interval <= 0 {
+		p.interval = defaultSamplingInterval
+	}
+
+	if p.first > 0 {
+		p.counters = newSamplingCounters(samplingTemplates)
+	}
+
+	for i := range p.rates {
+		p.rates[i] = -1
+	}
+	for name, rate := range config.Rates {
+		// The levels are validated with the configuration.
+		if level, err := logrus.ParseLevel(name); err == nil {
+			p.rates[level] = rate
+		}
+	}
+
+	return p
+}
+
+// keep reports whether the entry is kept.
+func (p *samplingPolicy) keep(level logrus.Level, template string) bool {
+	if p == nil {
+		return true
+	}
+
+	if p.counters != nil {
+		n := p.counters.inc(samplingKey{level: level, template: template}, p.now(), p.interval)
+		if n > p.first && (p.thereafter == 0 || (n-p.first)%p.thereafter != 0) {
+			return false
+		}
+	}
+
+	if rate := p.rates[level]; rate >= 0 && p.random() >= rate {
+		return false
+	}
+
+	return true
+}
+
+// samplingKey identifies the entries counted together.
+type samplingKey struct {
+	level    logrus.Level
+	template string
+}
+
+// samplingCounters counts the entries of the current interval by level and
+// template, keeping the counters of the most recently logged templates only.
+type samplingCounters struct {
+	mu  sync.Mutex
+	max int
+	// counters holds the elements of recent, which lists the counters from
+	// the most to the least recently incremented.
+	counters map[samplingKey]*list.Element
+	recent   *list.List
+}
+
+// samplingCounter counts the entries of a key in the current interval.
+type samplingCounter struct {
+	key     samplingKey
+	resetAt time.Time
+	count   uint64
+}
+
+func newSamplingCounters(maxKeys int) *samplingCounters {
+	return &samplingCounters{
+		max:      maxKeys,
+		counters: make(map[samplingKey]*list.Element, maxKeys),
+		recent:   list.New(),
+	}
+}
+
+// inc counts an entry, resetting the count of its key first if the interval
+// is over, and returns the count.
+func (c *samplingCounters) inc(key samplingKey, now time.Time, interval time.Duration) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.counters[key]
+	if ok {
+		c.recent.MoveToFront(e)
+	} else {
+		if c.recent.Len() >= c.max {
+			oldest := c.recent.Back()
+			c.recent.Remove(oldest)
+			delete(c.counters, oldest.Value.(*samplingCounter).key)
+		}
+
+		e = c.recent.PushFront(&samplingCounter{key: key})
+		c.counters[key] = e
+	}
+
+	counter := e.Value.(*samplingCounter)
+	if !now.Before(counter.resetAt) {
+		counter.resetAt = now.Add(interval)
+		counter.count = 0
+	}
+	counter.count++
+
+	return counter.count
+}
+
+// trackingSamplingHook skips the entries sampled out of an output, the
+// error reports for the tracking hook and the logs for the breadcrumbs hook,
+// then fires the hook.
+type trackingSamplingHook struct {
+	logrus.Hook
+	out sampledOut
+}
+
+func (h *trackingSamplingHook) Fire(entry *logrus.Entry) error {
+	if sampledOutOf(entry.Context)&h.out != 0 {
+		return nil
+	}
+
+	return h.Hook.Fire(entry)
+}
@@ -0,0 +1,266 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scribd/go-sdk/pkg/metrics"
+	"github.com/scribd/go-sdk/pkg/tracking"
+	"github.com/scribd/go-sdk/pkg/tracking/trackingtest"
+)
+
+func newTestSamplingPolicy(t *testing.T, config SamplingPolicy) (*samplingPolicy, *time.Time) {
+	t.Helper()
+
+	p := newSamplingPolicy(config)
+	require.NotNil(t, p)
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	p.now = func() time.Time { return now }
+
+	return p, &now
+}
+
+func TestSamplingPolicyFirstThereafter(t *testing.T) {
+	p, now := newTestSamplingPolicy(t, SamplingPolicy{First: 2, Thereafter: 3, Interval: time.Minute})
+
+	var kept []int
+	for i := 1; i <= 10; i++ {
+		if p.keep(logrus.InfoLevel, "GET %s") {
+			kept = append(kept, i)
+		}
+	}
+	assert.Equal(t, []int{1, 2, 5, 8}, kept)
+
+	assert.True(t, p.keep(logrus.InfoLevel, "POST %s"), "another template")
+	assert.True(t, p.keep(logrus.WarnLevel, "GET %s"), "another level")
+
+	*now = now.Add(time.Minute)
+	assert.True(t, p.keep(logrus.InfoLevel, "GET %s"), "another interval")
+	assert.True(t, p.keep(logrus.InfoLevel, "GET %s"))
+	assert.False(t, p.keep(logrus.InfoLevel, "GET %s"))
+}
+
+func TestSamplingPolicyWithoutThereafter(t *testing.T) {
+	p, _ := newTestSamplingPolicy(t, SamplingPolicy{First: 1})
+
+	assert.True(t, p.keep(logrus.ErrorLevel, "failed"))
+	for i := 0; i < 10; i++ {
+		assert.False(t, p.keep(logrus.ErrorLevel, "failed"))
+	}
+}
+
+func TestSamplingCountersEviction(t *testing.T) {
+	c := newSamplingCounters(2)
+	now := time.Now()
+	get := samplingKey{level: logrus.InfoLevel, template: "GET %s"}
+	post := samplingKey{level: logrus.InfoLevel, template: "POST %s"}
+	put := samplingKey{level: logrus.InfoLevel, template: "PUT %s"}
+
+	assert.Equal(t, uint64(1), c.inc(get, now, time.Minute))
+	assert.Equal(t, uint64(1), c.inc(post, now, time.Minute))
+	assert.Equal(t, uint64(2), c.inc(get, now, time.Minute))
+
+	// POST is the least recently logged template, it's evicted.
+	assert.Equal(t, uint64(1), c.inc(put, now, time.Minute))
+	assert.Len(t, c.counters, 2)
+	assert.Equal(t, uint64(3), c.inc(get, now, time.Minute))
+	assert.Equal(t, uint64(1), c.inc(post, now, time.Minute))
+}
+
+func TestSamplingPolicyRates(t *testing.T) {
+	p, _ := newTestSamplingPolicy(t, SamplingPolicy{Rates: map[string]float64{"debug": 0.25, "info": 0}})
+
+	random := 0.0
+	p.random = func() float64 { return random }
+
+	assert.True(t, p.keep(logrus.DebugLevel, "debug"))
+	assert.False(t, p.keep(logrus.InfoLevel, "info"))
+	assert.True(t, p.keep(logrus.WarnLevel, "warn"))
+
+	random = 0.5
+	assert.False(t, p.keep(logrus.DebugLevel, "debug"))
+	assert.True(t, p.keep(logrus.WarnLevel, "warn"))
+}
+
+func TestNewSampler(t *testing.T) {
+	assert.Nil(t, newSampler(SamplingConfig{}, true, nil))
+	assert.Nil(t, newSampler(SamplingConfig{Tracking: SamplingPolicy{First: 1}}, false, nil))
+
+	s := newSampler(SamplingConfig{Tracking: SamplingPolicy{First: 1}}, true, nil)
+	require.NotNil(t, s)
+	assert.Nil(t, s.logs)
+	assert.NotNil(t, s.tracking)
+	assert.Equal(t, defaultSamplingSummaryInterval, s.summaryInterval)
+}
+
+func TestSamplerSample(t *testing.T) {
+	config := SamplingConfig{
+		Logs:     SamplingPolicy{First: 1},
+		Tracking: SamplingPolicy{First: 2},
+	}
+
+	testCases := []struct {
+		name     string
+		tracked  bool
+		level    logrus.Level
+		expected []sampledOut
+		logged   []bool
+	}{
+		{
+			name:     "Info",
+			tracked:  true,
+			level:    logrus.InfoLevel,
+			expected: []sampledOut{0, sampledOutLogs, sampledOutLogs},
+			logged:   []bool{true, false, false},
+		},
+		{
+			name:     "ErrorTracked",
+			tracked:  true,
+			level:    logrus.ErrorLevel,
+			expected: []sampledOut{0, sampledOutLogs, sampledOutLogs | sampledOutTracking},
+			logged:   []bool{true, true, false},
+		},
+		{
+			name:     "ErrorNotTracked",
+			level:    logrus.ErrorLevel,
+			expected: []sampledOut{0, sampledOutLogs, sampledOutLogs},
+			logged:   []bool{true, false, false},
+		},
+		{
+			name:     "Fatal",
+			tracked:  true,
+			level:    logrus.FatalLevel,
+			expected: []sampledOut{0, 0, 0},
+			logged:   []bool{true, true, true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newSampler(config, tc.tracked, nil)
+
+			for i := range tc.expected {
+				out, logged := s.sample(tc.level, "template")
+				assert.Equal(t, tc.expected[i], out, "entry %d", i)
+				assert.Equal(t, tc.logged[i], logged, "entry %d", i)
+			}
+		})
+	}
+}
+
+// countingMetrics records the counts sent.
+type countingMetrics struct {
+	metrics.Metrics
+
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func (m *countingMetrics) Count(name string, value int64, tags []string, _ float64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sort.Strings(tags)
+	m.counts[name+"|"+strings.Join(tags, ",")] += value
+
+	return nil
+}
+
+func TestLoggerSampling(t *testing.T) {
+	for _, backend := range []string{BackendLogrus, BackendSlog} {
+		t.Run(backend, func(t *testing.T) {
+			config := logConfigForTest(withoutJSON)
+			config.Backend = backend
+			config.Sampling = SamplingConfig{
+				Logs: SamplingPolicy{First: 2, Interval: time.Hour},
+			}
+
+			m := &countingMetrics{counts: map[string]int64{}}
+
+			var buffer bytes.Buffer
+			l, err := NewBuilder(config).SetMetrics(m).BuildTestLogger(&buffer)
+			require.NoError(t, err)
+
+			for i := 0; i < 5; i++ {
+				l.WithFields(Fields{"i": i}).Infof("GET %s", "/")
+				WithContext(l, t.Context()).Debugf("debug %d", i)
+			}
+			l.Infof("another template")
+
+			assert.Equal(t, 2, strings.Count(buffer.String(), "GET /"))
+			assert.Equal(t, 2, strings.Count(buffer.String(), "message=\"debug"))
+			assert.Contains(t, buffer.String(), "another template")
+
+			buffer.Reset()
+			require.NoError(t, Close(l))
+
+			assert.Contains(t, buffer.String(), "Log sampling suppressed 6 entries and 0 error reports")
+			assert.Contains(t, buffer.String(), "level=warn")
+			assert.Equal(t, map[string]int64{
+				"logger.sampling.suppressed|level:debug,output:logs": 3,
+				"logger.sampling.suppressed|level:info,output:logs":  3,
+			}, m.counts)
+		})
+	}
+}
+
+func TestLoggerTrackingSampling(t *testing.T) {
+	config := logConfigForTest(withJSON)
+	config.Sampling = SamplingConfig{
+		Logs:     SamplingPolicy{First: 1, Interval: time.Hour},
+		Tracking: SamplingPolicy{First: 2, Interval: time.Hour},
+	}
+
+	var buffer bytes.Buffer
+	l, err := NewBuilder(config).BuildTestLogger(&buffer)
+	require.NoError(t, err)
+
+	// The test logger does not report, so the tracking is sampled as set
+	// up by Build.
+	entry := l.(*logrusLogEntry)
+	entry.sampler.tracked = true
+	entry.sampler.tracking = newSamplingPolicy(config.Sampling.Tracking)
+
+	hook := &recordingHook{}
+	entry.entry.Logger.AddHook(&trackingSamplingHook{Hook: hook, out: sampledOutTracking})
+
+	for i := 0; i < 3; i++ {
+		l.WithError(errors.New("failed")).Errorf("query failed")
+	}
+
+	assert.Equal(t, 1, strings.Count(buffer.String(), "query failed"))
+	assert.Len(t, hook.entries, 2)
+}
+
+func TestSlogTrackingHandlerSampling(t *testing.T) {
+	var buffer bytes.Buffer
+	config := slogConfigForTest(withoutJSON, "info")
+	hook, err := tracking.NewHook(trackingtest.New(), &tracking.Config{})
+	require.NoError(t, err)
+
+	handler := newTrackingHandler(newSlogHandler(newTestSinks(config, &buffer)), hook)
+
+	levels, err := NewLevelController(config)
+	require.NoError(t, err)
+
+	s := newSampler(SamplingConfig{Logs: SamplingPolicy{First: 1}, Tracking: SamplingPolicy{First: 2}}, true, nil)
+	l := newSlogLogger(handler, nil, levels, "", nil).withSampler(s)
+
+	for i := 0; i < 3; i++ {
+		l.WithError(errors.New("failed")).Errorf("query failed")
+	}
+
+	assert.Equal(t, 1, strings.Count(buffer.String(), "query failed"))
+	assert.Equal(t, int64(1), s.suppressedTracking[logrus.ErrorLevel].Load())
+	assert.Equal(t, int64(2), s.suppressedLogs[logrus.ErrorLevel].Load())
+}
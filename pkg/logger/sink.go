@@ -0,0 +1,661 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/twmb/franz-go/pkg/kgo"
+	lumberjack "gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/scribd/go-sdk/internal/pkg/configuration/validation"
+)
+
+// The sink types.
+const (
+	// SinkConsole writes the entries to STDOUT.
+	SinkConsole = "console"
+	// SinkFile writes the entries to a file rotated by size.
+	SinkFile = "file"
+	// SinkSyslog sends the entries to a syslog server, in the RFC 5424
+	// format.
+	SinkSyslog = "syslog"
+	// SinkUDP sends the entries as UDP datagrams.
+	SinkUDP = "udp"
+	// SinkUnix writes the entries to a Unix domain socket.
+	SinkUnix = "unix"
+	// SinkKafka publishes the entries to a Kafka topic.
+	SinkKafka = "kafka"
+)
+
+const (
+	// defaultSinkBufferSize is the number of entries buffered by the
+	// network and Kafka sinks, which are always asynchronous.
+	defaultSinkBufferSize = 1024
+	// sinkDialTimeout and sinkWriteTimeout bound the time a network sink
+	// waits for its server.
+	sinkDialTimeout  = 5 * time.Second
+	sinkWriteTimeout = 5 * time.Second
+	// syslogFacility is the facility of the syslog messages, user-level.
+	syslogFacility = 1
+
+	// fileMaxSize is the maximum size in megabytes of the log file
+	// before it gets rotated. It defaults to 100 megabytes.
+	fileMaxSize = 100
+	// fileWillCompress determines if the rotated log files should
+	// be compressed using gzip.
+	fileWillCompress = true
+	// fileMaxAge is the maximum number of days to retain old log
+	// files based on the timestamp encoded in their filename. Note
+	// that a day is defined as 24 hours and may not exactly
+	// correspond to calendar days due to daylight savings, leap
+	// seconds, etc. The default is not to remove old log files
+	// based on age.
+	fileMaxAge = 28
+)
+
+// SinkConfig configures a destination of the log entries.
+type SinkConfig struct {
+	// Type is one of the sink types, such as SinkConsole.
+	Type string `mapstructure:"type" validate:"oneof=console file syslog udp unix kafka"`
+	// Level is the level of the entries written to the sink. By default,
+	// the sink writes all the entries of the loggers, whose level can be
+	// changed at runtime.
+	Level      string `mapstructure:"level" validate:"omitempty,loglevel"`
+	JSONFormat bool   `mapstructure:"json_format"`
+	// BufferSize is the number of entries buffered by an asynchronous
+	// sink. When the buffer is full, the entries are dropped rather than
+	// blocking the logging goroutine. The console and file sinks are
+	// synchronous unless it is set; the others are always asynchronous,
+	// with a buffer of 1024 entries by default.
+	BufferSize int `mapstructure:"buffer_size" validate:"gte=0"`
+
+	// FileLocation and FileName are the location of the file sink, the
+	// file_location and file_name of the logger configuration by default.
+	FileLocation string         `mapstructure:"file_location"`
+	FileName     string         `mapstructure:"file_name"`
+	Rotation     RotationConfig `mapstructure:"rotation"`
+
+	// Network is the network of the syslog sink, one of "udp" (the
+	// default), "tcp", "unix" and "unixgram", or of the unix sink, one of
+	// "unix" (the default) and "unixgram".
+	Network string `mapstructure:"network"`
+	// Address is the address of the syslog, udp and unix sinks, such as
+	// "localhost:514" or "/dev/log".
+	Address string `mapstructure:"address"`
+	// Tag is the APP-NAME of the syslog messages, the name of the
+	// executable by default.
+	Tag string `mapstructure:"tag"`
+
+	// Topic is the topic of the Kafka sink. The records are published
+	// with the producer set with Builder.SetKafkaProducer.
+	Topic string `mapstructure:"topic"`
+}
+
+// RotationConfig configures the rotation of the file sink.
+type RotationConfig struct {
+	// MaxSize is the size in megabytes of the file before it gets
+	// rotated, 100 by default.
+	MaxSize int `mapstructure:"max_size" validate:"gte=0"`
+	// MaxAge is the number of days to retain the rotated files, 28 by
+	// default.
+	MaxAge int `mapstructure:"max_age" validate:"gte=0"`
+	// MaxBackups is the number of rotated files to retain, all of them by
+	// default.
+	MaxBackups int `mapstructure:"max_backups" validate:"gte=0"`
+	// Compress determines if the rotated files are compressed with gzip,
+	// which they are by default.
+	Compress *bool `mapstructure:"compress"`
+}
+
+// Validate checks the settings required by the type of the sink.
+func (c *SinkConfig) Validate() error {
+	var errs validation.Errors
+
+	require := func(key, value string) {
+		if value == "" {
+			errs = append(errs, &validation.FieldError{Path: key, Err: fmt.Errorf("is required for the %s sink", c.Type)})
+		}
+	}
+
+	networks := map[string][]string{
+		SinkSyslog: {"", "udp", "tcp", "unix", "unixgram"},
+		SinkUnix:   {"", "unix", "unixgram"},
+	}
+
+	switch c.Type {
+	case SinkSyslog, SinkUDP, SinkUnix:
+		require("address", c.Address)
+	case SinkKafka:
+		require("topic", c.Topic)
+	}
+
+	if c.Network != "" {
+		allowed, ok := networks[c.Type]
+		if !ok || !slices.Contains(allowed, c.Network) {
+			errs = append(errs, &validation.FieldError{Path: "network", Err: fmt.Errorf("unsupported network %q for the %s sink", c.Network, c.Type)})
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// KafkaProducer produces the records of the Kafka sinks. It's implemented
+// by the pubsub/kafka.Publisher and by the franz-go kgo.Client.
+type KafkaProducer interface {
+	Produce(ctx context.Context, r *kgo.Record, promise func(*kgo.Record, error))
+}
+
+// sinkConfigs returns the configured sinks, or the console and file sinks
+// of the console_* and file_* settings if none is.
+func (c *Config) sinkConfigs() []SinkConfig {
+	if len(c.Sinks) > 0 {
+		sinks := make([]SinkConfig, len(c.Sinks))
+		for i, sink := range c.Sinks {
+			if sink.FileLocation == "" {
+				sink.FileLocation = c.FileLocation
+			}
+			if sink.FileName == "" {
+				sink.FileName = c.FileName
+			}
+			sinks[i] = sink
+		}
+
+		return sinks
+	}
+
+	// Logging to both the console and the file has always been in the JSON
+	// format, whatever the format settings, to ease the log processing.
+	consoleJSON, fileJSON := c.ConsoleJSONFormat, c.FileJSONFormat
+	if c.ConsoleEnabled && c.FileEnabled {
+		consoleJSON, fileJSON = true, true
+	}
+
+	var sinks []SinkConfig
+	if c.ConsoleEnabled {
+		sinks = append(sinks, SinkConfig{
+			Type:       SinkConsole,
+			Level:      c.ConsoleLevel,
+			JSONFormat: consoleJSON,
+		})
+	}
+	if c.FileEnabled {
+		sinks = append(sinks, SinkConfig{
+			Type:         SinkFile,
+			Level:        c.FileLevel,
+			JSONFormat:   fileJSON,
+			FileLocation: c.FileLocation,
+			FileName:     c.FileName,
+		})
+	}
+
+	return sinks
+}
+
+// Close logs the last sampling summary and writes the entries buffered by
+// the sinks of the logger, then closes them. The sinks are shared by the
+// loggers derived from l, which must not be used after it. A logger not
+// built by this package is left as is.
+func Close(l Logger) error {
+	if cl, ok := l.(*contextLogger); ok {
+		l = cl.Logger
+	}
+
+	if c, ok := l.(interface{ close() error }); ok {
+		return c.close()
+	}
+
+	return nil
+}
+
+// sink is a destination of the log entries.
+type sink struct {
+	name   string
+	writer sinkWriter
+	json   bool
+	level  logrus.Level
+}
+
+func (s *sink) enabled(level logrus.Level) bool {
+	return level <= s.level
+}
+
+// sinks are the sinks of a logger, shared by the loggers derived from it.
+type sinks []*sink
+
+// exit terminates the process after a fatal entry, once the sinks are
+// closed. It is replaced in the tests.
+var exit = os.Exit
+
+// closeAndExit writes the entries buffered by the sinks, which would be lost
+// otherwise, then exits.
+func (s sinks) closeAndExit(code int) {
+	_ = s.Close()
+	exit(code)
+}
+
+// Close flushes and closes the sinks.
+func (s sinks) Close() error {
+	errs := make([]error, 0, len(s))
+	for _, sink := range s {
+		if err := sink.writer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("closing the %s sink: %w", sink.name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// newSinks opens the sinks of the configuration.
+func newSinks(config *Config, producer KafkaProducer) (sinks, error) {
+	configs := config.sinkConfigs()
+	result := make(sinks, 0, len(configs))
+
+	for i, sc := range configs {
+		s, err := newSink(fmt.Sprintf("%s #%d", sc.Type, i), sc, producer)
+		if err != nil {
+			_ = result.Close()
+			return nil, err
+		}
+		result = append(result, s)
+	}
+
+	return result, nil
+}
+
+// newTestSinks returns the sink writing all the entries to out, in the
+// format of the first configured sink.
+func newTestSinks(config *Config, out io.Writer) sinks {
+	configs := config.sinkConfigs()
+
+	return sinks{{
+		name:   "test",
+		writer: &streamWriter{w: out},
+		json:   len(configs) > 0 && configs[0].JSONFormat,
+		level:  logrus.TraceLevel,
+	}}
+}
+
+func newSink(name string, config SinkConfig, producer KafkaProducer) (*sink, error) {
+	level := logrus.TraceLevel
+	if config.Level != "" {
+		var err error
+		if level, err = logrus.ParseLevel(config.Level); err != nil {
+			return nil, fmt.Errorf("%s sink: %w", name, err)
+		}
+	}
+
+	var (
+		writer     sinkWriter
+		bufferSize = config.BufferSize
+	)
+
+	switch config.Type {
+	case SinkConsole:
+		writer = &streamWriter{w: os.Stdout}
+	case SinkFile:
+		writer = &streamWriter{w: newFileWriter(config)}
+	case SinkSyslog:
+		network := config.Network
+		if network == "" {
+			network = "udp"
+		}
+		writer = newSyslogWriter(network, config.Address, config.Tag)
+	case SinkUDP:
+		writer = &streamWriter{w: &connWriter{network: "udp", address: config.Address}}
+	case SinkUnix:
+		network := config.Network
+		if network == "" {
+			network = "unix"
+		}
+		writer = &streamWriter{w: &connWriter{network: network, address: config.Address}}
+	case SinkKafka:
+		if producer == nil {
+			return nil, fmt.Errorf("%s sink: no Kafka producer, see Builder.SetKafkaProducer", name)
+		}
+		writer = &kafkaWriter{producer: producer, topic: config.Topic}
+	default:
+		return nil, fmt.Errorf("%s sink: unknown sink type %q", name, config.Type)
+	}
+
+	writer = &reportingWriter{next: writer, name: name}
+
+	if bufferSize == 0 && config.Type != SinkConsole && config.Type != SinkFile {
+		bufferSize = defaultSinkBufferSize
+	}
+	if bufferSize > 0 {
+		writer = newAsyncWriter(writer, name, bufferSize)
+	}
+
+	return &sink{name: name, writer: writer, json: config.JSONFormat, level: level}, nil
+}
+
+func newFileWriter(config SinkConfig) *lumberjack.Logger {
+	rotation := config.Rotation
+
+	writer := &lumberjack.Logger{
+		Filename:   path.Join(config.FileLocation, config.FileName),
+		MaxSize:    fileMaxSize,
+		MaxAge:     fileMaxAge,
+		MaxBackups: rotation.MaxBackups,
+		Compress:   fileWillCompress,
+	}
+
+	if rotation.MaxSize > 0 {
+		writer.MaxSize = rotation.MaxSize
+	}
+	if rotation.MaxAge > 0 {
+		writer.MaxAge = rotation.MaxAge
+	}
+	if rotation.Compress != nil {
+		writer.Compress = *rotation.Compress
+	}
+
+	return writer
+}
+
+// sinkWriter writes the formatted entries to a sink.
+type sinkWriter interface {
+	writeEntry(level logrus.Level, entry []byte) error
+	Close() error
+}
+
+// streamWriter writes the entries to an io.Writer, one at a time.
+type streamWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (w *streamWriter) writeEntry(_ logrus.Level, entry []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, err := w.w.Write(entry)
+	return err
+}
+
+func (w *streamWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.w == os.Stdout {
+		return nil
+	}
+
+	if closer, ok := w.w.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+// kafkaWriter publishes the entries, without their trailing newline, to a
+// Kafka topic.
+type kafkaWriter struct {
+	producer KafkaProducer
+	topic    string
+}
+
+func (w *kafkaWriter) writeEntry(_ logrus.Level, entry []byte) error {
+	w.producer.Produce(context.Background(), &kgo.Record{
+		Topic: w.topic,
+		Value: bytes.Clone(bytes.TrimSuffix(entry, []byte("\n"))),
+	}, nil)
+
+	return nil
+}
+
+// Close does nothing: the producer is closed by its owner.
+func (w *kafkaWriter) Close() error {
+	return nil
+}
+
+// reportSinkError reports the failure of a sink to STDERR, the loggers not
+// being able to log it.
+var reportSinkError = func(name string, err error) {
+	fmt.Fprintf(os.Stderr, "logger: %s sink: %v\n", name, err)
+}
+
+// reportingWriter reports the first failure of a sink, then the first one
+// after it recovers, rather than every failed entry.
+type reportingWriter struct {
+	next    sinkWriter
+	name    string
+	failing atomic.Bool
+}
+
+func (w *reportingWriter) writeEntry(level logrus.Level, entry []byte) error {
+	err := w.next.writeEntry(level, entry)
+	if err == nil {
+		w.failing.Store(false)
+		return nil
+	}
+
+	if !w.failing.Swap(true) {
+		reportSinkError(w.name, err)
+	}
+
+	return err
+}
+
+func (w *reportingWriter) Close() error {
+	return w.next.Close()
+}
+
+// asyncWriter writes the entries from a goroutine, so that a slow sink does
+// not block the loggers. The entries are dropped when its buffer is full.
+type asyncWriter struct {
+	next    sinkWriter
+	name    string
+	entries chan asyncEntry
+	done    chan struct{}
+	dropped atomic.Int64
+
+	// mu guards closed, so that no entry is sent once the buffer is
+	// closed.
+	mu     sync.RWMutex
+	closed bool
+}
+
+type asyncEntry struct {
+	level logrus.Level
+	entry []byte
+}
+
+func newAsyncWriter(next sinkWriter, name string, bufferSize int) *asyncWriter {
+	w := &asyncWriter{
+		next:    next,
+		name:    name,
+		entries: make(chan asyncEntry, bufferSize),
+		done:    make(chan struct{}),
+	}
+
+	go w.run()
+
+	return w
+}
+
+func (w *asyncWriter) writeEntry(level logrus.Level, entry []byte) error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.closed {
+		return nil
+	}
+
+	select {
+	case w.entries <- asyncEntry{level: level, entry: bytes.Clone(entry)}:
+	default:
+		w.dropped.Add(1)
+	}
+
+	return nil
+}
+
+func (w *asyncWriter) run() {
+	defer close(w.done)
+
+	for e := range w.entries {
+		if dropped := w.dropped.Swap(0); dropped > 0 {
+			reportSinkError(w.name, fmt.Errorf("dropped %d entries, the buffer being full", dropped))
+		}
+
+		// The errors are reported by the next writer.
+		_ = w.next.writeEntry(e.level, e.entry)
+	}
+}
+
+// Close writes the buffered entries, then closes the sink.
+func (w *asyncWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.entries)
+	w.mu.Unlock()
+
+	<-w.done
+
+	return w.next.Close()
+}
+
+// connWriter writes to a network connection, dialed on the first write and
+// dialed again on the write following a failed one.
+type connWriter struct {
+	network string
+	address string
+	conn    net.Conn
+}
+
+func (w *connWriter) Write(p []byte) (int, error) {
+	if w.conn == nil {
+		conn, err := net.DialTimeout(w.network, w.address, sinkDialTimeout)
+		if err != nil {
+			return 0, err
+		}
+		w.conn = conn
+	}
+
+	_ = w.conn.SetWriteDeadline(time.Now().Add(sinkWriteTimeout))
+
+	n, err := w.conn.Write(p)
+	if err != nil {
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+
+	return n, err
+}
+
+func (w *connWriter) Close() error {
+	if w.conn == nil {
+		return nil
+	}
+
+	err := w.conn.Close()
+	w.conn = nil
+
+	return err
+}
+
+// syslogWriter sends the entries to a syslog server in the RFC 5424 format,
+// newline-terminated on the stream networks.
+type syslogWriter struct {
+	mu       sync.Mutex
+	conn     *connWriter
+	stream   bool
+	hostname string
+	tag      string
+	pid      int
+	now      func() time.Time
+}
+
+func newSyslogWriter(network, address, tag string) *syslogWriter {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+
+	if tag == "" {
+		tag = filepath.Base(os.Args[0])
+	}
+
+	return &syslogWriter{
+		conn:     &connWriter{network: network, address: address},
+		stream:   network == "tcp" || network == "unix",
+		hostname: hostname,
+		tag:      tag,
+		pid:      os.Getpid(),
+		now:      time.Now,
+	}
+}
+
+func (w *syslogWriter) writeEntry(level logrus.Level, entry []byte) error {
+	msg := w.format(level, entry)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, err := w.conn.Write(msg)
+	return err
+}
+
+// format returns the syslog message of the entry:
+// <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID - - MSG.
+func (w *syslogWriter) format(level logrus.Level, entry []byte) []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "<%d>1 %s %s %s %d - - ",
+		syslogFacility*8+syslogSeverity(level),
+		w.now().Format("2006-01-02T15:04:05.000000Z07:00"),
+		w.hostname, w.tag, w.pid)
+	b.Write(bytes.TrimRight(entry, "\n"))
+	if w.stream {
+		b.WriteByte('\n')
+	}
+
+	return []byte(b.String())
+}
+
+func (w *syslogWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.conn.Close()
+}
+
+// syslogSeverity returns the syslog severity of a level.
+func syslogSeverity(level logrus.Level) int {
+	switch level {
+	case logrus.PanicLevel:
+		return 0 // Emergency
+	case logrus.FatalLevel:
+		return 2 // Critical
+	case logrus.ErrorLevel:
+		return 3 // Error
+	case logrus.WarnLevel:
+		return 4 // Warning
+	case logrus.InfoLevel:
+		return 6 // Informational
+	default:
+		return 7 // Debug
+	}
+}
@@ -0,0 +1,480 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func TestLoggerSinks(t *testing.T) {
+	for _, backend := range []string{BackendLogrus, BackendSlog} {
+		t.Run(backend, func(t *testing.T) {
+			dir := t.TempDir()
+			config := &Config{
+				Backend:      backend,
+				FileLocation: dir,
+				Sinks: []SinkConfig{
+					{Type: SinkFile, FileName: "json.log", Level: "warn", JSONFormat: true},
+					{Type: SinkFile, FileName: "text.log", Level: "debug"},
+				},
+			}
+
+			l, err := NewBuilder(config).Build()
+			require.NoError(t, err)
+
+			l.WithFields(Fields{"id": 42}).Debugf("debug message")
+			l.WithFields(Fields{"id": 42}).Warnf("warn message")
+			require.NoError(t, Close(l))
+
+			jsonLog, err := os.ReadFile(filepath.Join(dir, "json.log"))
+			require.NoError(t, err)
+			require.Equal(t, 1, strings.Count(string(jsonLog), "\n"))
+
+			var fields map[string]any
+			require.NoError(t, json.Unmarshal(jsonLog, &fields))
+			assert.Equal(t, "warn message", fields[fieldKeyMsg])
+			assert.Equal(t, "warning", fields["level"])
+			assert.Equal(t, float64(42), fields["id"])
+
+			textLog, err := os.ReadFile(filepath.Join(dir, "text.log"))
+			require.NoError(t, err)
+			assert.Equal(t, 2, strings.Count(string(textLog), "\n"))
+			assert.Contains(t, string(textLog), `message="debug message"`)
+			assert.Contains(t, string(textLog), `message="warn message"`)
+			assert.Contains(t, string(textLog), "id=42")
+		})
+	}
+}
+
+func TestLogLevelWithSinks(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   *Config
+		expected logrus.Level
+		err      bool
+	}{
+		{
+			name: "MostVerboseSink",
+			config: &Config{Sinks: []SinkConfig{
+				{Type: SinkConsole, Level: "warn"},
+				{Type: SinkFile, Level: "debug"},
+				{Type: SinkKafka},
+			}},
+			expected: logrus.DebugLevel,
+		},
+		{
+			name: "SinksWithoutLevel",
+			config: &Config{
+				ConsoleLevel: "info",
+				Sinks:        []SinkConfig{{Type: SinkConsole}},
+			},
+			expected: logrus.InfoLevel,
+		},
+		{
+			name:   "UnknownSinkLevel",
+			config: &Config{Sinks: []SinkConfig{{Type: SinkConsole, Level: "verbose"}}},
+			err:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			level, err := logLevel(tc.config)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, level)
+		})
+	}
+}
+
+func TestSinkConfigs(t *testing.T) {
+	t.Run("Legacy", func(t *testing.T) {
+		config := &Config{
+			ConsoleEnabled: true,
+			ConsoleLevel:   "info",
+			FileEnabled:    true,
+			FileJSONFormat: true,
+			FileLevel:      "debug",
+			FileLocation:   "/var/log",
+			FileName:       "app.log",
+		}
+
+		// Logging to both the console and the file forces the JSON format.
+		assert.Equal(t, []SinkConfig{
+			{Type: SinkConsole, Level: "info", JSONFormat: true},
+			{Type: SinkFile, Level: "debug", JSONFormat: true, FileLocation: "/var/log", FileName: "app.log"},
+		}, config.sinkConfigs())
+	})
+
+	t.Run("LegacyConsole", func(t *testing.T) {
+		config := &Config{
+			ConsoleEnabled: true,
+			ConsoleLevel:   "info",
+			FileJSONFormat: true,
+		}
+
+		assert.Equal(t, []SinkConfig{{Type: SinkConsole, Level: "info"}}, config.sinkConfigs())
+	})
+
+	t.Run("FileDefaults", func(t *testing.T) {
+		config := &Config{
+			ConsoleEnabled: true,
+			FileLocation:   "/var/log",
+			FileName:       "app.log",
+			Sinks:          []SinkConfig{{Type: SinkFile, FileName: "audit.log"}},
+		}
+
+		assert.Equal(t, []SinkConfig{
+			{Type: SinkFile, FileLocation: "/var/log", FileName: "audit.log"},
+		}, config.sinkConfigs())
+	})
+}
+
+func TestNewFileWriter(t *testing.T) {
+	writer := newFileWriter(SinkConfig{FileLocation: "/var/log", FileName: "app.log"})
+	assert.Equal(t, "/var/log/app.log", writer.Filename)
+	assert.Equal(t, fileMaxSize, writer.MaxSize)
+	assert.Equal(t, fileMaxAge, writer.MaxAge)
+	assert.True(t, writer.Compress)
+
+	compress := false
+	writer = newFileWriter(SinkConfig{
+		FileLocation: "/var/log",
+		FileName:     "app.log",
+		Rotation:     RotationConfig{MaxSize: 10, MaxAge: 7, MaxBackups: 3, Compress: &compress},
+	})
+	assert.Equal(t, 10, writer.MaxSize)
+	assert.Equal(t, 7, writer.MaxAge)
+	assert.Equal(t, 3, writer.MaxBackups)
+	assert.False(t, writer.Compress)
+}
+
+// sinkErrors records the errors reported by the sinks.
+func sinkErrors(t *testing.T) func() []string {
+	t.Helper()
+
+	var (
+		mu     sync.Mutex
+		errors []string
+	)
+
+	report := reportSinkError
+	reportSinkError = func(name string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errors = append(errors, fmt.Sprintf("%s: %v", name, err))
+	}
+	t.Cleanup(func() { reportSinkError = report })
+
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), errors...)
+	}
+}
+
+// blockingWriter records the entries once it is unblocked.
+type blockingWriter struct {
+	unblock chan struct{}
+	mu      sync.Mutex
+	entries []string
+	closed  bool
+}
+
+func (w *blockingWriter) writeEntry(_ logrus.Level, entry []byte) error {
+	<-w.unblock
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.entries = append(w.entries, string(entry))
+
+	return nil
+}
+
+func (w *blockingWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+func TestAsyncWriter(t *testing.T) {
+	reported := sinkErrors(t)
+
+	next := &blockingWriter{unblock: make(chan struct{})}
+	writer := newAsyncWriter(next, "test", 2)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 10; i++ {
+			assert.NoError(t, writer.writeEntry(logrus.InfoLevel, []byte(fmt.Sprintf("entry %d", i))))
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the writer blocked on the slow sink")
+	}
+
+	close(next.unblock)
+	require.NoError(t, writer.Close())
+
+	assert.True(t, next.closed)
+	assert.Contains(t, next.entries, "entry 0")
+	assert.Less(t, len(next.entries), 10)
+
+	assert.NoError(t, writer.writeEntry(logrus.InfoLevel, []byte("closed")))
+	assert.NotContains(t, next.entries, "closed")
+	for _, err := range reported() {
+		assert.Contains(t, err, "test: dropped")
+	}
+}
+
+// failingWriter fails until it is fixed.
+type failingWriter struct {
+	err error
+}
+
+func (w *failingWriter) writeEntry(logrus.Level, []byte) error { return w.err }
+func (w *failingWriter) Close() error                          { return nil }
+
+func TestReportingWriter(t *testing.T) {
+	reported := sinkErrors(t)
+
+	next := &failingWriter{err: errors.New("connection refused")}
+	writer := &reportingWriter{next: next, name: "udp #0"}
+
+	assert.Error(t, writer.writeEntry(logrus.InfoLevel, nil))
+	assert.Error(t, writer.writeEntry(logrus.InfoLevel, nil))
+	assert.Equal(t, []string{"udp #0: connection refused"}, reported())
+
+	next.err = nil
+	assert.NoError(t, writer.writeEntry(logrus.InfoLevel, nil))
+
+	next.err = errors.New("broken pipe")
+	assert.Error(t, writer.writeEntry(logrus.InfoLevel, nil))
+	assert.Equal(t, []string{"udp #0: connection refused", "udp #0: broken pipe"}, reported())
+}
+
+func TestUDPSink(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	config := &Config{Sinks: []SinkConfig{
+		{Type: SinkUDP, Address: conn.LocalAddr().String(), Level: "info", JSONFormat: true},
+	}}
+
+	l, err := NewBuilder(config).Build()
+	require.NoError(t, err)
+
+	l.Infof("over udp")
+	require.NoError(t, Close(l))
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buffer := make([]byte, 4096)
+	n, _, err := conn.ReadFrom(buffer)
+	require.NoError(t, err)
+
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(buffer[:n], &fields))
+	assert.Equal(t, "over udp", fields[fieldKeyMsg])
+}
+
+func TestUnixSink(t *testing.T) {
+	dir, err := os.MkdirTemp("", "sink")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	address := filepath.Join(dir, "log.sock")
+	listener, err := net.Listen("unix", address)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		buffer := make([]byte, 4096)
+		n, _ := conn.Read(buffer)
+		received <- string(buffer[:n])
+	}()
+
+	config := &Config{
+		Backend: BackendSlog,
+		Sinks:   []SinkConfig{{Type: SinkUnix, Address: address, Level: "info"}},
+	}
+
+	l, err := NewBuilder(config).Build()
+	require.NoError(t, err)
+
+	l.Infof("over unix")
+	require.NoError(t, Close(l))
+
+	select {
+	case entry := <-received:
+		assert.Contains(t, entry, `message="over unix"`)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no entry received")
+	}
+}
+
+func TestSyslogWriterFormat(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 6000, time.UTC)
+
+	testCases := []struct {
+		name     string
+		network  string
+		level    logrus.Level
+		expected string
+	}{
+		{
+			name:     "Datagram",
+			network:  "udp",
+			level:    logrus.ErrorLevel,
+			expected: "<11>1 2026-01-02T03:04:05.000006Z host app 42 - - message=test",
+		},
+		{
+			name:     "Stream",
+			network:  "tcp",
+			level:    logrus.InfoLevel,
+			expected: "<14>1 2026-01-02T03:04:05.000006Z host app 42 - - message=test\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			writer := newSyslogWriter(tc.network, "localhost:514", "app")
+			writer.hostname = "host"
+			writer.pid = 42
+			writer.now = func() time.Time { return now }
+
+			assert.Equal(t, tc.expected, string(writer.format(tc.level, []byte("message=test\n"))))
+		})
+	}
+}
+
+func TestSyslogSink(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	config := &Config{Sinks: []SinkConfig{
+		{Type: SinkSyslog, Address: conn.LocalAddr().String(), Tag: "app", Level: "info"},
+	}}
+
+	l, err := NewBuilder(config).Build()
+	require.NoError(t, err)
+
+	l.Warnf("over syslog")
+	require.NoError(t, Close(l))
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buffer := make([]byte, 4096)
+	n, _, err := conn.ReadFrom(buffer)
+	require.NoError(t, err)
+
+	message := string(buffer[:n])
+	assert.True(t, strings.HasPrefix(message, "<12>1 "), message)
+	assert.Contains(t, message, fmt.Sprintf(" app %d - - ", os.Getpid()))
+	assert.Contains(t, message, `message="over syslog"`)
+}
+
+// recordingProducer records the produced records.
+type recordingProducer struct {
+	mu      sync.Mutex
+	records []*kgo.Record
+}
+
+func (p *recordingProducer) Produce(_ context.Context, r *kgo.Record, _ func(*kgo.Record, error)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.records = append(p.records, r)
+}
+
+func TestKafkaSink(t *testing.T) {
+	config := &Config{Sinks: []SinkConfig{
+		{Type: SinkKafka, Topic: "logs", Level: "info", JSONFormat: true},
+	}}
+
+	t.Run("WithoutProducer", func(t *testing.T) {
+		_, err := NewBuilder(config).Build()
+		assert.EqualError(t, err, "kafka #0 sink: no Kafka producer, see Builder.SetKafkaProducer")
+	})
+
+	t.Run("WithProducer", func(t *testing.T) {
+		producer := &recordingProducer{}
+
+		l, err := NewBuilder(config).SetKafkaProducer(producer).Build()
+		require.NoError(t, err)
+
+		l.Debugf("not published")
+		l.Infof("published")
+		require.NoError(t, Close(l))
+
+		require.Len(t, producer.records, 1)
+		assert.Equal(t, "logs", producer.records[0].Topic)
+
+		var fields map[string]any
+		require.NoError(t, json.Unmarshal(producer.records[0].Value, &fields))
+		assert.Equal(t, "published", fields[fieldKeyMsg])
+		assert.NotContains(t, string(producer.records[0].Value), "\n")
+	})
+}
+
+func TestCloseWithContext(t *testing.T) {
+	producer := &recordingProducer{}
+	config := &Config{Sinks: []SinkConfig{{Type: SinkKafka, Topic: "logs", Level: "info"}}}
+
+	l, err := NewBuilder(config).SetKafkaProducer(producer).Build()
+	require.NoError(t, err)
+
+	l = WithContext(l, context.Background())
+	l.Infof("published")
+	require.NoError(t, Close(l))
+
+	assert.Len(t, producer.records, 1)
+}
+
+func TestFatalfClosesSinks(t *testing.T) {
+	var code int
+	exit = func(c int) { code = c }
+	defer func() { exit = os.Exit }()
+
+	for _, backend := range []string{BackendLogrus, BackendSlog} {
+		t.Run(backend, func(t *testing.T) {
+			code = 0
+			producer := &recordingProducer{}
+			config := &Config{Backend: backend, Sinks: []SinkConfig{{Type: SinkKafka, Topic: "logs", Level: "info"}}}
+
+			l, err := NewBuilder(config).SetKafkaProducer(producer).Build()
+			require.NoError(t, err)
+
+			l.Fatalf("fatal")
+
+			assert.Equal(t, 1, code)
+			assert.Len(t, producer.records, 1)
+		})
+	}
+}
@@ -0,0 +1,422 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/scribd/go-sdk/pkg/tracking"
+)
+
+// The slog levels of the Trace, Fatal and Panic levels, which slog does not
+// define.
+const (
+	slogLevelTrace = slog.LevelDebug - 4
+	slogLevelFatal = slog.LevelError + 4
+	slogLevelPanic = slog.LevelError + 8
+)
+
+// toLogrusLevel returns the logrus level of a slog level, rounding the
+// levels slog allows between the known ones down.
+func toLogrusLevel(level slog.Level) logrus.Level {
+	switch {
+	case level >= slogLevelPanic:
+		return logrus.PanicLevel
+	case level >= slogLevelFatal:
+		return logrus.FatalLevel
+	case level >= slog.LevelError:
+		return logrus.ErrorLevel
+	case level >= slog.LevelWarn:
+		return logrus.WarnLevel
+	case level >= slog.LevelInfo:
+		return logrus.InfoLevel
+	case level >= slog.LevelDebug:
+		return logrus.DebugLevel
+	default:
+		return logrus.TraceLevel
+	}
+}
+
+// newSlogHandler returns the slog handler writing the records to the sinks,
+// with the same keys as the logrus formatter. The handler handles all the
+// levels, the records being filtered by the level controller of the logger,
+// then by the level of each sink.
+func newSlogHandler(sinks sinks) slog.Handler {
+	handlers := make([]slog.Handler, len(sinks))
+	for i, sink := range sinks {
+		handlers[i] = &sinkHandler{sink: sink}
+	}
+
+	if len(handlers) == 1 {
+		return handlers[0]
+	}
+
+	return &multiHandler{handlers: handlers}
+}
+
+// sinkHandler writes the records of the levels of a sink to it, in its
+// format. The record is formatted by a handler of the standard library,
+// created for each record to know the level of the formatted bytes, to which
+// the attributes and groups of the handler are added again.
+type sinkHandler struct {
+	sink *sink
+	with []func(slog.Handler) slog.Handler
+}
+
+func (h *sinkHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.sink.enabled(toLogrusLevel(level))
+}
+
+// Handle writes the record to the sink. Its write errors are reported by
+// the sink, not returned for every record.
+func (h *sinkHandler) Handle(ctx context.Context, record slog.Record) error {
+	var buffer bytes.Buffer
+
+	opts := &slog.HandlerOptions{
+		Level:       slogLevelTrace,
+		ReplaceAttr: replaceSlogAttr,
+	}
+
+	var handler slog.Handler
+	if h.sink.json {
+		handler = slog.NewJSONHandler(&buffer, opts)
+	} else {
+		handler = slog.NewTextHandler(&buffer, opts)
+	}
+
+	for _, with := range h.with {
+		handler = with(handler)
+	}
+
+	if err := handler.Handle(ctx, record); err != nil {
+		return err
+	}
+
+	_ = h.sink.writer.writeEntry(toLogrusLevel(record.Level), buffer.Bytes())
+
+	return nil
+}
+
+func (h *sinkHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.withHandler(func(handler slog.Handler) slog.Handler {
+		return handler.WithAttrs(attrs)
+	})
+}
+
+func (h *sinkHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return h.withHandler(func(handler slog.Handler) slog.Handler {
+		return handler.WithGroup(name)
+	})
+}
+
+func (h *sinkHandler) withHandler(with func(slog.Handler) slog.Handler) slog.Handler {
+	return &sinkHandler{sink: h.sink, with: append(h.with[:len(h.with):len(h.with)], with)}
+}
+
+// multiHandler passes the records to all the handlers that handle them.
+type multiHandler struct {
+	handlers []slog.Handler
+}
+
+func (h *multiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return slices.ContainsFunc(h.handlers, func(handler slog.Handler) bool {
+		return handler.Enabled(ctx, level)
+	})
+}
+
+func (h *multiHandler) Handle(ctx context.Context, record slog.Record) error {
+	var errs []error
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, record.Level) {
+			if err := handler.Handle(ctx, record.Clone()); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (h *multiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.apply(func(handler slog.Handler) slog.Handler {
+		return handler.WithAttrs(attrs)
+	})
+}
+
+func (h *multiHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return h.apply(func(handler slog.Handler) slog.Handler {
+		return handler.WithGroup(name)
+	})
+}
+
+func (h *multiHandler) apply(with func(slog.Handler) slog.Handler) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = with(handler)
+	}
+
+	return &multiHandler{handlers: handlers}
+}
+
+// replaceSlogAttr renames the built-in attributes and formats the time and
+// the levels like the logrus formatter does.
+func replaceSlogAttr(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return a
+	}
+
+	switch a.Key {
+	case slog.TimeKey:
+		return slog.String(fieldKeyTime, a.Value.Time().Format(time.RFC3339))
+	case slog.MessageKey:
+		a.Key = fieldKeyMsg
+	case slog.LevelKey:
+		level, _ := a.Value.Any().(slog.Level)
+		a.Value = slog.StringValue(toLogrusLevel(level).String())
+	}
+
+	return a
+}
+
+// slogLogger implements the `Logger` interface with a log/slog logger. The
+// records are filtered by the level controller.
+type slogLogger struct {
+	logger  *slog.Logger
+	levels  *LevelController
+	name    string
+	sinks   sinks
+	sampler *sampler
+}
+
+func newSlogLogger(handler slog.Handler, fields Fields, levels *LevelController, name string, sinks sinks) *slogLogger {
+	return &slogLogger{
+		logger: slog.New(handler).With(fieldsToArgs(fields)...),
+		levels: levels,
+		name:   name,
+		sinks:  sinks,
+	}
+}
+
+func (l *slogLogger) Tracef(format string, args ...any) {
+	l.log(slogLevelTrace, format, args...)
+}
+
+func (l *slogLogger) Debugf(format string, args ...any) {
+	l.log(slog.LevelDebug, format, args...)
+}
+
+func (l *slogLogger) Infof(format string, args ...any) {
+	l.log(slog.LevelInfo, format, args...)
+}
+
+func (l *slogLogger) Warnf(format string, args ...any) {
+	l.log(slog.LevelWarn, format, args...)
+}
+
+func (l *slogLogger) Errorf(format string, args ...any) {
+	l.log(slog.LevelError, format, args...)
+}
+
+// Fatalf logs the message then exits, like the logrus logger.
+func (l *slogLogger) Fatalf(format string, args ...any) {
+	l.log(slogLevelFatal, format, args...)
+	l.sinks.closeAndExit(1)
+}
+
+// Panicf logs the message then panics with it, like the logrus logger.
+func (l *slogLogger) Panicf(format string, args ...any) {
+	l.log(slogLevelPanic, format, args...)
+	panic(fmt.Sprintf(format, args...))
+}
+
+func (l *slogLogger) TraceContext(ctx context.Context, format string, args ...any) {
+	l.logContext(ctx, slogLevelTrace, format, args...)
+}
+
+func (l *slogLogger) DebugContext(ctx context.Context, format string, args ...any) {
+	l.logContext(ctx, slog.LevelDebug, format, args...)
+}
+
+func (l *slogLogger) InfoContext(ctx context.Context, format string, args ...any) {
+	l.logContext(ctx, slog.LevelInfo, format, args...)
+}
+
+func (l *slogLogger) WarnContext(ctx context.Context, format string, args ...any) {
+	l.logContext(ctx, slog.LevelWarn, format, args...)
+}
+
+func (l *slogLogger) ErrorContext(ctx context.Context, format string, args ...any) {
+	l.logContext(ctx, slog.LevelError, format, args...)
+}
+
+func (l *slogLogger) WithFields(fields Fields) Logger {
+	return &slogLogger{logger: l.logger.With(fieldsToArgs(fields)...), levels: l.levels, name: l.name, sinks: l.sinks, sampler: l.sampler}
+}
+
+// WithError sets an error field, with the same key as the logrus logger.
+func (l *slogLogger) WithError(err error) Logger {
+	return &slogLogger{logger: l.logger.With(logrus.ErrorKey, err), levels: l.levels, name: l.name, sinks: l.sinks, sampler: l.sampler}
+}
+
+func (l *slogLogger) named(name string) Logger {
+	return &slogLogger{logger: l.logger, levels: l.levels, name: name, sinks: l.sinks, sampler: l.sampler}
+}
+
+func (l *slogLogger) loggerName() string {
+	return l.name
+}
+
+func (l *slogLogger) withSampler(s *sampler) Logger {
+	return &slogLogger{logger: l.logger, levels: l.levels, name: l.name, sinks: l.sinks, sampler: s}
+}
+
+func (l *slogLogger) close() error {
+	l.sampler.stop()
+	return l.sinks.Close()
+}
+
+func (l *slogLogger) enabled(level slog.Level) bool {
+	return l.levels.enabled(l.name, toLogrusLevel(level))
+}
+
+func (l *slogLogger) log(level slog.Level, format string, args ...any) {
+	if !l.enabled(level) {
+		return
+	}
+
+	ctx, ok := l.sample(context.Background(), level, format)
+	if !ok {
+		return
+	}
+
+	l.logger.Log(ctx, level, fmt.Sprintf(format, args...))
+}
+
+// logContext logs with the context fields, extracted only if the level is
+// enabled and the record is not sampled out.
+func (l *slogLogger) logContext(ctx context.Context, level slog.Level, format string, args ...any) {
+	if !l.enabled(level) {
+		return
+	}
+
+	ctx, ok := l.sample(ctx, level, format)
+	if !ok {
+		return
+	}
+
+	l.logger.With(fieldsToArgs(ContextFields(ctx))...).Log(ctx, level, fmt.Sprintf(format, args...))
+}
+
+// sample returns the context of the record, carrying the outputs it is
+// sampled out of for the handlers, and whether it is logged at all.
+func (l *slogLogger) sample(ctx context.Context, level slog.Level, format string) (context.Context, bool) {
+	out, ok := l.sampler.sample(toLogrusLevel(level), format)
+	if out != 0 {
+		ctx = withSampledOut(ctx, out)
+	}
+
+	return ctx, ok
+}
+
+// fieldsToArgs returns the fields as slog arguments, sorted by key for a
+// stable output.
+func fieldsToArgs(fields Fields) []any {
+	args := make([]any, 0, len(fields))
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		args = append(args, slog.Any(key, fields[key]))
+	}
+
+	return args
+}
+
+// trackingHandler reports the records to Sentry through the tracking hook,
+// or records them as breadcrumbs of their request, then passes them to the
+// next handler.
+type trackingHandler struct {
+	next        slog.Handler
+	hook        *tracking.Hook
+	breadcrumbs *tracking.BreadcrumbHook
+	fields      Fields
+	group       string
+}
+
+func newTrackingHandler(next slog.Handler, hook *tracking.Hook) slog.Handler {
+	return &trackingHandler{next: next, hook: hook, breadcrumbs: hook.BreadcrumbHook(), fields: Fields{}}
+}
+
+// Enabled reports whether the record is reported or handled by the next
+// handler, the sinks having their own levels.
+func (h *trackingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return slices.Contains(h.hook.Levels(), toLogrusLevel(level)) || h.next.Enabled(ctx, level)
+}
+
+// Handle reports the record, or records it as a breadcrumb, then passes it
+// to the next handler, unless it is sampled out of them.
+func (h *trackingHandler) Handle(ctx context.Context, record slog.Record) error {
+	out := sampledOutOf(ctx)
+
+	level := toLogrusLevel(record.Level)
+	reported := out&sampledOutTracking == 0 && slices.Contains(h.hook.Levels(), level)
+	recorded := out&sampledOutLogs == 0 && slices.Contains(h.breadcrumbs.Levels(), level)
+	if reported || recorded {
+		fields := maps.Clone(h.fields)
+		record.Attrs(func(a slog.Attr) bool {
+			addAttr(fields, h.group, a)
+			return true
+		})
+
+		// The hooks only need the level, the message, the data and the
+		// context.
+		entry := &logrus.Entry{
+			Level:   level,
+			Message: record.Message,
+			Data:    logrus.Fields(fields),
+			Time:    record.Time,
+			Context: ctx,
+		}
+
+		if reported {
+			_ = h.hook.Fire(entry)
+		} else {
+			_ = h.breadcrumbs.Fire(entry)
+		}
+	}
+
+	if out&sampledOutLogs != 0 || !h.next.Enabled(ctx, record.Level) {
+		return nil
+	}
+
+	return h.next.Handle(ctx, record)
+}
+
+func (h *trackingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := maps.Clone(h.fields)
+	for _, a := range attrs {
+		addAttr(fields, h.group, a)
+	}
+
+	return &trackingHandler{next: h.next.WithAttrs(attrs), hook: h.hook, breadcrumbs: h.breadcrumbs, fields: fields, group: h.group}
+}
+
+func (h *trackingHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return &trackingHandler{next: h.next.WithGroup(name), hook: h.hook, breadcrumbs: h.breadcrumbs, fields: h.fields, group: h.group + name + "."}
+}
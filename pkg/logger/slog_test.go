@@ -0,0 +1,189 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scribd/go-sdk/pkg/tracking"
+	"github.com/scribd/go-sdk/pkg/tracking/trackingtest"
+)
+
+func slogConfigForTest(withJSONFormat bool, level string) *Config {
+	return &Config{
+		Backend:           BackendSlog,
+		ConsoleEnabled:    true,
+		ConsoleJSONFormat: withJSONFormat,
+		ConsoleLevel:      level,
+	}
+}
+
+func TestSlogBackendJSONFields(t *testing.T) {
+	var buffer bytes.Buffer
+	l, err := NewBuilder(slogConfigForTest(withJSON, "trace")).
+		SetFields(Fields{"role": "test"}).
+		BuildTestLogger(&buffer)
+	require.NoError(t, err)
+
+	l.WithFields(Fields{"id": 42}).WithError(errors.New("failed")).Warnf("test %s", "message")
+
+	var fields Fields
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &fields))
+
+	assert.Nil(t, fields["msg"])
+	assert.Nil(t, fields["time"])
+	assert.NotEmpty(t, fields[fieldKeyTime])
+	assert.Equal(t, "test message", fields[fieldKeyMsg])
+	assert.Equal(t, "warning", fields["level"])
+	assert.Equal(t, "test", fields["role"])
+	assert.Equal(t, float64(42), fields["id"])
+	assert.Equal(t, "failed", fields["error"])
+}
+
+func TestSlogBackendTextFields(t *testing.T) {
+	logAndAssertTextFields(
+		t,
+		slogConfigForTest(withoutJSON, "trace"),
+		func(log Logger) {
+			log.Tracef("test_message")
+		},
+		func(fields map[string]string) {
+			assert.Empty(t, fields["msg"])
+			assert.Equal(t, "trace", fields["level"])
+			assert.NotEmpty(t, fields[fieldKeyTime])
+			assert.Equal(t, "test_message", fields[fieldKeyMsg])
+		},
+	)
+}
+
+func TestSlogBackendLevel(t *testing.T) {
+	testCases := []struct {
+		name                string
+		level               string
+		log                 func(log Logger)
+		withExpectedContent bool
+	}{
+		{
+			name:                "WhenConfigLevelIsTraceDebugIsLogged",
+			level:               "trace",
+			log:                 func(l Logger) { l.Debugf("test message") },
+			withExpectedContent: true,
+		},
+		{
+			name:                "WhenConfigLevelIsDebugTraceIsNotLogged",
+			level:               "debug",
+			log:                 func(l Logger) { l.Tracef("test message") },
+			withExpectedContent: false,
+		},
+		{
+			name:                "WhenConfigLevelIsWarnInfoIsNotLogged",
+			level:               "warn",
+			log:                 func(l Logger) { l.Infof("test message") },
+			withExpectedContent: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logAndAssertContent(t, slogConfigForTest(withJSON, tc.level), tc.log, tc.withExpectedContent)
+		})
+	}
+}
+
+func TestSlogBackendPanic(t *testing.T) {
+	var buffer bytes.Buffer
+	l, err := NewBuilder(slogConfigForTest(withJSON, "info")).BuildTestLogger(&buffer)
+	require.NoError(t, err)
+
+	assert.PanicsWithValue(t, "test message", func() { l.Panicf("test %s", "message") })
+	assert.Contains(t, buffer.String(), `"level":"panic"`)
+}
+
+func TestSlogBackendBuild(t *testing.T) {
+	config := slogConfigForTest(withJSON, "info")
+
+	l, err := NewBuilder(config).
+		SetTracking(&tracking.Config{SentryDSN: "https://key@sentry.io/project"}).
+		Build()
+	require.NoError(t, err)
+
+	_, ok := l.(*slogLogger)
+	assert.True(t, ok)
+
+	config.ConsoleLevel = "verbose"
+	_, err = NewBuilder(config).Build()
+	assert.Error(t, err)
+}
+
+func TestTrackingHandler(t *testing.T) {
+	var buffer bytes.Buffer
+	config := slogConfigForTest(withoutJSON, "info")
+	levels, err := NewLevelController(config)
+	require.NoError(t, err)
+
+	recorder := trackingtest.New()
+	hook, err := tracking.NewHook(recorder, &tracking.Config{})
+	require.NoError(t, err)
+
+	handler := newTrackingHandler(newSlogHandler(newTestSinks(config, &buffer)), hook)
+
+	l := newSlogLogger(handler, Fields{"role": "test"}, levels, "", nil)
+	l.WithError(errors.New("failed")).Errorf("test_message")
+
+	assert.True(t, strings.Contains(buffer.String(), "message=test_message"))
+	assert.True(t, strings.Contains(buffer.String(), "role=test"))
+	recorder.AssertCaptured(t, "^test_message$", nil)
+}
+
+func TestTrackingBreadcrumbs(t *testing.T) {
+	for _, backend := range []string{BackendLogrus, BackendSlog} {
+		t.Run(backend, func(t *testing.T) {
+			var events []*sentry.Event
+			trackingConfig := &tracking.Config{
+				BeforeSend: func(event *sentry.Event, _ *sentry.EventHint) *sentry.Event {
+					events = append(events, event)
+					return nil
+				},
+			}
+
+			var buffer bytes.Buffer
+			config := logConfigForTest(withoutJSON)
+			config.Backend = backend
+			config.ConsoleLevel = "debug"
+
+			hook, err := tracking.NewSentryHook(trackingConfig)
+			require.NoError(t, err)
+
+			var l Logger
+			if backend == BackendSlog {
+				levels, err := NewLevelController(config)
+				require.NoError(t, err)
+
+				l = newSlogLogger(newTrackingHandler(newSlogHandler(newTestSinks(config, &buffer)), hook), nil, levels, "", nil)
+			} else {
+				l, err = NewBuilder(config).BuildTestLogger(&buffer)
+				require.NoError(t, err)
+				l.(*logrusLogEntry).setTracking(hook)
+			}
+
+			ctx := tracking.WithRequestHub(t.Context(), "/users/{id}")
+			rl := WithContext(l, ctx)
+
+			rl.WithFields(Fields{"user_id": 42}).Infof("loading user")
+			rl.Debugf("not recorded")
+			rl.WithError(errors.New("not found")).Errorf("loading failed")
+
+			require.Len(t, events, 1)
+			assert.Equal(t, "/users/{id}", events[0].Tags["route"])
+			require.Len(t, events[0].Breadcrumbs, 1)
+			assert.Equal(t, "loading user", events[0].Breadcrumbs[0].Message)
+			assert.EqualValues(t, 42, events[0].Breadcrumbs[0].Data["user_id"])
+		})
+	}
+}
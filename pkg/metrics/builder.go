@@ -1,9 +1,16 @@
 package metrics
 
 import (
+	"context"
 	"fmt"
+	"maps"
+	"slices"
 
 	datadogstatsd "github.com/DataDog/datadog-go/statsd"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
 )
 
 const (
@@ -22,29 +29,123 @@ func NewBuilder(config *Config) *Builder {
 	}
 }
 
-// Build applies the given configuration and returns a Metrics instance.
+// Build applies the given configuration and returns a Metrics instance of
+// the backend of the configuration.
 func (b *Builder) Build() (Metrics, error) {
+	switch b.config.Backend {
+	case "", BackendStatsd:
+		return b.buildStatsd()
+	case BackendPrometheus:
+		return b.buildPrometheus(), nil
+	case BackendOTel:
+		return b.buildOTel()
+	case BackendNoop:
+		return Noop{}, nil
+	default:
+		return nil, fmt.Errorf("unknown metrics backend: %s", b.config.Backend)
+	}
+}
+
+// tags returns the global tags of the metrics: the service and env ones,
+// then the ones of the configuration, sorted by key.
+func (b *Builder) tags() []string {
+	tags := []string{
+		fmt.Sprintf("service:%s", b.config.service()),
+		fmt.Sprintf("env:%s", b.config.Environment),
+	}
+	for _, key := range slices.Sorted(maps.Keys(b.config.Tags)) {
+		tags = append(tags, fmt.Sprintf("%s:%s", key, b.config.Tags[key]))
+	}
+
+	return tags
+}
+
+func (b *Builder) buildStatsd() (Metrics, error) {
+	opts := []datadogstatsd.Option{
+		// Namespace to prepend to all statsd calls.
+		datadogstatsd.WithNamespace(b.config.namespace()),
+		// Tags are global tags to be added to every statsd call.
+		datadogstatsd.WithTags(b.tags()),
+	}
+
+	if b.config.BufferPoolSize > 0 {
+		opts = append(opts, datadogstatsd.WithBufferPoolSize(b.config.BufferPoolSize))
+	}
+	if b.config.BufferFlushInterval > 0 {
+		opts = append(opts, datadogstatsd.WithBufferFlushInterval(b.config.BufferFlushInterval))
+	}
+	if b.config.MaxMessagesPerPayload > 0 {
+		opts = append(opts, datadogstatsd.WithMaxMessagesPerPayload(b.config.MaxMessagesPerPayload))
+	}
+	if b.config.SenderQueueSize > 0 {
+		opts = append(opts, datadogstatsd.WithSenderQueueSize(b.config.SenderQueueSize))
+	}
+
+	switch {
+	case b.config.ExtendedClientSideAggregation:
+		opts = append(opts, datadogstatsd.WithExtendedClientSideAggregation())
+	case b.config.ClientSideAggregation:
+		opts = append(opts, datadogstatsd.WithClientSideAggregation())
+	}
+	if b.config.AggregationFlushInterval > 0 {
+		opts = append(opts, datadogstatsd.WithAggregationInterval(b.config.AggregationFlushInterval))
+	}
+
 	// New returns a pointer to a new Client given an addr in the
 	// format "hostname:port" or "unix:///path/to/socket".
 	//
 	// If the addr parameter is empty, the client uses the
 	// DD_AGENT_HOST and (optionally) the DD_DOGSTATSD_PORT
 	// environment variables to build a target address.
-	dogstatsd, err := datadogstatsd.New("")
+	dogstatsd, err := datadogstatsd.New(b.config.Address, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	// Namespace to prepend to all statsd calls.
-	dogstatsd.Namespace = b.config.App + "."
+	if len(b.config.SampleRates) > 0 {
+		return newSampledMetrics(dogstatsd, b.config.sampleRates()), nil
+	}
 
-	serviceName := fmt.Sprintf("%s-%s", b.config.App, datadogServiceSuffix)
+	return dogstatsd, nil
+}
 
-	// Tags are global tags to be added to every statsd call.
-	dogstatsd.Tags = []string{
-		fmt.Sprintf("service:%s", serviceName),
-		fmt.Sprintf("env:%s", b.config.Environment),
+func (b *Builder) buildPrometheus() Metrics {
+	// The same labels as the global tags of the statsd client.
+	labels := map[string]string{
+		"service": b.config.service(),
+		"env":     b.config.Environment,
 	}
+	maps.Copy(labels, b.config.Tags)
 
-	return dogstatsd, nil
+	return NewPrometheus(b.config.namespace(), labels)
+}
+
+func (b *Builder) buildOTel() (Metrics, error) {
+	// The exporter is configured with the OTEL_EXPORTER_OTLP_* environment
+	// variables, such as OTEL_EXPORTER_OTLP_ENDPOINT.
+	exporter, err := otlpmetrichttp.New(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("creating otlp metrics exporter. err: %w", err)
+	}
+
+	// The same attributes as the global tags of the statsd client.
+	attributes := []attribute.KeyValue{
+		attribute.String("service.name", b.config.service()),
+		attribute.String("deployment.environment", b.config.Environment),
+	}
+	for _, key := range slices.Sorted(maps.Keys(b.config.Tags)) {
+		attributes = append(attributes, attribute.String(key, b.config.Tags[key]))
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attributes...))
+	if err != nil {
+		return nil, fmt.Errorf("creating otel resource. err: %w", err)
+	}
+
+	provider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)),
+		sdkmetric.WithResource(res),
+	)
+
+	return NewOTel(provider, b.config.namespace()), nil
 }
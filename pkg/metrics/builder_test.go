@@ -0,0 +1,133 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	datadogstatsd "github.com/DataDog/datadog-go/statsd"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuild(t *testing.T) {
+	testCases := []struct {
+		name    string
+		backend string
+		want    any
+		wantErr bool
+	}{
+		{
+			name:    "WithAPrometheusBackendItBuildsAPrometheusClient",
+			backend: BackendPrometheus,
+			want:    &Prometheus{},
+		},
+		{
+			name:    "WithAnOTelBackendItBuildsAnOTelClient",
+			backend: BackendOTel,
+			want:    &OTel{},
+		},
+		{
+			name:    "WithANoopBackendItBuildsANoopClient",
+			backend: BackendNoop,
+			want:    Noop{},
+		},
+		{
+			name:    "WithAnUnknownBackendItFails",
+			backend: "graphite",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &Config{Environment: "test", App: "orders", Backend: tc.backend}
+
+			m, err := NewBuilder(config).Build()
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.IsType(t, tc.want, m)
+		})
+	}
+}
+
+func TestBuildPrometheusLabels(t *testing.T) {
+	config := &Config{
+		Environment: "test",
+		App:         "orders",
+		Backend:     BackendPrometheus,
+		Tags:        map[string]string{"region": "eu-west-1"},
+	}
+
+	m, err := NewBuilder(config).Build()
+	require.NoError(t, err)
+
+	p, ok := m.(*Prometheus)
+	require.True(t, ok)
+	assert.Equal(t, "orders", p.namespace)
+	assert.Equal(t, "orders-app", p.constLabels["service"])
+	assert.Equal(t, "test", p.constLabels["env"])
+	assert.Equal(t, "eu-west-1", p.constLabels["region"])
+}
+
+func TestBuildStatsd(t *testing.T) {
+	testCases := []struct {
+		name      string
+		config    *Config
+		wantType  any
+		wantNS    string
+		wantTags  []string
+		wantRates map[string]float64
+	}{
+		{
+			name:     "WithTheDefaults",
+			config:   &Config{Environment: "test", App: "orders", Address: "127.0.0.1:8125"},
+			wantType: &datadogstatsd.Client{},
+			wantNS:   "orders.",
+			wantTags: []string{"service:orders-app", "env:test"},
+		},
+		{
+			name: "WithTheConfiguration",
+			config: &Config{
+				Environment:           "test",
+				App:                   "orders",
+				Address:               "127.0.0.1:8125",
+				Namespace:             "shop",
+				Service:               "orders-api",
+				Tags:                  map[string]string{"version": "1.2.3", "region": "eu-west-1"},
+				BufferPoolSize:        64,
+				BufferFlushInterval:   50 * time.Millisecond,
+				ClientSideAggregation: true,
+				SampleRates:           []SampleRate{{Metric: "orders.created", Rate: 0.5}},
+			},
+			wantType:  &sampledMetrics{},
+			wantNS:    "shop.",
+			wantTags:  []string{"service:orders-api", "env:test", "region:eu-west-1", "version:1.2.3"},
+			wantRates: map[string]float64{"orders.created": 0.5},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := NewBuilder(tc.config).Build()
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = m.Close() })
+
+			require.IsType(t, tc.wantType, m)
+
+			if sampled, ok := m.(*sampledMetrics); ok {
+				assert.Equal(t, tc.wantRates, sampled.rates)
+				m = sampled.Metrics
+			}
+
+			client, ok := m.(*datadogstatsd.Client)
+			require.True(t, ok)
+			assert.Equal(t, tc.wantNS, client.Namespace)
+			assert.Equal(t, tc.wantTags, client.Tags)
+		})
+	}
+}
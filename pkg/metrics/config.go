@@ -1,16 +1,168 @@
 package metrics
 
+import (
+	"fmt"
+	"os"
+	"time"
+
+	cbuilder "github.com/scribd/go-sdk/internal/pkg/configuration/builder"
+	"github.com/scribd/go-sdk/internal/pkg/configuration/validation"
+)
+
+const (
+	// BackendStatsd sends the metrics to a DogStatsD agent.
+	BackendStatsd = "statsd"
+	// BackendPrometheus aggregates the metrics in process, to be scraped by
+	// Prometheus.
+	BackendPrometheus = "prometheus"
+	// BackendOTel exports the metrics to an OpenTelemetry collector, with
+	// OTLP/HTTP.
+	BackendOTel = "otel"
+	// BackendNoop drops the metrics, see Noop.
+	BackendNoop = "noop"
+)
+
+// Config is the configuration of the metrics client, loaded from the
+// metrics configuration file.
 type Config struct {
-	Environment string
-	App         string
+	// Enabled sends the metrics, true by default. When it's false, LoadConfig
+	// selects BackendNoop.
+	Enabled bool `mapstructure:"enabled"`
+	// Environment is the environment of the application, the ENV of the
+	// configuration.
+	Environment string `mapstructure:"-"`
+	// App is the name of the application, APP_SETTINGS_NAME by default.
+	App string `mapstructure:"app"`
+	// Backend is the backend of the metrics, BackendStatsd by default.
+	Backend string `mapstructure:"backend" validate:"omitempty,oneof=statsd prometheus otel noop"`
+
+	// Address is the address of the DogStatsD agent, "host:port" over UDP or
+	// "unix:///path/to/socket" over UDS. Without it, the agent is found with
+	// the DD_AGENT_HOST and DD_DOGSTATSD_PORT environment variables.
+	Address string `mapstructure:"address"`
+	// Namespace prefixes the names of the metrics, App by default.
+	Namespace string `mapstructure:"namespace"`
+	// Service is the service tag of the metrics, App with the "-app"
+	// suffix by default.
+	Service string `mapstructure:"service"`
+	// Tags are the tags added to every metric, after the service and env
+	// ones, such as the version, the region or the pod of the application.
+	Tags map[string]string `mapstructure:"tags"`
+
+	// BufferPoolSize is the number of buffers of the statsd client, which
+	// depends on the transport by default.
+	BufferPoolSize int `mapstructure:"buffer_pool_size" validate:"gte=0"`
+	// BufferFlushInterval is the interval at which the statsd client sends
+	// its buffers, 100ms by default.
+	BufferFlushInterval time.Duration `mapstructure:"buffer_flush_interval" validate:"gte=0"`
+	// MaxMessagesPerPayload is the maximum number of metrics sent in a single
+	// payload by the statsd client, unbounded by default.
+	MaxMessagesPerPayload int `mapstructure:"max_messages_per_payload" validate:"gte=0"`
+	// SenderQueueSize is the number of payloads queued by the statsd client
+	// before they are sent, which depends on the transport by default.
+	SenderQueueSize int `mapstructure:"sender_queue_size" validate:"gte=0"`
+
+	// ClientSideAggregation aggregates the counts, gauges and sets in the
+	// statsd client before sending them.
+	ClientSideAggregation bool `mapstructure:"client_side_aggregation"`
+	// ExtendedClientSideAggregation aggregates the histograms, distributions
+	// and timings too. It implies ClientSideAggregation.
+	ExtendedClientSideAggregation bool `mapstructure:"extended_client_side_aggregation"`
+	// AggregationFlushInterval is the interval at which the aggregated
+	// metrics are sent, 2s by default.
+	AggregationFlushInterval time.Duration `mapstructure:"aggregation_flush_interval" validate:"gte=0"`
+
+	// SampleRates are the sample rates of the metrics sent to the statsd
+	// agent, replacing the ones given when they're sent.
+	SampleRates []SampleRate `mapstructure:"sample_rates"`
 }
 
-// NewConfig returns a new Config instance.
+// SampleRate is the sample rate of a metric.
+type SampleRate struct {
+	// Metric is the name of the metric, without the namespace.
+	Metric string `mapstructure:"metric" validate:"required"`
+	// Rate is the rate of the values sent, between 0 and 1.
+	Rate float64 `mapstructure:"rate" validate:"gt=0,lte=1"`
+}
+
+func init() {
+	cbuilder.Register("metrics", func(loader cbuilder.Loader) (any, error) {
+		return loadConfig(loader)
+	})
+}
+
+// NewConfig returns a new Config instance for the environment and the
+// application, the other settings having their default values.
+//
+// Deprecated: Use LoadConfig, which reads the metrics configuration file.
 func NewConfig(environment string, app string) (*Config, error) {
 	config := &Config{
+		Enabled:     true,
 		Environment: environment,
 		App:         app,
 	}
 
 	return config, nil
 }
+
+// LoadConfig returns a new Config instance read from the metrics
+// configuration file.
+func LoadConfig() (*Config, error) {
+	return loadConfig(cbuilder.Loader{})
+}
+
+func loadConfig(loader cbuilder.Loader) (*Config, error) {
+	config := &Config{}
+	viperBuilder := loader.New("metrics")
+
+	viperBuilder.SetDefault("enabled", true)
+	viperBuilder.SetDefault("app", os.Getenv("APP_SETTINGS_NAME"))
+
+	vConf, err := viperBuilder.Build()
+	if err != nil {
+		return config, err
+	}
+
+	if err = vConf.Unmarshal(config); err != nil {
+		return config, fmt.Errorf("unable to decode into struct: %s", err.Error())
+	}
+
+	if err = validation.Validate("metrics", config); err != nil {
+		return config, err
+	}
+
+	config.Environment = vConf.GetString("ENV")
+	if !config.Enabled {
+		config.Backend = BackendNoop
+	}
+
+	return config, nil
+}
+
+// namespace returns the namespace of the metrics.
+func (c *Config) namespace() string {
+	if c.Namespace != "" {
+		return c.Namespace
+	}
+
+	return c.App
+}
+
+// service returns the service tag of the metrics.
+func (c *Config) service() string {
+	if c.Service != "" {
+		return c.Service
+	}
+
+	return fmt.Sprintf("%s-%s", c.App, datadogServiceSuffix)
+}
+
+// sampleRates returns the sample rates by metric name.
+func (c *Config) sampleRates() map[string]float64 {
+	rates := make(map[string]float64, len(c.SampleRates))
+	for _, sr := range c.SampleRates {
+		rates[sr.Metric] = sr.Rate
+	}
+
+	return rates
+}
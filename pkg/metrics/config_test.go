@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfig(t *testing.T) {
+	c, err := NewConfig("test", "go-sdk")
+	require.NoError(t, err)
+
+	assert.Equal(t, &Config{Enabled: true, Environment: "test", App: "go-sdk"}, c)
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("WithoutConfigFileFails", func(t *testing.T) {
+		t.Setenv("APP_ROOT", t.TempDir())
+
+		_, err := LoadConfig()
+		assert.Error(t, err)
+	})
+
+	t.Run("WithConfigFile", func(t *testing.T) {
+		t.Setenv("APP_ROOT", "testdata")
+		t.Setenv("APP_METRICS_TAGS_REGION", "us-west-2")
+
+		c, err := LoadConfig()
+		require.NoError(t, err)
+
+		assert.Equal(t, &Config{
+			Enabled:     true,
+			Environment: "test",
+			App:         "orders",
+			Address:     "127.0.0.1:8125",
+			Namespace:   "shop",
+			Tags: map[string]string{
+				"version": "1.2.3",
+				"region":  "us-west-2",
+			},
+			BufferPoolSize:           64,
+			BufferFlushInterval:      50 * time.Millisecond,
+			ClientSideAggregation:    true,
+			AggregationFlushInterval: 5 * time.Second,
+			SampleRates:              []SampleRate{{Metric: "orders.created", Rate: 0.5}},
+		}, c)
+	})
+}
+
+// writeConfig writes the metrics configuration file of a new APP_ROOT.
+func writeConfig(t *testing.T, content string) {
+	t.Helper()
+
+	appRoot := t.TempDir()
+	t.Setenv("APP_ROOT", appRoot)
+	require.NoError(t, os.Mkdir(filepath.Join(appRoot, "config"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(appRoot, "config", "metrics.yml"), []byte(content), 0o600))
+}
+
+func TestLoadConfigDefaults(t *testing.T) {
+	writeConfig(t, "test:\n  address: \"unix:///var/run/datadog/dsd.socket\"\n")
+	t.Setenv("APP_SETTINGS_NAME", "payments")
+
+	c, err := LoadConfig()
+	require.NoError(t, err)
+
+	assert.True(t, c.Enabled)
+	assert.Equal(t, "payments", c.App)
+	assert.Equal(t, "", c.Backend)
+	assert.Equal(t, "payments", c.namespace())
+	assert.Equal(t, "payments-app", c.service())
+}
+
+func TestLoadConfigDisabled(t *testing.T) {
+	writeConfig(t, "test:\n  enabled: false\n  backend: prometheus\n")
+
+	c, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, BackendNoop, c.Backend)
+
+	m, err := NewBuilder(c).Build()
+	require.NoError(t, err)
+	assert.Equal(t, Noop{}, m)
+}
+
+func TestLoadConfigValidation(t *testing.T) {
+	writeConfig(t, "test:\n"+
+		"  backend: graphite\n"+
+		"  buffer_pool_size: -1\n"+
+		"  sample_rates:\n"+
+		"    - metric: orders.created\n"+
+		"      rate: 2\n"+
+		"    - rate: 0.5\n")
+
+	_, err := LoadConfig()
+	require.Error(t, err)
+
+	assert.Equal(t,
+		"metrics.backend (APP_METRICS_BACKEND): must be one of statsd, prometheus, otel, noop, got \"graphite\"\n"+
+			"metrics.buffer_pool_size (APP_METRICS_BUFFER_POOL_SIZE): must be >= 0\n"+
+			"metrics.sample_rates[0].rate: must be <= 1\n"+
+			"metrics.sample_rates[1].metric: is required",
+		err.Error())
+}
@@ -1,6 +1,7 @@
 package kafka
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"sort"
@@ -8,7 +9,13 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/twmb/franz-go/pkg/kgo"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/scribd/go-sdk/pkg/metrics"
 )
 
 type (
@@ -569,3 +576,38 @@ func getExpectedSampleRate(sampleRates map[string]float64, rate float64, metric
 
 	return defaultSampleRate
 }
+
+func TestBrokerMetricsWithOTel(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	m := metrics.NewOTel(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)), "kafka")
+
+	bm := NewBrokerMetrics(m)
+	bm.OnBrokerRead(kgo.BrokerMetadata{NodeID: 1}, 0, 128, 2*time.Millisecond, 5*time.Millisecond, nil)
+	bm.OnBrokerRead(kgo.BrokerMetadata{NodeID: 1}, 0, 0, 0, 0, fmt.Errorf("read failed"))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	node := attribute.NewSet(attribute.String("node", "1"))
+	got := map[string]any{}
+	for _, metric := range rm.ScopeMetrics[0].Metrics {
+		switch data := metric.Data.(type) {
+		case metricdata.Sum[int64]:
+			require.Len(t, data.DataPoints, 1)
+			assert.Equal(t, node, data.DataPoints[0].Attributes)
+			got[metric.Name] = data.DataPoints[0].Value
+		case metricdata.Histogram[float64]:
+			require.Len(t, data.DataPoints, 1)
+			assert.Equal(t, node, data.DataPoints[0].Attributes)
+			got[metric.Name] = data.DataPoints[0].Sum
+		}
+	}
+
+	assert.Equal(t, map[string]any{
+		"kafka_client.broker.read_bytes_total":  int64(128),
+		"kafka_client.broker.read_errors_total": int64(1),
+		"kafka_client.broker.read_wait_latency": 0.002,
+		"kafka_client.broker.read_latency":      0.005,
+	}, got)
+}
@@ -1,7 +1,10 @@
 /*
-Package metrics provides a configured Datadog Statsd client.
+Package metrics provides a configured Datadog Statsd client, a Prometheus
+client exposing the metrics to be scraped, or an OpenTelemetry client
+exporting them to a collector.
 
 `datadog-go` is the official library that provides a [DogStatsD](ddsd) client.
+`client_golang` is the official Prometheus [instrumentation library](prom).
 
 The following documentation is available:
 
@@ -10,6 +13,7 @@ The following documentation is available:
 
 [ddgo]: <http://godoc.org/github.com/DataDog/datadog-go/statsd>
 [ddsd]: <https://docs.datadoghq.com/developers/dogstatsd/?tab=go>
+[prom]: <https://pkg.go.dev/github.com/prometheus/client_golang/prometheus>
 */
 package metrics
 
@@ -0,0 +1,370 @@
+/*
+Package metricstest provides a Recorder recording the metrics sent, to assert
+on them in the tests instead of sending them to a statsd agent or mocking the
+metrics.Metrics interface.
+
+	r := metricstest.New()
+	ctx := sdkmetricscontext.ToContext(context.Background(), r)
+
+	handler(ctx)
+
+	r.AssertSum(t, "orders.created", []string{"country:fr"}, 1)
+
+The Recorder can be given to anything taking a metrics.Metrics, such as the
+Kafka metrics hooks. It's safe for concurrent use.
+*/
+package metricstest
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scribd/go-sdk/pkg/metrics"
+)
+
+// Kind is the kind of a metric sample.
+type Kind string
+
+const (
+	// Count is the kind of the samples sent with Count, Incr and Decr.
+	Count Kind = "count"
+	// Gauge is the kind of the samples sent with Gauge.
+	Gauge Kind = "gauge"
+	// Histogram is the kind of the samples sent with Histogram.
+	Histogram Kind = "histogram"
+	// Distribution is the kind of the samples sent with Distribution.
+	Distribution Kind = "distribution"
+	// Set is the kind of the samples sent with Set.
+	Set Kind = "set"
+	// Timing is the kind of the samples sent with Timing and
+	// TimeInMilliseconds, whose value is in milliseconds.
+	Timing Kind = "timing"
+	// Event is the kind of the events sent with SimpleEvent.
+	Event Kind = "event"
+)
+
+// Sample is a metric sample recorded by a Recorder.
+type Sample struct {
+	Time time.Time
+	Kind Kind
+	// Name is the name of the metric, or the title of the event.
+	Name string
+	// Value is the value of the sample: the value added for the counts,
+	// and the duration in milliseconds for the timings.
+	Value float64
+	// Text is the value of the sets, and the text of the events.
+	Text string
+	Tags []string
+	Rate float64
+}
+
+// Recorder is a metrics.Metrics recording the samples sent.
+type Recorder struct {
+	mu      sync.Mutex
+	samples []Sample
+	flushes int
+	closed  bool
+}
+
+var _ metrics.Metrics = (*Recorder)(nil)
+
+// New returns a Recorder.
+func New() *Recorder {
+	return &Recorder{}
+}
+
+// Gauge records the value of the gauge.
+func (r *Recorder) Gauge(name string, value float64, tags []string, rate float64) error {
+	r.record(Gauge, name, value, "", tags, rate)
+	return nil
+}
+
+// Count records the value added to the counter.
+func (r *Recorder) Count(name string, value int64, tags []string, rate float64) error {
+	r.record(Count, name, float64(value), "", tags, rate)
+	return nil
+}
+
+// Histogram records the value of the histogram.
+func (r *Recorder) Histogram(name string, value float64, tags []string, rate float64) error {
+	r.record(Histogram, name, value, "", tags, rate)
+	return nil
+}
+
+// Distribution records the value of the distribution.
+func (r *Recorder) Distribution(name string, value float64, tags []string, rate float64) error {
+	r.record(Distribution, name, value, "", tags, rate)
+	return nil
+}
+
+// Decr records -1 added to the counter.
+func (r *Recorder) Decr(name string, tags []string, rate float64) error {
+	return r.Count(name, -1, tags, rate)
+}
+
+// Incr records 1 added to the counter.
+func (r *Recorder) Incr(name string, tags []string, rate float64) error {
+	return r.Count(name, 1, tags, rate)
+}
+
+// Set records the value of the set.
+func (r *Recorder) Set(name string, value string, tags []string, rate float64) error {
+	r.record(Set, name, 0, value, tags, rate)
+	return nil
+}
+
+// Timing records the duration, in milliseconds.
+func (r *Recorder) Timing(name string, value time.Duration, tags []string, rate float64) error {
+	return r.TimeInMilliseconds(name, float64(value)/float64(time.Millisecond), tags, rate)
+}
+
+// TimeInMilliseconds records the duration, in milliseconds.
+func (r *Recorder) TimeInMilliseconds(name string, value float64, tags []string, rate float64) error {
+	r.record(Timing, name, value, "", tags, rate)
+	return nil
+}
+
+// SimpleEvent records the event.
+func (r *Recorder) SimpleEvent(title, text string) error {
+	r.record(Event, title, 0, text, nil, 1)
+	return nil
+}
+
+// Close marks the Recorder as closed, see Closed. The samples sent after
+// are still recorded.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+
+	return nil
+}
+
+// Flush counts the flushes, see Flushes.
+func (r *Recorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.flushes++
+
+	return nil
+}
+
+// SetWriteTimeout does nothing, the samples being recorded in memory.
+func (r *Recorder) SetWriteTimeout(time.Duration) error {
+	return nil
+}
+
+func (r *Recorder) record(kind Kind, name string, value float64, text string, tags []string, rate float64) {
+	sample := Sample{
+		Time:  time.Now(),
+		Kind:  kind,
+		Name:  name,
+		Value: value,
+		Text:  text,
+		Tags:  slices.Clone(tags),
+		Rate:  rate,
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.samples = append(r.samples, sample)
+}
+
+// Samples returns the samples recorded, in the order they were sent.
+func (r *Recorder) Samples() []Sample {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.samples)
+}
+
+// Flushes returns the number of calls to Flush.
+func (r *Recorder) Flushes() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.flushes
+}
+
+// Closed reports whether Close was called.
+func (r *Recorder) Closed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.closed
+}
+
+// Reset forgets the samples recorded, the flushes and the closing.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.samples = nil
+	r.flushes = 0
+	r.closed = false
+}
+
+// FilterName returns the samples of the metric named after name.
+func (r *Recorder) FilterName(name string) []Sample {
+	return r.filter(func(s Sample) bool { return s.Name == name })
+}
+
+// FilterTag returns the samples tagged with the tag, such as "country:fr".
+func (r *Recorder) FilterTag(tag string) []Sample {
+	return r.filter(func(s Sample) bool { return slices.Contains(s.Tags, tag) })
+}
+
+// FilterKind returns the samples of the kind.
+func (r *Recorder) FilterKind(kind Kind) []Sample {
+	return r.filter(func(s Sample) bool { return s.Kind == kind })
+}
+
+// Find returns the samples of the metric named after name whose tags include
+// the tags given, in any order.
+func (r *Recorder) Find(name string, tags ...string) []Sample {
+	return r.filter(func(s Sample) bool { return s.Name == name && includes(s.Tags, tags) })
+}
+
+// Sum returns the sum of the counts of the metric with the tags, as Find
+// finds them.
+func (r *Recorder) Sum(name string, tags ...string) int64 {
+	var sum int64
+	for _, s := range r.Find(name, tags...) {
+		if s.Kind == Count {
+			sum += int64(s.Value)
+		}
+	}
+
+	return sum
+}
+
+// LastGauge returns the last value of the gauge with the tags, as Find finds
+// them, and whether it was sent.
+func (r *Recorder) LastGauge(name string, tags ...string) (float64, bool) {
+	samples := r.Find(name, tags...)
+	for i := len(samples) - 1; i >= 0; i-- {
+		if samples[i].Kind == Gauge {
+			return samples[i].Value, true
+		}
+	}
+
+	return 0, false
+}
+
+// Values returns the values of the metric with the tags, as Find finds them,
+// in the order they were sent.
+func (r *Recorder) Values(name string, tags ...string) []float64 {
+	samples := r.Find(name, tags...)
+
+	values := make([]float64, 0, len(samples))
+	for _, s := range samples {
+		values = append(values, s.Value)
+	}
+
+	return values
+}
+
+func (r *Recorder) filter(match func(Sample) bool) []Sample {
+	var found []Sample
+	for _, s := range r.Samples() {
+		if match(s) {
+			found = append(found, s)
+		}
+	}
+
+	return found
+}
+
+// AssertEmitted asserts that the metric was sent with the tags given, as
+// Find finds it.
+func (r *Recorder) AssertEmitted(t assert.TestingT, name string, tags []string, msgAndArgs ...any) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	if len(r.Find(name, tags...)) > 0 {
+		return true
+	}
+
+	return assert.Fail(t, fmt.Sprintf("No metric %s sent with the tags %v, the metrics being:\n%s",
+		name, tags, r.dump()), msgAndArgs...)
+}
+
+// AssertNotEmitted asserts that the metric was not sent with the tags given.
+func (r *Recorder) AssertNotEmitted(t assert.TestingT, name string, tags []string, msgAndArgs ...any) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	found := r.Find(name, tags...)
+	if len(found) == 0 {
+		return true
+	}
+
+	return assert.Fail(t, fmt.Sprintf("Unexpected metric %s sent with the tags %v: %v",
+		name, tags, found[0].Tags), msgAndArgs...)
+}
+
+// AssertSum asserts that the sum of the counts of the metric with the tags
+// given, as Sum sums them, is want.
+func (r *Recorder) AssertSum(t assert.TestingT, name string, tags []string, want int64, msgAndArgs ...any) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	if got := r.Sum(name, tags...); got != want {
+		return assert.Fail(t, fmt.Sprintf("Sum of the counts of %s with the tags %v is %d, not %d, the metrics being:\n%s",
+			name, tags, got, want, r.dump()), msgAndArgs...)
+	}
+
+	return true
+}
+
+// AssertGauge asserts that the last value of the gauge with the tags given,
+// as LastGauge returns it, is want.
+func (r *Recorder) AssertGauge(t assert.TestingT, name string, tags []string, want float64, msgAndArgs ...any) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	got, ok := r.LastGauge(name, tags...)
+	if !ok {
+		return assert.Fail(t, fmt.Sprintf("No gauge %s sent with the tags %v, the metrics being:\n%s",
+			name, tags, r.dump()), msgAndArgs...)
+	}
+	if got != want {
+		return assert.Fail(t, fmt.Sprintf("Last value of the gauge %s with the tags %v is %v, not %v",
+			name, tags, got, want), msgAndArgs...)
+	}
+
+	return true
+}
+
+// dump returns the samples, one per line, for the failure messages.
+func (r *Recorder) dump() string {
+	var b strings.Builder
+	for _, s := range r.Samples() {
+		fmt.Fprintf(&b, "\t%s %s %v %v\n", s.Kind, s.Name, s.Value, s.Tags)
+	}
+
+	return b.String()
+}
+
+// includes reports whether the tags include the expected ones.
+func includes(tags, expected []string) bool {
+	for _, tag := range expected {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+
+	return true
+}
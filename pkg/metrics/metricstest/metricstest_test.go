@@ -0,0 +1,163 @@
+package metricstest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	sdkmetricscontext "github.com/scribd/go-sdk/pkg/context/metrics"
+	"github.com/scribd/go-sdk/pkg/metrics/kafka"
+)
+
+// recordingT records the failures of the assertions.
+type recordingT struct {
+	errors []string
+}
+
+func (t *recordingT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestRecorder(t *testing.T) {
+	r := New()
+
+	require.NoError(t, r.Incr("orders.created", []string{"country:fr", "express"}, 1))
+	require.NoError(t, r.Count("orders.created", 2, []string{"country:fr"}, 0.5))
+	require.NoError(t, r.Decr("orders.created", []string{"country:us"}, 1))
+	require.NoError(t, r.Gauge("pool.size", 3, []string{"pool:db"}, 1))
+	require.NoError(t, r.Gauge("pool.size", 5, []string{"pool:db"}, 1))
+	require.NoError(t, r.Histogram("order.amount", 20, nil, 1))
+	require.NoError(t, r.Distribution("order.amount", 40, nil, 1))
+	require.NoError(t, r.Timing("query.latency", 1500*time.Microsecond, nil, 1))
+	require.NoError(t, r.TimeInMilliseconds("query.latency", 3, nil, 1))
+	require.NoError(t, r.Set("users", "42", nil, 1))
+	require.NoError(t, r.SimpleEvent("deploy", "v1"))
+	require.NoError(t, r.Flush())
+	require.NoError(t, r.Close())
+
+	assert.Len(t, r.Samples(), 11)
+	assert.Len(t, r.FilterName("orders.created"), 3)
+	assert.Len(t, r.FilterTag("country:fr"), 2)
+	assert.Len(t, r.FilterKind(Timing), 2)
+	assert.Len(t, r.Find("orders.created", "express", "country:fr"), 1)
+
+	assert.Equal(t, int64(3), r.Sum("orders.created", "country:fr"))
+	assert.Equal(t, int64(2), r.Sum("orders.created"))
+	assert.Equal(t, int64(0), r.Sum("orders.cancelled"))
+
+	value, ok := r.LastGauge("pool.size", "pool:db")
+	assert.True(t, ok)
+	assert.Equal(t, 5.0, value)
+	_, ok = r.LastGauge("pool.size", "pool:cache")
+	assert.False(t, ok)
+
+	assert.Equal(t, []float64{20, 40}, r.Values("order.amount"))
+	assert.Equal(t, []float64{1.5, 3}, r.Values("query.latency"))
+
+	set := r.FilterKind(Set)
+	require.Len(t, set, 1)
+	assert.Equal(t, "42", set[0].Text)
+
+	event := r.FilterKind(Event)
+	require.Len(t, event, 1)
+	assert.Equal(t, "deploy", event[0].Name)
+	assert.Equal(t, "v1", event[0].Text)
+
+	assert.Equal(t, 1, r.Flushes())
+	assert.True(t, r.Closed())
+
+	r.AssertEmitted(t, "orders.created", []string{"express"})
+	r.AssertNotEmitted(t, "orders.created", []string{"country:de"})
+	r.AssertSum(t, "orders.created", []string{"country:us"}, -1)
+	r.AssertGauge(t, "pool.size", nil, 5)
+
+	r.Reset()
+	assert.Empty(t, r.Samples())
+	assert.Zero(t, r.Flushes())
+	assert.False(t, r.Closed())
+}
+
+func TestRecorderCopiesTheTags(t *testing.T) {
+	r := New()
+
+	tags := []string{"country:fr"}
+	require.NoError(t, r.Incr("orders.created", tags, 1))
+	tags[0] = "country:us"
+
+	r.AssertSum(t, "orders.created", []string{"country:fr"}, 1)
+}
+
+func TestRecorderAssertionsFail(t *testing.T) {
+	r := New()
+	require.NoError(t, r.Incr("orders.created", []string{"country:fr"}, 1))
+	require.NoError(t, r.Gauge("pool.size", 3, nil, 1))
+
+	rt := &recordingT{}
+
+	assert.False(t, r.AssertEmitted(rt, "orders.cancelled", nil))
+	require.Len(t, rt.errors, 1)
+	assert.Contains(t, rt.errors[0], "No metric orders.cancelled sent with the tags []")
+	assert.Contains(t, rt.errors[0], "count orders.created 1 [country:fr]")
+
+	assert.False(t, r.AssertNotEmitted(rt, "orders.created", []string{"country:fr"}))
+	assert.False(t, r.AssertSum(rt, "orders.created", nil, 2))
+	assert.False(t, r.AssertGauge(rt, "pool.size", nil, 4))
+	assert.False(t, r.AssertGauge(rt, "pool.max", nil, 4))
+	assert.Len(t, rt.errors, 5)
+}
+
+func TestRecorderConcurrency(t *testing.T) {
+	r := New()
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for range 100 {
+				_ = r.Incr("orders.created", nil, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	r.AssertSum(t, "orders.created", nil, 1000)
+}
+
+func TestRecorderInContext(t *testing.T) {
+	r := New()
+	ctx := sdkmetricscontext.ToContext(context.Background(), r)
+
+	m, err := sdkmetricscontext.Extract(ctx)
+	require.NoError(t, err)
+	require.NoError(t, m.Incr("orders.created", []string{"country:fr"}, 1))
+
+	r.AssertSum(t, "orders.created", []string{"country:fr"}, 1)
+}
+
+func TestRecorderWithKafkaHooks(t *testing.T) {
+	r := New()
+
+	pm := kafka.NewProducerMetrics(r)
+	pm.OnProduceRecordUnbuffered(&kgo.Record{}, nil)
+	pm.OnProduceRecordUnbuffered(&kgo.Record{}, fmt.Errorf("produce failed"))
+
+	cm := kafka.NewConsumerMetrics(r, kafka.WithSampleRate(0.5))
+	cm.OnFetchBatchRead(kgo.BrokerMetadata{NodeID: 1}, "orders", 2, kgo.FetchBatchMetrics{UncompressedBytes: 128})
+
+	r.AssertSum(t, "kafka_client.producer.records_unbuffered", nil, 1)
+	r.AssertSum(t, "kafka_client.producer.records_error", nil, 1)
+	r.AssertSum(t, "kafka_client.consumer.fetch_bytes_uncompressed_total",
+		[]string{"node:1", "topic:orders", "partition:2"}, 128)
+
+	fetched := r.FilterName("kafka_client.consumer.fetch_bytes_uncompressed_total")
+	require.Len(t, fetched, 1)
+	assert.Equal(t, 0.5, fetched[0].Rate)
+}
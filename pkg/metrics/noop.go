@@ -0,0 +1,48 @@
+package metrics
+
+import "time"
+
+// Noop is a Metrics client dropping the metrics, used when the metrics are
+// disabled.
+type Noop struct{}
+
+var _ Metrics = Noop{}
+
+// Gauge does nothing.
+func (Noop) Gauge(string, float64, []string, float64) error { return nil }
+
+// Count does nothing.
+func (Noop) Count(string, int64, []string, float64) error { return nil }
+
+// Histogram does nothing.
+func (Noop) Histogram(string, float64, []string, float64) error { return nil }
+
+// Distribution does nothing.
+func (Noop) Distribution(string, float64, []string, float64) error { return nil }
+
+// Decr does nothing.
+func (Noop) Decr(string, []string, float64) error { return nil }
+
+// Incr does nothing.
+func (Noop) Incr(string, []string, float64) error { return nil }
+
+// Set does nothing.
+func (Noop) Set(string, string, []string, float64) error { return nil }
+
+// Timing does nothing.
+func (Noop) Timing(string, time.Duration, []string, float64) error { return nil }
+
+// TimeInMilliseconds does nothing.
+func (Noop) TimeInMilliseconds(string, float64, []string, float64) error { return nil }
+
+// SimpleEvent does nothing.
+func (Noop) SimpleEvent(string, string) error { return nil }
+
+// Close does nothing.
+func (Noop) Close() error { return nil }
+
+// Flush does nothing.
+func (Noop) Flush() error { return nil }
+
+// SetWriteTimeout does nothing.
+func (Noop) SetWriteTimeout(time.Duration) error { return nil }
@@ -0,0 +1,238 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+const (
+	// defaultOTelTimeout is the time given to the exporter to flush the
+	// metrics, see SetWriteTimeout.
+	defaultOTelTimeout = time.Second * 5
+
+	// secondsUnit is the unit of the histograms of the durations.
+	secondsUnit = "s"
+)
+
+// OTel is a Metrics client recording the metrics with OpenTelemetry
+// instruments, exported by the readers of its meter provider, such as the
+// OTLP/HTTP exporter of the builder.
+//
+// The metrics are mapped to instruments named after them:
+//
+//   - Count, Incr and Decr to counters, which can't be decremented,
+//   - Gauge to gauges,
+//   - Histogram and Distribution to histograms,
+//   - Timing and TimeInMilliseconds to histograms, in seconds.
+//
+// The statsd-style `key:value` tags are mapped to string attributes; a tag
+// without a value is a boolean attribute whose value is true. The sample
+// rates are ignored, every value being aggregated in process.
+type OTel struct {
+	provider *sdkmetric.MeterProvider
+	meter    otelmetric.Meter
+
+	mu          sync.Mutex
+	timeout     time.Duration
+	instruments map[string]otelInstrument
+}
+
+var _ Metrics = (*OTel)(nil)
+
+// NewOTel returns an OTel client recording the metrics with a meter, named
+// after the scope, of the provider.
+func NewOTel(provider *sdkmetric.MeterProvider, scope string) *OTel {
+	return &OTel{
+		provider:    provider,
+		meter:       provider.Meter(scope),
+		timeout:     defaultOTelTimeout,
+		instruments: map[string]otelInstrument{},
+	}
+}
+
+// Gauge records the value of the gauge.
+func (o *OTel) Gauge(name string, value float64, tags []string, _ float64) error {
+	gauge, err := instrument(o, name, gaugeKind, func() (otelmetric.Float64Gauge, error) {
+		return o.meter.Float64Gauge(name)
+	})
+	if err != nil {
+		return err
+	}
+
+	gauge.Record(context.Background(), value, tagsToAttributes(tags))
+
+	return nil
+}
+
+// Count adds the value to the counter. The value can't be negative.
+func (o *OTel) Count(name string, value int64, tags []string, _ float64) error {
+	if value < 0 {
+		return fmt.Errorf("counting metric %s: otel counters can't be decremented", name)
+	}
+
+	counter, err := instrument(o, name, counterKind, func() (otelmetric.Int64Counter, error) {
+		return o.meter.Int64Counter(name)
+	})
+	if err != nil {
+		return err
+	}
+
+	counter.Add(context.Background(), value, tagsToAttributes(tags))
+
+	return nil
+}
+
+// Histogram records the value in the histogram.
+func (o *OTel) Histogram(name string, value float64, tags []string, _ float64) error {
+	histogram, err := instrument(o, name, histogramKind, func() (otelmetric.Float64Histogram, error) {
+		return o.meter.Float64Histogram(name)
+	})
+	if err != nil {
+		return err
+	}
+
+	histogram.Record(context.Background(), value, tagsToAttributes(tags))
+
+	return nil
+}
+
+// Distribution records the value in the histogram, the distributions being
+// aggregated by each instance like the histograms.
+func (o *OTel) Distribution(name string, value float64, tags []string, rate float64) error {
+	return o.Histogram(name, value, tags, rate)
+}
+
+// Decr returns an error, the counters can't be decremented.
+func (o *OTel) Decr(name string, tags []string, rate float64) error {
+	return o.Count(name, -1, tags, rate)
+}
+
+// Incr adds 1 to the counter.
+func (o *OTel) Incr(name string, tags []string, rate float64) error {
+	return o.Count(name, 1, tags, rate)
+}
+
+// Set returns an error, the sets being not supported.
+func (o *OTel) Set(name string, _ string, _ []string, _ float64) error {
+	return fmt.Errorf("setting metric %s: otel sets are not supported", name)
+}
+
+// Timing records the duration in the histogram, in seconds.
+func (o *OTel) Timing(name string, value time.Duration, tags []string, _ float64) error {
+	return o.recordSeconds(name, value.Seconds(), tags)
+}
+
+// TimeInMilliseconds records the duration in the histogram, in seconds.
+func (o *OTel) TimeInMilliseconds(name string, value float64, tags []string, _ float64) error {
+	return o.recordSeconds(name, value/float64(time.Second/time.Millisecond), tags)
+}
+
+// SimpleEvent returns an error, the events being not supported.
+func (o *OTel) SimpleEvent(title, _ string) error {
+	return fmt.Errorf("sending event %s: otel events are not supported", title)
+}
+
+// Close flushes the metrics and shuts the meter provider down.
+func (o *OTel) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), o.writeTimeout())
+	defer cancel()
+
+	return o.provider.Shutdown(ctx)
+}
+
+// Flush exports the metrics recorded.
+func (o *OTel) Flush() error {
+	ctx, cancel := context.WithTimeout(context.Background(), o.writeTimeout())
+	defer cancel()
+
+	return o.provider.ForceFlush(ctx)
+}
+
+// SetWriteTimeout sets the time given to Flush and Close to export the
+// metrics, 5 seconds by default.
+func (o *OTel) SetWriteTimeout(d time.Duration) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.timeout = d
+
+	return nil
+}
+
+func (o *OTel) writeTimeout() time.Duration {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.timeout
+}
+
+func (o *OTel) recordSeconds(name string, seconds float64, tags []string) error {
+	histogram, err := instrument(o, name, histogramKind, func() (otelmetric.Float64Histogram, error) {
+		return o.meter.Float64Histogram(name, otelmetric.WithUnit(secondsUnit))
+	})
+	if err != nil {
+		return err
+	}
+
+	histogram.Record(context.Background(), seconds, tagsToAttributes(tags))
+
+	return nil
+}
+
+// otelInstrument is the instrument of a metric, with its kind.
+type otelInstrument struct {
+	kind       string
+	instrument any
+}
+
+// instrument returns the instrument of the metric, creating it on its first
+// value. The metric can't be recorded with instruments of other kinds.
+func instrument[T any](o *OTel, name, kind string, create func() (T, error)) (T, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var zero T
+
+	if existing, ok := o.instruments[name]; ok {
+		if existing.kind != kind {
+			return zero, fmt.Errorf("metric %s is a %s, not a %s", name, existing.kind, kind)
+		}
+
+		return existing.instrument.(T), nil
+	}
+
+	i, err := create()
+	if err != nil {
+		return zero, fmt.Errorf("creating metric %s. err: %w", name, err)
+	}
+	o.instruments[name] = otelInstrument{kind: kind, instrument: i}
+
+	return i, nil
+}
+
+// tagsToAttributes returns the attributes of the statsd-style `key:value`
+// tags.
+func tagsToAttributes(tags []string) otelmetric.MeasurementOption {
+	attributes := make([]attribute.KeyValue, 0, len(tags))
+	for _, tag := range tags {
+		if tag == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(tag, ":")
+		if !ok {
+			attributes = append(attributes, attribute.Bool(key, true))
+			continue
+		}
+		attributes = append(attributes, attribute.String(key, value))
+	}
+
+	return otelmetric.WithAttributes(attributes...)
+}
@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+)
+
+func newTestOTel() (*OTel, *sdkmetric.ManualReader) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	return NewOTel(provider, "test"), reader
+}
+
+// collect returns the metric of the reader named after name.
+func collect(t *testing.T, reader *sdkmetric.ManualReader, name string) metricdata.Metrics {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m
+			}
+		}
+	}
+
+	require.Failf(t, "metric not found", "no metric %s", name)
+	return metricdata.Metrics{}
+}
+
+func TestOTelCounters(t *testing.T) {
+	o, reader := newTestOTel()
+
+	require.NoError(t, o.Incr("orders.created", []string{"country:fr", "express"}, 1))
+	require.NoError(t, o.Count("orders.created", 2, []string{"country:fr", "express"}, 0.5))
+	require.NoError(t, o.Incr("orders.created", []string{"country:us"}, 1))
+
+	metricdatatest.AssertEqual(t, metricdata.Metrics{
+		Name: "orders.created",
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints: []metricdata.DataPoint[int64]{
+				{
+					Attributes: attribute.NewSet(attribute.String("country", "fr"), attribute.Bool("express", true)),
+					Value:      3,
+				},
+				{
+					Attributes: attribute.NewSet(attribute.String("country", "us")),
+					Value:      1,
+				},
+			},
+		},
+	}, collect(t, reader, "orders.created"), metricdatatest.IgnoreTimestamp())
+
+	assert.Error(t, o.Decr("orders.created", nil, 1))
+	assert.EqualError(t, o.Gauge("orders.created", 1, nil, 1), "metric orders.created is a counter, not a gauge")
+}
+
+func TestOTelGauges(t *testing.T) {
+	o, reader := newTestOTel()
+
+	require.NoError(t, o.Gauge("pool.size", 3, []string{"pool:db"}, 1))
+	require.NoError(t, o.Gauge("pool.size", 5, []string{"pool:db"}, 1))
+
+	metricdatatest.AssertEqual(t, metricdata.Metrics{
+		Name: "pool.size",
+		Data: metricdata.Gauge[float64]{
+			DataPoints: []metricdata.DataPoint[float64]{
+				{Attributes: attribute.NewSet(attribute.String("pool", "db")), Value: 5},
+			},
+		},
+	}, collect(t, reader, "pool.size"), metricdatatest.IgnoreTimestamp())
+}
+
+func TestOTelHistograms(t *testing.T) {
+	o, reader := newTestOTel()
+
+	require.NoError(t, o.Histogram("order.amount", 20, nil, 1))
+	require.NoError(t, o.Distribution("order.amount", 40, nil, 1))
+
+	data, ok := collect(t, reader, "order.amount").Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, data.DataPoints, 1)
+	assert.Equal(t, uint64(2), data.DataPoints[0].Count)
+	assert.Equal(t, 60.0, data.DataPoints[0].Sum)
+
+	require.NoError(t, o.Timing("gorm.query_latency", 100*time.Millisecond, []string{"table:users"}, 1))
+	require.NoError(t, o.TimeInMilliseconds("gorm.query_latency", 300, []string{"table:users"}, 1))
+
+	latency := collect(t, reader, "gorm.query_latency")
+	assert.Equal(t, "s", latency.Unit)
+
+	data, ok = latency.Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, data.DataPoints, 1)
+	assert.Equal(t, uint64(2), data.DataPoints[0].Count)
+	assert.InDelta(t, 0.4, data.DataPoints[0].Sum, 1e-9)
+	assert.Equal(t, attribute.NewSet(attribute.String("table", "users")), data.DataPoints[0].Attributes)
+}
+
+func TestOTelUnsupported(t *testing.T) {
+	o, _ := newTestOTel()
+
+	assert.Error(t, o.Set("users", "42", nil, 1))
+	assert.Error(t, o.SimpleEvent("deploy", "v1"))
+	assert.NoError(t, o.SetWriteTimeout(time.Second))
+	assert.NoError(t, o.Flush())
+	assert.NoError(t, o.Close())
+}
@@ -0,0 +1,301 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	counterKind   = "counter"
+	gaugeKind     = "gauge"
+	histogramKind = "histogram"
+
+	// emptyTagValue is the label value of the tags without a value.
+	emptyTagValue = "true"
+)
+
+// Prometheus is a Metrics client aggregating the metrics in process, in the
+// collectors of a Prometheus registry exposed by its Handler.
+//
+// The metrics are mapped to collectors named after them, with the dots
+// replaced by underscores, and prefixed with the namespace:
+//
+//   - Count, Incr and Decr to counters, which can't be decremented,
+//   - Gauge to gauges,
+//   - Histogram and Distribution to histograms,
+//   - Timing and TimeInMilliseconds to histograms, in seconds.
+//
+// The statsd-style `key:value` tags are mapped to labels; a tag without a
+// value is a label whose value is `true`. The labels of a metric are the keys
+// of the tags of its first value; the following values can't have other keys,
+// and the missing ones are empty. The sample rates are ignored, every value
+// being aggregated in process.
+type Prometheus struct {
+	registry    *prometheus.Registry
+	namespace   string
+	constLabels prometheus.Labels
+
+	mu         sync.Mutex
+	collectors map[string]*promCollector
+}
+
+var _ Metrics = (*Prometheus)(nil)
+
+// promCollector is the collector of a metric, with its label names.
+type promCollector struct {
+	kind      string
+	labels    []string
+	counter   *prometheus.CounterVec
+	gauge     *prometheus.GaugeVec
+	histogram *prometheus.HistogramVec
+}
+
+// NewPrometheus returns a Prometheus client registering its collectors,
+// prefixed with the namespace and with the constant labels, in a registry of
+// its own. The registry includes the Go runtime and the process collectors.
+func NewPrometheus(namespace string, constLabels map[string]string) *Prometheus {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	labels := prometheus.Labels{}
+	for key, value := range constLabels {
+		labels[promName(key)] = value
+	}
+
+	return &Prometheus{
+		registry:    registry,
+		namespace:   promName(namespace),
+		constLabels: labels,
+		collectors:  map[string]*promCollector{},
+	}
+}
+
+// Registry returns the registry of the client, to register other collectors
+// exposed by its Handler.
+func (p *Prometheus) Registry() *prometheus.Registry {
+	return p.registry
+}
+
+// Handler returns the http.Handler exposing the metrics, to be mounted on
+// `/metrics`.
+func (p *Prometheus) Handler() http.Handler {
+	return promhttp.HandlerFor(p.registry, promhttp.HandlerOpts{})
+}
+
+// Gauge sets the value of the gauge.
+func (p *Prometheus) Gauge(name string, value float64, tags []string, _ float64) error {
+	c, labels, err := p.collector(name, gaugeKind, tags)
+	if err != nil {
+		return err
+	}
+
+	c.gauge.With(labels).Set(value)
+
+	return nil
+}
+
+// Count adds the value to the counter. The value can't be negative.
+func (p *Prometheus) Count(name string, value int64, tags []string, _ float64) error {
+	if value < 0 {
+		return fmt.Errorf("counting metric %s: prometheus counters can't be decremented", name)
+	}
+
+	c, labels, err := p.collector(name, counterKind, tags)
+	if err != nil {
+		return err
+	}
+
+	c.counter.With(labels).Add(float64(value))
+
+	return nil
+}
+
+// Histogram observes the value in the histogram.
+func (p *Prometheus) Histogram(name string, value float64, tags []string, _ float64) error {
+	c, labels, err := p.collector(name, histogramKind, tags)
+	if err != nil {
+		return err
+	}
+
+	c.histogram.With(labels).Observe(value)
+
+	return nil
+}
+
+// Distribution observes the value in the histogram, the distributions being
+// aggregated by each instance like the histograms.
+func (p *Prometheus) Distribution(name string, value float64, tags []string, rate float64) error {
+	return p.Histogram(name, value, tags, rate)
+}
+
+// Decr returns an error, the counters can't be decremented.
+func (p *Prometheus) Decr(name string, tags []string, rate float64) error {
+	return p.Count(name, -1, tags, rate)
+}
+
+// Incr adds 1 to the counter.
+func (p *Prometheus) Incr(name string, tags []string, rate float64) error {
+	return p.Count(name, 1, tags, rate)
+}
+
+// Set returns an error, the sets being not supported.
+func (p *Prometheus) Set(name string, _ string, _ []string, _ float64) error {
+	return fmt.Errorf("setting metric %s: prometheus sets are not supported", name)
+}
+
+// Timing observes the duration in the histogram, in seconds.
+func (p *Prometheus) Timing(name string, value time.Duration, tags []string, rate float64) error {
+	return p.Histogram(name, value.Seconds(), tags, rate)
+}
+
+// TimeInMilliseconds observes the duration in the histogram, in seconds.
+func (p *Prometheus) TimeInMilliseconds(name string, value float64, tags []string, rate float64) error {
+	return p.Histogram(name, value/float64(time.Second/time.Millisecond), tags, rate)
+}
+
+// SimpleEvent returns an error, the events being not supported.
+func (p *Prometheus) SimpleEvent(title, _ string) error {
+	return fmt.Errorf("sending event %s: prometheus events are not supported", title)
+}
+
+// Close does nothing, the metrics being collected by Prometheus.
+func (p *Prometheus) Close() error {
+	return nil
+}
+
+// Flush does nothing, the metrics being collected by Prometheus.
+func (p *Prometheus) Flush() error {
+	return nil
+}
+
+// SetWriteTimeout does nothing, the metrics being collected by Prometheus.
+func (p *Prometheus) SetWriteTimeout(time.Duration) error {
+	return nil
+}
+
+// collector returns the collector of the metric, registering it on its
+// first value, and the labels of the tags.
+func (p *Prometheus) collector(name, kind string, tags []string) (*promCollector, prometheus.Labels, error) {
+	labels := tagsToLabels(tags)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	c, ok := p.collectors[name]
+	if !ok {
+		var err error
+		if c, err = p.register(name, kind, labels); err != nil {
+			return nil, nil, err
+		}
+		p.collectors[name] = c
+	}
+
+	if c.kind != kind {
+		return nil, nil, fmt.Errorf("metric %s is a %s, not a %s", name, c.kind, kind)
+	}
+
+	for key := range labels {
+		if !slices.Contains(c.labels, key) {
+			return nil, nil, fmt.Errorf("metric %s has no label %s, its labels being %v", name, key, c.labels)
+		}
+	}
+	for _, key := range c.labels {
+		if _, ok := labels[key]; !ok {
+			labels[key] = ""
+		}
+	}
+
+	return c, labels, nil
+}
+
+func (p *Prometheus) register(name, kind string, labels prometheus.Labels) (*promCollector, error) {
+	labelNames := make([]string, 0, len(labels))
+	for key := range labels {
+		labelNames = append(labelNames, key)
+	}
+	slices.Sort(labelNames)
+
+	fqName := promName(name)
+	if p.namespace != "" {
+		fqName = p.namespace + "_" + fqName
+	}
+
+	c := &promCollector{kind: kind, labels: labelNames}
+
+	var collector prometheus.Collector
+	switch kind {
+	case counterKind:
+		c.counter = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: fqName, Help: name, ConstLabels: p.constLabels,
+		}, labelNames)
+		collector = c.counter
+	case gaugeKind:
+		c.gauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: fqName, Help: name, ConstLabels: p.constLabels,
+		}, labelNames)
+		collector = c.gauge
+	default:
+		c.histogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: fqName, Help: name, ConstLabels: p.constLabels,
+		}, labelNames)
+		collector = c.histogram
+	}
+
+	if err := p.registry.Register(collector); err != nil {
+		return nil, fmt.Errorf("registering metric %s. err: %w", name, err)
+	}
+
+	return c, nil
+}
+
+// tagsToLabels returns the labels of the statsd-style `key:value` tags.
+func tagsToLabels(tags []string) prometheus.Labels {
+	labels := make(prometheus.Labels, len(tags))
+	for _, tag := range tags {
+		if tag == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(tag, ":")
+		if !ok {
+			value = emptyTagValue
+		}
+		labels[promName(key)] = value
+	}
+
+	return labels
+}
+
+// promName returns the name with the characters not allowed in the
+// Prometheus metric and label names replaced by underscores.
+func promName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+
+		return '_'
+	}, name)
+}
+
+// Handler returns the http.Handler exposing the metrics of the client, to
+// be mounted on `/metrics`, if it's a Prometheus one, or a handler
+// responding with a 404 status otherwise.
+func Handler(m Metrics) http.Handler {
+	if p, ok := m.(*Prometheus); ok {
+		return p.Handler()
+	}
+
+	return http.NotFoundHandler()
+}
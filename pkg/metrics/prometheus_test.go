@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusCounters(t *testing.T) {
+	p := NewPrometheus("app", map[string]string{"env": "test"})
+
+	require.NoError(t, p.Incr("kafka_client.broker.read_errors_total", []string{"node:1"}, 1))
+	require.NoError(t, p.Count("kafka_client.broker.read_errors_total", 2, []string{"node:1"}, 0.5))
+	require.NoError(t, p.Incr("kafka_client.broker.read_errors_total", []string{"node:2"}, 1))
+
+	expected := `
+# HELP app_kafka_client_broker_read_errors_total kafka_client.broker.read_errors_total
+# TYPE app_kafka_client_broker_read_errors_total counter
+app_kafka_client_broker_read_errors_total{env="test",node="1"} 3
+app_kafka_client_broker_read_errors_total{env="test",node="2"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(p.Registry(), strings.NewReader(expected),
+		"app_kafka_client_broker_read_errors_total"))
+
+	assert.Error(t, p.Decr("kafka_client.broker.read_errors_total", []string{"node:1"}, 1))
+	assert.Error(t, p.Count("kafka_client.broker.read_errors_total", -2, []string{"node:1"}, 1))
+}
+
+func TestPrometheusGaugesAndHistograms(t *testing.T) {
+	p := NewPrometheus("", nil)
+
+	require.NoError(t, p.Gauge("pool.size", 3, []string{"pool:db"}, 1))
+	require.NoError(t, p.Gauge("pool.size", 5, []string{"pool:db"}, 1))
+	assert.Equal(t, 5.0, testutil.ToFloat64(p.collectors["pool.size"].gauge.WithLabelValues("db")))
+
+	require.NoError(t, p.Histogram("gorm.query_latency", 0.2, nil, 1))
+	require.NoError(t, p.Distribution("gorm.query_latency", 0.4, nil, 1))
+	require.NoError(t, p.Timing("gorm.query_latency", 100*time.Millisecond, nil, 1))
+	require.NoError(t, p.TimeInMilliseconds("gorm.query_latency", 300, nil, 1))
+
+	expected := `
+# HELP gorm_query_latency gorm.query_latency
+# TYPE gorm_query_latency histogram
+gorm_query_latency_bucket{le="0.005"} 0
+gorm_query_latency_bucket{le="0.01"} 0
+gorm_query_latency_bucket{le="0.025"} 0
+gorm_query_latency_bucket{le="0.05"} 0
+gorm_query_latency_bucket{le="0.1"} 1
+gorm_query_latency_bucket{le="0.25"} 2
+gorm_query_latency_bucket{le="0.5"} 4
+gorm_query_latency_bucket{le="1"} 4
+gorm_query_latency_bucket{le="2.5"} 4
+gorm_query_latency_bucket{le="5"} 4
+gorm_query_latency_bucket{le="10"} 4
+gorm_query_latency_bucket{le="+Inf"} 4
+gorm_query_latency_sum 1
+gorm_query_latency_count 4
+`
+	assert.NoError(t, testutil.GatherAndCompare(p.Registry(), strings.NewReader(expected), "gorm_query_latency"))
+}
+
+func TestPrometheusLabels(t *testing.T) {
+	p := NewPrometheus("", nil)
+
+	require.NoError(t, p.Incr("http.requests", []string{"method:GET", "status-class:2xx", "cached"}, 1))
+	// The missing labels are empty.
+	require.NoError(t, p.Incr("http.requests", []string{"method:POST"}, 1))
+
+	expected := `
+# HELP http_requests http.requests
+# TYPE http_requests counter
+http_requests{cached="",method="POST",status_class=""} 1
+http_requests{cached="true",method="GET",status_class="2xx"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(p.Registry(), strings.NewReader(expected), "http_requests"))
+
+	err := p.Incr("http.requests", []string{"route:/users"}, 1)
+	assert.EqualError(t, err, "metric http.requests has no label route, its labels being [cached method status_class]")
+
+	err = p.Gauge("http.requests", 1, nil, 1)
+	assert.EqualError(t, err, "metric http.requests is a counter, not a gauge")
+}
+
+func TestPrometheusUnsupported(t *testing.T) {
+	p := NewPrometheus("", nil)
+
+	assert.Error(t, p.Set("users", "42", nil, 1))
+	assert.Error(t, p.SimpleEvent("deploy", "v1"))
+	assert.NoError(t, p.Flush())
+	assert.NoError(t, p.SetWriteTimeout(time.Second))
+	assert.NoError(t, p.Close())
+}
+
+func TestHandler(t *testing.T) {
+	p := NewPrometheus("app", nil)
+	require.NoError(t, p.Incr("orders.created", []string{"country:fr"}, 1))
+
+	recorder := httptest.NewRecorder()
+	Handler(p).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body, err := io.ReadAll(recorder.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, string(body), `app_orders_created{country="fr"} 1`)
+	assert.Contains(t, string(body), "go_goroutines")
+
+	recorder = httptest.NewRecorder()
+	Handler(nil).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
@@ -0,0 +1,60 @@
+package metrics
+
+import "time"
+
+// sampledMetrics is a Metrics client sending the metrics with the sample
+// rates of their names, instead of the ones given, with the wrapped client.
+// The other metrics are sent with the rates given.
+type sampledMetrics struct {
+	Metrics
+	rates map[string]float64
+}
+
+func newSampledMetrics(metrics Metrics, rates map[string]float64) *sampledMetrics {
+	return &sampledMetrics{Metrics: metrics, rates: rates}
+}
+
+func (s *sampledMetrics) Gauge(name string, value float64, tags []string, rate float64) error {
+	return s.Metrics.Gauge(name, value, tags, s.rate(name, rate))
+}
+
+func (s *sampledMetrics) Count(name string, value int64, tags []string, rate float64) error {
+	return s.Metrics.Count(name, value, tags, s.rate(name, rate))
+}
+
+func (s *sampledMetrics) Histogram(name string, value float64, tags []string, rate float64) error {
+	return s.Metrics.Histogram(name, value, tags, s.rate(name, rate))
+}
+
+func (s *sampledMetrics) Distribution(name string, value float64, tags []string, rate float64) error {
+	return s.Metrics.Distribution(name, value, tags, s.rate(name, rate))
+}
+
+func (s *sampledMetrics) Decr(name string, tags []string, rate float64) error {
+	return s.Metrics.Decr(name, tags, s.rate(name, rate))
+}
+
+func (s *sampledMetrics) Incr(name string, tags []string, rate float64) error {
+	return s.Metrics.Incr(name, tags, s.rate(name, rate))
+}
+
+func (s *sampledMetrics) Set(name string, value string, tags []string, rate float64) error {
+	return s.Metrics.Set(name, value, tags, s.rate(name, rate))
+}
+
+func (s *sampledMetrics) Timing(name string, value time.Duration, tags []string, rate float64) error {
+	return s.Metrics.Timing(name, value, tags, s.rate(name, rate))
+}
+
+func (s *sampledMetrics) TimeInMilliseconds(name string, value float64, tags []string, rate float64) error {
+	return s.Metrics.TimeInMilliseconds(name, value, tags, s.rate(name, rate))
+}
+
+// rate returns the sample rate of the metric, or the rate given.
+func (s *sampledMetrics) rate(name string, rate float64) float64 {
+	if r, ok := s.rates[name]; ok {
+		return r
+	}
+
+	return rate
+}
@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ratesRecorder records the sample rates of the metrics sent.
+type ratesRecorder struct {
+	Noop
+	rates []float64
+}
+
+func (r *ratesRecorder) Incr(_ string, _ []string, rate float64) error {
+	r.rates = append(r.rates, rate)
+	return nil
+}
+
+func (r *ratesRecorder) Timing(_ string, _ time.Duration, _ []string, rate float64) error {
+	r.rates = append(r.rates, rate)
+	return nil
+}
+
+func TestSampledMetrics(t *testing.T) {
+	recorder := &ratesRecorder{}
+	m := newSampledMetrics(recorder, map[string]float64{"orders.created": 0.5, "orders.latency": 0.1})
+
+	require.NoError(t, m.Incr("orders.created", nil, 1))
+	require.NoError(t, m.Incr("orders.cancelled", nil, 0.8))
+	require.NoError(t, m.Timing("orders.latency", time.Second, nil, 1))
+
+	assert.Equal(t, []float64{0.5, 0.8, 0.1}, recorder.rates)
+}
@@ -40,7 +40,7 @@ func (dlm DatabaseLoggingMiddleware) Handler(next http.Handler) http.Handler {
 		}
 
 		newDB := db.Session(&gorm.Session{
-			Logger: sdklogger.NewGormLogger(l),
+			Logger: sdklogger.NewGormLoggerFrom(db.Logger, l),
 			NewDB:  true,
 		})
 
@@ -4,10 +4,11 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/gorilla/mux"
+
 	sdkloggercontext "github.com/scribd/go-sdk/pkg/context/logger"
-	sdkrequestidcontext "github.com/scribd/go-sdk/pkg/context/requestid"
-	sdkinstrumentation "github.com/scribd/go-sdk/pkg/instrumentation"
 	sdklogger "github.com/scribd/go-sdk/pkg/logger"
+	"github.com/scribd/go-sdk/pkg/tracking"
 )
 
 const (
@@ -39,29 +40,14 @@ func NewLoggingMiddleware(l sdklogger.Logger) LoggingMiddleware {
 // the total elapsed time per request in milliseconds.
 func (lm LoggingMiddleware) Handler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		logContext := sdkinstrumentation.TraceLogs(r.Context())
-
-		requestID, err := sdkrequestidcontext.Extract(r.Context())
-		if err != nil {
-			lm.logger.WithFields(sdklogger.Fields{
-				"error": err.Error(),
-			}).Tracef("Could not retrieve request id from the context")
-		}
+		ctx := tracking.WithRequestHub(r.Context(), routeTemplate(r))
 
-		logger := lm.logger.WithFields(sdklogger.Fields{
-			"http": sdklogger.Fields{
-				"request_id": requestID,
-			},
-			"dd": sdklogger.Fields{
-				"trace_id": logContext.TraceID,
-				"span_id":  logContext.SpanID,
-			},
-		})
+		logger := sdklogger.WithContext(lm.logger, ctx)
 
 		start := time.Now()
 		lrw := newLoggingResponseWriter(w)
 
-		ctx := sdkloggercontext.ToContext(r.Context(), logger)
+		ctx = sdkloggercontext.ToContext(ctx, logger)
 
 		// Parse the request params/form to populate r.Form for
 		// logging. The request form has to be parsed before the
@@ -77,7 +63,6 @@ func (lm LoggingMiddleware) Handler(next http.Handler) http.Handler {
 		logger = logger.WithFields(sdklogger.Fields{
 			"http": sdklogger.Fields{
 				"remote_addr":            r.RemoteAddr,
-				"request_id":             requestID,
 				"request_ip":             r.Header.Get(ForwardedForHeader),
 				"request_method":         r.Method,
 				"request_path":           r.URL.EscapedPath(),
@@ -87,10 +72,6 @@ func (lm LoggingMiddleware) Handler(next http.Handler) http.Handler {
 				"response_status":        lrw.StatusCode,
 				"response_time_total_ms": time.Since(start).Milliseconds(),
 			},
-			"dd": sdklogger.Fields{
-				"trace_id": logContext.TraceID,
-				"span_id":  logContext.SpanID,
-			},
 		})
 
 		switch {
@@ -116,6 +97,22 @@ func (lm LoggingMiddleware) Handler(next http.Handler) http.Handler {
 	})
 }
 
+// routeTemplate returns the path template of the route of the request, if
+// it's served by a mux router.
+func routeTemplate(r *http.Request) string {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return ""
+	}
+
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return ""
+	}
+
+	return template
+}
+
 type loggingResponseWriter struct {
 	http.ResponseWriter
 	StatusCode int
@@ -7,7 +7,13 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/getsentry/sentry-go"
+	"github.com/gorilla/mux"
+
+	sdkloggercontext "github.com/scribd/go-sdk/pkg/context/logger"
+	sdkrequestidcontext "github.com/scribd/go-sdk/pkg/context/requestid"
 	sdklogger "github.com/scribd/go-sdk/pkg/logger"
+	"github.com/scribd/go-sdk/pkg/logger/loggertest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -36,6 +42,7 @@ func TestOutputStructuredContentFromMiddleware(t *testing.T) {
 
 	req, err := http.NewRequest("GET", "http://example.com", nil)
 	require.Nil(t, err)
+	req = req.WithContext(sdkrequestidcontext.ToContext(req.Context(), "request-id"))
 
 	recorder := httptest.NewRecorder()
 
@@ -71,12 +78,12 @@ func TestOutputStructuredContentFromMiddleware(t *testing.T) {
 		assert.NotEmpty(t, fields["message"])
 		assert.Equal(t, "info", fields["level"])
 		assert.NotEmpty(t, fields["timestamp"])
+		assert.Equal(t, "request-id", fields["request_id"])
 		assert.NotEmpty(t, fields["http"])
 
 		var http = (fields["http"]).(map[string]any)
 
 		assert.NotNil(t, http["remote_addr"])
-		assert.NotNil(t, http["request_id"])
 		assert.NotNil(t, http["request_ip"])
 		assert.NotEmpty(t, http["request_method"])
 		assert.NotNil(t, http["request_path"])
@@ -139,3 +146,49 @@ func TestResponseStatusFromMiddleware(t *testing.T) {
 
 	assertions(fields)
 }
+
+func TestMiddlewareWithRecordingLogger(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		l, err := sdkloggercontext.Extract(req.Context())
+		require.Nil(t, err)
+
+		l.WithFields(sdklogger.Fields{"user_id": 42}).Debugf("loading the user")
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	req, err := http.NewRequest("GET", "http://example.com/users?id=42", nil)
+	require.Nil(t, err)
+
+	l := loggertest.New()
+	NewLoggingMiddleware(l).Handler(handler).ServeHTTP(httptest.NewRecorder(), req)
+
+	l.AssertLogged(t, sdklogger.Debug, "^loading the user$", sdklogger.Fields{"user_id": 42})
+	l.AssertLogged(t, sdklogger.Error, "^GET /users HTTP/1.1 500$", sdklogger.Fields{
+		"http": sdklogger.Fields{
+			"request_method":  "GET",
+			"request_path":    "/users",
+			"request_params":  map[string]any{"id": []string{"42"}},
+			"response_status": 500,
+		},
+	})
+	l.AssertCount(t, sdklogger.Error, 1)
+}
+
+func TestMiddlewareRequestHub(t *testing.T) {
+	var hub *sentry.Hub
+
+	router := mux.NewRouter()
+	router.Use(NewLoggingMiddleware(loggertest.New()).Handler)
+	router.HandleFunc("/users/{id}", func(w http.ResponseWriter, req *http.Request) {
+		hub = sentry.GetHubFromContext(req.Context())
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.NotNil(t, hub)
+	assert.NotSame(t, sentry.CurrentHub(), hub)
+
+	event := hub.Scope().ApplyToEvent(&sentry.Event{}, nil, nil)
+	assert.Equal(t, "/users/{id}", event.Tags["route"])
+}
@@ -1,42 +1,197 @@
 package middleware
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"runtime/debug"
+	"time"
+
+	"github.com/sirupsen/logrus"
 
 	sdkloggercontext "github.com/scribd/go-sdk/pkg/context/logger"
+	sdkmetricscontext "github.com/scribd/go-sdk/pkg/context/metrics"
+	sdklogger "github.com/scribd/go-sdk/pkg/logger"
+	sdkmetrics "github.com/scribd/go-sdk/pkg/metrics"
+	"github.com/scribd/go-sdk/pkg/tracking"
+)
+
+const (
+	// recoveryFlushTimeout is the time given to the reporter to send the
+	// panic, before the service halts.
+	recoveryFlushTimeout = time.Second * 5
+
+	// panicsMetric counts the panics recovered, tagged with the route of
+	// the request.
+	panicsMetric = "http.server.panics_total"
 )
 
 // RecoveryMiddleware is a middleware that recovers from panics and logs them.
-type RecoveryMiddleware struct{}
+type RecoveryMiddleware struct {
+	reporter tracking.Reporter
+	metrics  sdkmetrics.Metrics
+	fatal    bool
+}
+
+// RecoveryOption sets an optional parameter for the RecoveryMiddleware.
+type RecoveryOption func(*RecoveryMiddleware)
+
+// RecoveryReporter is used to report the panics with the reporter, instead of
+// the tracking hook of the logger of the request.
+func RecoveryReporter(reporter tracking.Reporter) RecoveryOption {
+	return func(rm *RecoveryMiddleware) { rm.reporter = reporter }
+}
+
+// RecoveryMetrics is used to count the panics with the metrics client,
+// instead of the one of the request context set by the MetricsMiddleware.
+func RecoveryMetrics(metrics sdkmetrics.Metrics) RecoveryOption {
+	return func(rm *RecoveryMiddleware) { rm.metrics = metrics }
+}
+
+// RecoveryFatal makes the middleware log the panics in fatal level, which
+// halts the service, instead of responding with a 500 status.
+func RecoveryFatal() RecoveryOption {
+	return func(rm *RecoveryMiddleware) { rm.fatal = true }
+}
 
 // NewRecoveryMiddleware is a constructor used to build a RecoveryMiddleware.
 // IMPORTANT: This middleware should be the last one in the middleware chain.
-func NewRecoveryMiddleware() RecoveryMiddleware {
-	return RecoveryMiddleware{}
+func NewRecoveryMiddleware(opts ...RecoveryOption) RecoveryMiddleware {
+	rm := RecoveryMiddleware{}
+	for _, opt := range opts {
+		opt(&rm)
+	}
+
+	return rm
 }
 
 // Handler implements the middlewares.Handlerer interface: it returns a
 // http.Handler to be mounted as middleware. The Handler recovers from a panic,
-// sends a sentry event, sends fatal error log and halts the service.
+// logs it in error level with its stack trace, which sends a sentry event,
+// counts it and responds with a 500 status if nothing was written yet. With
+// RecoveryFatal, it sends fatal error log and halts the service instead.
+//
+// The http.ErrAbortHandler panics, aborting the response, are not recovered.
 func (rm RecoveryMiddleware) Handler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := &recoveryResponseWriter{ResponseWriter: w}
+
 		defer func() {
-			if rec := recover(); rec != nil {
-				l, err := sdkloggercontext.Extract(r.Context())
-				if err != nil {
-					debug.PrintStack()
-					log.Printf("logger not found in context: %v\n", err)
-					log.Fatalf("http: panic serving URI %s: %v", r.URL.RequestURI(), rec)
-				}
-
-				l.WithError(fmt.Errorf("%v", rec)).
-					Fatalf("http: panic serving URI %s: %v", r.URL.RequestURI(), rec)
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if err, ok := rec.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(rec)
 			}
+
+			rm.recover(rw, r, rec, debug.Stack())
 		}()
 
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(rw, r)
 	})
 }
+
+func (rm RecoveryMiddleware) recover(w *recoveryResponseWriter, r *http.Request, rec any, stack []byte) {
+	msg := fmt.Sprintf("http: panic serving URI %s: %v", r.URL.RequestURI(), rec)
+
+	err, ok := rec.(error)
+	if !ok {
+		err = errors.New(fmt.Sprint(rec))
+	}
+
+	route := routeTemplate(r)
+	if route == "" {
+		route = "unknown"
+	}
+
+	if rm.reporter != nil {
+		level := logrus.ErrorLevel
+		if rm.fatal {
+			level = logrus.FatalLevel
+		}
+
+		rm.reporter.Capture(r.Context(), &tracking.EntryError{
+			Level:   level,
+			Message: msg,
+			Fields:  map[string]any{"stack": string(stack)},
+			Err:     err,
+		}, map[string]string{"route": route})
+
+		if rm.fatal {
+			rm.reporter.Flush(recoveryFlushTimeout)
+		}
+
+		err = tracking.Reported(err)
+	}
+
+	metrics := rm.metrics
+	if metrics == nil {
+		metrics, _ = sdkmetricscontext.Extract(r.Context())
+	}
+	if metrics != nil {
+		_ = metrics.Incr(panicsMetric, []string{"route:" + route, "method:" + r.Method}, 1)
+	}
+
+	l, lerr := sdkloggercontext.Extract(r.Context())
+	switch {
+	case lerr != nil && rm.fatal:
+		debug.PrintStack()
+		log.Printf("logger not found in context: %v\n", lerr)
+		log.Fatalf("%s", msg)
+	case lerr != nil:
+		log.Printf("%s\n%s", msg, stack)
+	case rm.fatal:
+		l.WithError(err).Fatalf("%s", msg)
+	default:
+		l.WithFields(sdklogger.Fields{"stack": string(stack)}).WithError(err).Errorf("%s", msg)
+	}
+
+	if !w.wroteHeader {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// recoveryResponseWriter records whether the response was started, not to
+// write the status of a panic after it.
+type recoveryResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+// WriteHeader uses the wrapped http.ResponseWriter to write the given code.
+func (rw *recoveryResponseWriter) WriteHeader(code int) {
+	rw.wroteHeader = true
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+// Write uses the wrapped http.ResponseWriter to write the body.
+func (rw *recoveryResponseWriter) Write(b []byte) (int, error) {
+	rw.wroteHeader = true
+	return rw.ResponseWriter.Write(b)
+}
+
+func (rw *recoveryResponseWriter) Flush() {
+	rw.wroteHeader = true
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (rw *recoveryResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("the wrapped http.ResponseWriter is not a http.Hijacker")
+	}
+
+	rw.wroteHeader = true
+	return h.Hijack()
+}
+
+// Unwrap returns the wrapped http.ResponseWriter, for http.ResponseController.
+func (rw *recoveryResponseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	sdkloggercontext "github.com/scribd/go-sdk/pkg/context/logger"
+	sdklogger "github.com/scribd/go-sdk/pkg/logger"
+	"github.com/scribd/go-sdk/pkg/logger/loggertest"
+	"github.com/scribd/go-sdk/pkg/metrics/metricstest"
+	"github.com/scribd/go-sdk/pkg/tracking"
+	"github.com/scribd/go-sdk/pkg/tracking/trackingtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errPanic = errors.New("nil map")
+
+func panickingHandler(w http.ResponseWriter, req *http.Request) {
+	panic(errPanic)
+}
+
+// serveRecovery serves the request with the handler mounted on a mux route,
+// with the recovery middleware and the logger in the request context.
+func serveRecovery(l sdklogger.Logger, rm RecoveryMiddleware, handler http.HandlerFunc) *httptest.ResponseRecorder {
+	router := mux.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			next.ServeHTTP(w, req.WithContext(sdkloggercontext.ToContext(req.Context(), l)))
+		})
+	})
+	router.Use(rm.Handler)
+	router.HandleFunc("/users/{id}", handler)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+
+	return recorder
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	l := loggertest.New()
+	metrics := metricstest.New()
+
+	recorder := serveRecovery(l, NewRecoveryMiddleware(RecoveryMetrics(metrics)), panickingHandler)
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Equal(t, http.StatusText(http.StatusInternalServerError)+"\n", recorder.Body.String())
+
+	l.AssertLogged(t, sdklogger.Error, "^http: panic serving URI /users/42: nil map$", nil)
+	l.AssertCount(t, sdklogger.Fatal, 0)
+	entries := l.Entries()
+	require.Len(t, entries, 1)
+	assert.ErrorIs(t, entries[0].Err, errPanic)
+	assert.Contains(t, entries[0].Fields["stack"], "panickingHandler")
+
+	metrics.AssertSum(t, "http.server.panics_total", []string{"route:/users/{id}", "method:GET"}, 1)
+}
+
+func TestRecoveryMiddlewareContextMetrics(t *testing.T) {
+	metrics := metricstest.New()
+
+	rm := NewRecoveryMiddleware()
+	handler := NewMetricsMiddleware(metrics).Handler(rm.Handler(http.HandlerFunc(panickingHandler)))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	metrics.AssertSum(t, "http.server.panics_total", []string{"route:unknown", "method:GET"}, 1)
+}
+
+func TestRecoveryMiddlewareReporter(t *testing.T) {
+	l := loggertest.New()
+	reporter := trackingtest.New()
+
+	recorder := serveRecovery(l, NewRecoveryMiddleware(RecoveryReporter(reporter)), panickingHandler)
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+
+	reporter.AssertCount(t, 1)
+	reporter.AssertCaptured(t, "^http: panic serving URI", map[string]string{"route": "/users/{id}"})
+	reporter.AssertErrorCaptured(t, errPanic)
+	assert.Zero(t, reporter.Flushes())
+
+	// The error logged is marked as reported, not to be reported again by
+	// the tracking hook of the logger.
+	entries := l.Entries()
+	require.Len(t, entries, 1)
+	assert.Equal(t, tracking.Reported(errPanic), entries[0].Err)
+}
+
+func TestRecoveryMiddlewareResponseStarted(t *testing.T) {
+	l := loggertest.New()
+
+	recorder := serveRecovery(l, NewRecoveryMiddleware(), func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		panic("after the header")
+	})
+
+	assert.Equal(t, http.StatusAccepted, recorder.Code)
+	assert.Empty(t, recorder.Body.String())
+	l.AssertLogged(t, sdklogger.Error, "after the header$", nil)
+}
+
+func TestRecoveryMiddlewareFatal(t *testing.T) {
+	l := loggertest.New()
+	reporter := trackingtest.New()
+
+	serveRecovery(l, NewRecoveryMiddleware(RecoveryFatal(), RecoveryReporter(reporter)), panickingHandler)
+
+	l.AssertLogged(t, sdklogger.Fatal, "^http: panic serving URI /users/42: nil map$", nil)
+	l.AssertCount(t, sdklogger.Error, 0)
+	assert.Equal(t, 1, reporter.Flushes())
+}
+
+func TestRecoveryMiddlewareAbortHandler(t *testing.T) {
+	l := loggertest.New()
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		serveRecovery(l, NewRecoveryMiddleware(), func(w http.ResponseWriter, req *http.Request) {
+			panic(http.ErrAbortHandler)
+		})
+	})
+	assert.Empty(t, l.Entries())
+}
@@ -0,0 +1,232 @@
+package middleware
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sdkmetrics "github.com/scribd/go-sdk/pkg/metrics"
+)
+
+const (
+	requestsMetric         = "http.server.requests_total"
+	requestDurationMetric  = "http.server.request_duration"
+	requestsInFlightMetric = "http.server.requests_in_flight"
+	requestSizeMetric      = "http.server.request_size"
+	responseSizeMetric     = "http.server.response_size"
+
+	// unknownRoute is the route of the requests not served by a mux route.
+	unknownRoute = "unknown"
+	// otherTagValue is the route of the requests whose route is not in the
+	// allow-list, and the method of the requests with a non-standard one.
+	otherTagValue = "other"
+)
+
+// standardMethods are the methods tagged as is, the others being tagged as
+// otherTagValue.
+var standardMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodConnect: true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+}
+
+// RequestMetricsMiddleware is a middleware that records the rate, the errors
+// and the duration of the requests.
+type RequestMetricsMiddleware struct {
+	metrics sdkmetrics.Metrics
+	routes  map[string]bool
+
+	// inFlight holds the *atomic.Int64 counts of the requests in flight by
+	// tags, the methods being bounded.
+	inFlight sync.Map
+}
+
+// RequestMetricsOption sets an optional parameter for the
+// RequestMetricsMiddleware.
+type RequestMetricsOption func(*RequestMetricsMiddleware)
+
+// RequestMetricsRoutes sets the allow-list of the route templates tagged, such
+// as "/users/{id}", the other routes being tagged as "other". By default, all
+// the route templates are tagged.
+func RequestMetricsRoutes(routes ...string) RequestMetricsOption {
+	return func(rm *RequestMetricsMiddleware) {
+		rm.routes = make(map[string]bool, len(routes))
+		for _, route := range routes {
+			rm.routes[route] = true
+		}
+	}
+}
+
+// NewRequestMetricsMiddleware is a constructor used to build a
+// RequestMetricsMiddleware recording the metrics with the client.
+func NewRequestMetricsMiddleware(metrics sdkmetrics.Metrics, opts ...RequestMetricsOption) *RequestMetricsMiddleware {
+	rm := &RequestMetricsMiddleware{
+		metrics: metrics,
+	}
+	for _, opt := range opts {
+		opt(rm)
+	}
+
+	return rm
+}
+
+// Handler implements the middlewares.Handlerer interface: it returns a
+// http.Handler to be mounted as middleware. The handler records:
+//
+//   - http.server.requests_total, the count of the requests,
+//   - http.server.request_duration, the distribution of their duration, in seconds,
+//   - http.server.requests_in_flight, the gauge of the requests being served,
+//   - http.server.request_size and http.server.response_size, the histograms
+//     of the sizes of their bodies, in bytes.
+//
+// They are tagged with the method, the route template and, except the
+// in-flight gauge, the status class, such as "5xx", of the request. The
+// route template is the one of the mux route of the request, so the
+// middleware must be mounted on the router, with `router.Use`; the requests
+// not served by a route are tagged as "unknown".
+func (rm *RequestMetricsMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		tags := []string{"method:" + rm.method(r), "route:" + rm.route(r)}
+
+		rm.addInFlight(tags, 1)
+		defer rm.addInFlight(tags, -1)
+
+		body := &countingReadCloser{ReadCloser: r.Body}
+		if r.Body != nil {
+			r.Body = body
+		}
+
+		mrw := &metricsResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(mrw, r)
+
+		tags = append(slices.Clone(tags), "status_class:"+statusClass(mrw.status))
+
+		_ = rm.metrics.Incr(requestsMetric, tags, 1)
+		_ = rm.metrics.Distribution(requestDurationMetric, time.Since(start).Seconds(), tags, 1)
+		_ = rm.metrics.Histogram(requestSizeMetric, float64(body.bytes), tags, 1)
+		_ = rm.metrics.Histogram(responseSizeMetric, float64(mrw.bytes), tags, 1)
+	})
+}
+
+// method returns the method of the request, or "other" for the non-standard
+// ones.
+func (rm *RequestMetricsMiddleware) method(r *http.Request) string {
+	if standardMethods[r.Method] {
+		return r.Method
+	}
+
+	return otherTagValue
+}
+
+// route returns the route template of the request, "unknown" without one or
+// "other" if it's not in the allow-list.
+func (rm *RequestMetricsMiddleware) route(r *http.Request) string {
+	route := routeTemplate(r)
+
+	switch {
+	case route == "":
+		return unknownRoute
+	case rm.routes != nil && !rm.routes[route]:
+		return otherTagValue
+	default:
+		return route
+	}
+}
+
+// addInFlight adds delta to the requests in flight with the tags, and
+// records their count.
+func (rm *RequestMetricsMiddleware) addInFlight(tags []string, delta int64) {
+	key := strings.Join(tags, ",")
+	counter, ok := rm.inFlight.Load(key)
+	if !ok {
+		counter, _ = rm.inFlight.LoadOrStore(key, new(atomic.Int64))
+	}
+	inFlight := counter.(*atomic.Int64).Add(delta)
+
+	_ = rm.metrics.Gauge(requestsInFlightMetric, float64(inFlight), tags, 1)
+}
+
+// statusClass returns the class of the status, such as "2xx".
+func statusClass(status int) string {
+	if status < 100 || status > 599 {
+		return otherTagValue
+	}
+
+	return strconv.Itoa(status/100) + "xx"
+}
+
+// countingReadCloser counts the bytes read from the body of a request.
+type countingReadCloser struct {
+	io.ReadCloser
+	bytes int64
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.bytes += int64(n)
+
+	return n, err
+}
+
+// metricsResponseWriter records the status and the size of the response.
+type metricsResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+// WriteHeader uses the wrapped http.ResponseWriter to write the given code.
+func (mrw *metricsResponseWriter) WriteHeader(code int) {
+	if !mrw.wroteHeader {
+		mrw.status = code
+		mrw.wroteHeader = true
+	}
+	mrw.ResponseWriter.WriteHeader(code)
+}
+
+// Write uses the wrapped http.ResponseWriter to write the body.
+func (mrw *metricsResponseWriter) Write(b []byte) (int, error) {
+	mrw.wroteHeader = true
+	n, err := mrw.ResponseWriter.Write(b)
+	mrw.bytes += int64(n)
+
+	return n, err
+}
+
+func (mrw *metricsResponseWriter) Flush() {
+	mrw.wroteHeader = true
+	if f, ok := mrw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (mrw *metricsResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := mrw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("the wrapped http.ResponseWriter is not a http.Hijacker")
+	}
+
+	mrw.wroteHeader = true
+	return h.Hijack()
+}
+
+// Unwrap returns the wrapped http.ResponseWriter, for http.ResponseController.
+func (mrw *metricsResponseWriter) Unwrap() http.ResponseWriter {
+	return mrw.ResponseWriter
+}
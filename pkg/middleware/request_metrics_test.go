@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"github.com/scribd/go-sdk/pkg/metrics/metricstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMetricsRouter(metrics *metricstest.Recorder, opts ...RequestMetricsOption) *mux.Router {
+	router := mux.NewRouter()
+	router.Use(NewRequestMetricsMiddleware(metrics, opts...).Handler)
+	router.HandleFunc("/users/{id}", func(w http.ResponseWriter, req *http.Request) {
+		_, _ = io.ReadAll(req.Body)
+		_, _ = w.Write([]byte("user"))
+	})
+	router.HandleFunc("/orders/{id}", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	return router
+}
+
+func TestRequestMetricsMiddleware(t *testing.T) {
+	metrics := metricstest.New()
+	router := newMetricsRouter(metrics)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/users/42", strings.NewReader("name")))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/users/43", strings.NewReader("name")))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/orders/1", nil))
+
+	tags := []string{"method:PUT", "route:/users/{id}", "status_class:2xx"}
+	metrics.AssertSum(t, "http.server.requests_total", tags, 2)
+	assert.Len(t, metrics.Values("http.server.request_duration", tags...), 2)
+	assert.Equal(t, []float64{4, 4}, metrics.Values("http.server.request_size", tags...))
+	assert.Equal(t, []float64{4, 4}, metrics.Values("http.server.response_size", tags...))
+	assert.Equal(t, []float64{1, 0, 1, 0}, metrics.Values("http.server.requests_in_flight", "method:PUT"))
+	metrics.AssertNotEmitted(t, "http.server.requests_in_flight", []string{"status_class:2xx"})
+
+	tags = []string{"method:GET", "route:/orders/{id}", "status_class:4xx"}
+	metrics.AssertSum(t, "http.server.requests_total", tags, 1)
+	assert.Equal(t, []float64{0}, metrics.Values("http.server.response_size", tags...))
+}
+
+func TestRequestMetricsMiddlewareCardinality(t *testing.T) {
+	metrics := metricstest.New()
+	router := newMetricsRouter(metrics, RequestMetricsRoutes("/users/{id}"))
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/42", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/orders/42", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("PURGE", "/users/42", nil))
+
+	metrics.AssertSum(t, "http.server.requests_total", []string{"method:GET", "route:/users/{id}", "status_class:2xx"}, 1)
+	metrics.AssertSum(t, "http.server.requests_total", []string{"method:GET", "route:other", "status_class:4xx"}, 1)
+	metrics.AssertSum(t, "http.server.requests_total", []string{"method:other", "route:/users/{id}", "status_class:2xx"}, 1)
+}
+
+func TestRequestMetricsMiddlewareWithoutRoute(t *testing.T) {
+	metrics := metricstest.New()
+	handler := NewRequestMetricsMiddleware(metrics).Handler(testingFailingHandler(t))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	// The raw path is never used as a tag.
+	requests := metrics.FilterName("http.server.requests_total")
+	require.Len(t, requests, 1)
+	assert.Equal(t, []string{"method:GET", "route:unknown", "status_class:4xx"}, requests[0].Tags)
+}
+
+func TestStatusClass(t *testing.T) {
+	testCases := []struct {
+		status int
+		want   string
+	}{
+		{status: http.StatusOK, want: "2xx"},
+		{status: http.StatusMovedPermanently, want: "3xx"},
+		{status: http.StatusNotFound, want: "4xx"},
+		{status: http.StatusServiceUnavailable, want: "5xx"},
+		{status: 999, want: "other"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.want, func(t *testing.T) {
+			assert.Equal(t, tc.want, statusClass(tc.status))
+		})
+	}
+}
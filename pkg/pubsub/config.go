@@ -3,10 +3,15 @@ package pubsub
 import (
 	"errors"
 	"fmt"
+	"io"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/spf13/viper"
+
 	cbuilder "github.com/scribd/go-sdk/internal/pkg/configuration/builder"
+	"github.com/scribd/go-sdk/internal/pkg/configuration/validation"
 )
 
 type (
@@ -17,17 +22,21 @@ type (
 		Kafka Kafka `mapstructure:"kafka"`
 		// SQS contains the configuration for AWS SQS client
 		SQS SQS `mapstructure:"sqs"`
+
+		// loader is how the configuration is read, and read again when
+		// it's watched.
+		loader cbuilder.Loader
 	}
 
 	// Publisher contains the publisher specific configuration
 	Publisher struct {
 		// MaxAttempts represents the maximum number of times
 		// the client will try to send message again in case of failure
-		MaxAttempts int `mapstructure:"max_attempts"`
+		MaxAttempts int `mapstructure:"max_attempts" validate:"gte=0"`
 		// WriteTimeout the maximum amount of time the client will wait for message to be written to Kafka topic
-		WriteTimeout time.Duration `mapstructure:"write_timeout"`
+		WriteTimeout time.Duration `mapstructure:"write_timeout" validate:"gte=0"`
 		// Topic the Kafka topic name to publish messages to
-		Topic string `mapstructure:"topic"`
+		Topic string `mapstructure:"topic" validate:"when=Enabled,required"`
 		// Enabled whether the publisher is enabled or not
 		Enabled bool `mapstructure:"enabled"`
 		// MetricsEnabled controls if metrics publishing is enabled or not
@@ -36,9 +45,9 @@ type (
 
 	Subscriber struct {
 		// Topic the Kafka topic name to retrieve messages from
-		Topic string `mapstructure:"topic"`
+		Topic string `mapstructure:"topic" validate:"when=Enabled,required"`
 		// GroupId the Kafka consumer group id
-		GroupId string `mapstructure:"group_id"`
+		GroupId string `mapstructure:"group_id" validate:"when=Enabled,required"`
 		// Enabled whether the subscriber id enabled or not
 		Enabled bool `mapstructure:"enabled"`
 		// MetricsEnabled controls if metrics publishing is enabled or not
@@ -46,11 +55,11 @@ type (
 		// AutoCommit controls if the subscriber should auto commit messages
 		AutoCommit AutoCommit `mapstructure:"auto_commit"`
 		// Workers controls the number of workers that will be used to process messages
-		Workers int `mapstructure:"workers"`
+		Workers int `mapstructure:"workers" validate:"when=Enabled,gt=0"`
 		// BlockRebalance controls if the rebalance event should be blocked while the polling is in progress
 		BlockRebalance bool `mapstructure:"block_rebalance"`
 		// MaxRecords controls the maximum number of records to be fetched in a single request
-		MaxRecords int `mapstructure:"max_records"`
+		MaxRecords int `mapstructure:"max_records" validate:"gte=0"`
 	}
 
 	AutoCommit struct {
@@ -142,10 +151,10 @@ type (
 	SQSSubscriber struct {
 		Enabled     bool   `mapstructure:"enabled"`
 		QueueURL    string `mapstructure:"queue_url"`
-		MaxMessages int    `mapstructure:"max_messages"`
-		Workers     int    `mapstructure:"workers"`
+		MaxMessages int    `mapstructure:"max_messages" validate:"gte=0,lte=10"`
+		Workers     int    `mapstructure:"workers" validate:"gte=0"`
 
-		WaitTime time.Duration `mapstructure:"wait_time"`
+		WaitTime time.Duration `mapstructure:"wait_time" validate:"gte=0,lte=20s"`
 	}
 
 	SQSPublisher struct {
@@ -180,23 +189,61 @@ var (
 	ErrEmptySQSQueueURL = errors.New("sqs queue url is empty")
 )
 
+func init() {
+	cbuilder.Register("pubsub", func(loader cbuilder.Loader) (any, error) {
+		return newConfig(loader)
+	})
+}
+
 // NewConfig returns a new Config instance.
 func NewConfig() (*Config, error) {
-	config := &Config{}
-	viperBuilder := cbuilder.New("pubsub")
-
-	viperBuilder.SetDefault("kafka.subscriber.auto_commit.enabled", true)
+	return newConfig(cbuilder.Loader{})
+}
 
-	vConf, err := viperBuilder.Build()
+func newConfig(loader cbuilder.Loader) (*Config, error) {
+	vConf, err := newViperBuilder(loader).Build()
 	if err != nil {
-		return config, err
+		return &Config{}, err
 	}
 
-	if err = vConf.Unmarshal(config); err != nil {
+	return decodeConfig(vConf, loader)
+}
+
+// Watch starts watching the pubsub configuration file. Every time a change
+// to it is loaded and validated, onChange is called with the previous and
+// the new configuration. A change that cannot be loaded or is not valid is
+// passed to onError, which can be nil, and the previous configuration stays
+// in place.
+func (c *Config) Watch(onChange func(old, new *Config), onError func(error)) (io.Closer, error) {
+	viperBuilder := newViperBuilder(c.loader)
+	if _, err := viperBuilder.Build(); err != nil {
+		return nil, err
+	}
+
+	current := *c
+	return cbuilder.WatchDecoded(viperBuilder, &current, func(vConf *viper.Viper) (*Config, error) {
+		return decodeConfig(vConf, c.loader)
+	}, onChange, onError)
+}
+
+func newViperBuilder(loader cbuilder.Loader) *cbuilder.ViperBuilder {
+	viperBuilder := loader.New("pubsub")
+
+	viperBuilder.SetDefault("kafka.subscriber.auto_commit.enabled", true)
+
+	return viperBuilder
+}
+
+func decodeConfig(vConf *viper.Viper, loader cbuilder.Loader) (*Config, error) {
+	// The build hook is for the initial load only, not for the reloads.
+	loader.OnBuild = nil
+
+	config := &Config{loader: loader}
+	if err := vConf.Unmarshal(config); err != nil {
 		return config, fmt.Errorf("unable to decode into struct: %s", err.Error())
 	}
 
-	if err = config.validate(); err != nil {
+	if err := validation.Validate("pubsub", config); err != nil {
 		return config, err
 	}
 
@@ -205,24 +252,53 @@ func NewConfig() (*Config, error) {
 	return config, nil
 }
 
-func (c *Config) validate() error {
-	if c.Kafka.SASL.Enabled && c.Kafka.SASLMechanism() == Unknown {
+// Validate checks the Kafka settings involving several fields.
+func (k Kafka) Validate() error {
+	var errs validation.Errors
+
+	if k.SASL.Enabled && k.SASLMechanism() == Unknown {
 		var allowedMechanisms []string
 		for k := range _stringToSASLMechanism {
 			allowedMechanisms = append(allowedMechanisms, k)
 		}
+		slices.Sort(allowedMechanisms)
+
+		errs = append(errs, &validation.FieldError{
+			Path: "sasl.mechanism",
+			Err: fmt.Errorf(
+				"%s mechanism provided, but following mechanisms are allowed: %s",
+				k.SASL.Mechanism,
+				strings.Join(allowedMechanisms, ","),
+			),
+		})
+	}
+	if (k.Publisher.Enabled || k.Subscriber.Enabled) && len(k.BrokerUrls) == 0 {
+		errs = append(errs, &validation.FieldError{
+			Path: "broker_urls",
+			Err:  errors.New("is required when the publisher or the subscriber is enabled"),
+		})
+	}
 
-		return fmt.Errorf(
-			"%s mechanism provided, but following mechanisms are allowed: %s",
-			c.Kafka.SASL.Mechanism,
-			strings.Join(allowedMechanisms, ","),
-		)
+	if len(errs) == 0 {
+		return nil
 	}
-	if c.SQS.Publisher.Enabled && c.SQS.Publisher.QueueURL == "" {
-		return ErrEmptySQSQueueURL
+
+	return errs
+}
+
+// Validate checks that the queue URL is set when the publisher is enabled.
+func (p SQSPublisher) Validate() error {
+	if p.Enabled && p.QueueURL == "" {
+		return &validation.FieldError{Path: "queue_url", Err: ErrEmptySQSQueueURL}
 	}
-	if c.SQS.Subscriber.Enabled && c.SQS.Subscriber.QueueURL == "" {
-		return ErrEmptySQSQueueURL
+
+	return nil
+}
+
+// Validate checks that the queue URL is set when the subscriber is enabled.
+func (s SQSSubscriber) Validate() error {
+	if s.Enabled && s.QueueURL == "" {
+		return &validation.FieldError{Path: "queue_url", Err: ErrEmptySQSQueueURL}
 	}
 
 	return nil
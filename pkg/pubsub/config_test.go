@@ -1,9 +1,11 @@
 package pubsub
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -426,3 +428,74 @@ func TestNewConfigWithAppRoot(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigWatch(t *testing.T) {
+	appRoot := t.TempDir()
+	t.Setenv("APP_ROOT", appRoot)
+	require.NoError(t, os.Mkdir(filepath.Join(appRoot, "config"), 0o700))
+
+	write := func(workers int, queueURL string) {
+		content := fmt.Sprintf(
+			"test:\n  kafka:\n    subscriber:\n      workers: %d\n  sqs:\n    publisher:\n      enabled: true\n      queue_url: %q\n",
+			workers, queueURL)
+		require.NoError(t, os.WriteFile(filepath.Join(appRoot, "config", "pubsub.yml"), []byte(content), 0o600))
+	}
+	write(1, "https://sqs/queue")
+
+	c, err := NewConfig()
+	require.NoError(t, err)
+
+	changes := make(chan [2]*Config, 1)
+	errs := make(chan error, 1)
+	w, err := c.Watch(func(old, new *Config) {
+		changes <- [2]*Config{old, new}
+	}, func(err error) {
+		errs <- err
+	})
+	require.NoError(t, err)
+	defer w.Close()
+
+	write(4, "https://sqs/queue")
+
+	select {
+	case got := <-changes:
+		assert.Equal(t, 1, got[0].Kafka.Subscriber.Workers)
+		assert.Equal(t, 4, got[1].Kafka.Subscriber.Workers)
+	case err := <-errs:
+		t.Fatalf("unexpected error: %s", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("configuration was not reloaded")
+	}
+
+	// An enabled publisher without a queue is rejected.
+	write(8, "")
+
+	select {
+	case err := <-errs:
+		assert.ErrorIs(t, err, ErrEmptySQSQueueURL)
+	case got := <-changes:
+		t.Fatalf("invalid configuration applied: %+v", got[1])
+	case <-time.After(5 * time.Second):
+		t.Fatal("error was not reported")
+	}
+}
+
+func TestNewConfigValidation(t *testing.T) {
+	appRoot := t.TempDir()
+	t.Setenv("APP_ROOT", appRoot)
+	require.NoError(t, os.Mkdir(filepath.Join(appRoot, "config"), 0o700))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(appRoot, "config", "pubsub.yml"),
+		[]byte("test:\n  kafka:\n    broker_urls: [\"localhost:9092\"]\n    subscriber:\n      enabled: true\n      topic: test\n  sqs:\n    subscriber:\n      max_messages: 20\n"),
+		0o600,
+	))
+
+	_, err := NewConfig()
+	require.Error(t, err)
+
+	assert.Equal(t, strings.Join([]string{
+		"pubsub.kafka.subscriber.group_id (APP_PUBSUB_KAFKA_SUBSCRIBER_GROUP_ID): is required",
+		"pubsub.kafka.subscriber.workers (APP_PUBSUB_KAFKA_SUBSCRIBER_WORKERS): must be > 0",
+		"pubsub.sqs.subscriber.max_messages (APP_PUBSUB_SQS_SUBSCRIBER_MAX_MESSAGES): must be <= 10",
+	}, "\n"), err.Error())
+}
@@ -0,0 +1,133 @@
+/*
+Package secrets resolves secret references found in configuration values.
+
+A secret reference has the form `${<scheme>:<reference>}`, for example
+`${file:/run/secrets/db}` or `${env:DATABASE_PASSWORD}`. The reference is
+resolved by the SecretResolver registered for the scheme. The `file` and
+`env` resolvers are registered by default; the AWS Secrets Manager and SSM
+Parameter Store resolvers are provided by the aws package.
+*/
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	// FileScheme is the scheme of references to the content of a file.
+	FileScheme = "file"
+	// EnvScheme is the scheme of references to an environment variable.
+	EnvScheme = "env"
+)
+
+// SecretResolver resolves the secret references of a single scheme.
+type SecretResolver interface {
+	// Resolve returns the secret the reference points to. The reference
+	// is the part of the placeholder after the scheme.
+	Resolve(ctx context.Context, reference string) (string, error)
+}
+
+// SecretResolverFunc is an adapter to use ordinary functions as
+// SecretResolver.
+type SecretResolverFunc func(ctx context.Context, reference string) (string, error)
+
+// Resolve calls f(ctx, reference).
+func (f SecretResolverFunc) Resolve(ctx context.Context, reference string) (string, error) {
+	return f(ctx, reference)
+}
+
+var (
+	referencePattern = regexp.MustCompile(`\$\{([a-z][a-z0-9]*):([^}]+)\}`)
+
+	registryMu sync.RWMutex
+	registry   = map[string]SecretResolver{
+		FileScheme: SecretResolverFunc(resolveFile),
+		EnvScheme:  SecretResolverFunc(resolveEnv),
+	}
+)
+
+// Register registers the resolver for the given scheme, replacing any
+// resolver previously registered for it.
+func Register(scheme string, resolver SecretResolver) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	registry[scheme] = resolver
+}
+
+// Unregister removes the resolver registered for the given scheme.
+func Unregister(scheme string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	delete(registry, scheme)
+}
+
+// HasReference reports whether value contains at least one secret reference.
+func HasReference(value string) bool {
+	return referencePattern.MatchString(value)
+}
+
+// Resolve replaces every secret reference in value with the secret it points
+// to. A reference with a scheme without a registered resolver is an error,
+// so that an unresolved placeholder is never used as a secret.
+func Resolve(ctx context.Context, value string) (string, error) {
+	var resolveErr error
+
+	resolved := referencePattern.ReplaceAllStringFunc(value, func(placeholder string) string {
+		if resolveErr != nil {
+			return placeholder
+		}
+
+		match := referencePattern.FindStringSubmatch(placeholder)
+		scheme, reference := match[1], match[2]
+
+		registryMu.RLock()
+		resolver, ok := registry[scheme]
+		registryMu.RUnlock()
+
+		if !ok {
+			resolveErr = fmt.Errorf("no secret resolver registered for scheme %q", scheme)
+			return placeholder
+		}
+
+		secret, err := resolver.Resolve(ctx, reference)
+		if err != nil {
+			resolveErr = fmt.Errorf("resolving %s secret: %w", scheme, err)
+			return placeholder
+		}
+
+		return secret
+	})
+
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+
+	return resolved, nil
+}
+
+func resolveFile(_ context.Context, reference string) (string, error) {
+	content, err := os.ReadFile(reference)
+	if err != nil {
+		return "", err
+	}
+
+	// Secret files mounted by orchestrators or written by editors usually
+	// end with a new line that is not part of the secret.
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+func resolveEnv(_ context.Context, reference string) (string, error) {
+	value, ok := os.LookupEnv(reference)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", reference)
+	}
+
+	return value, nil
+}
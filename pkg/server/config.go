@@ -2,10 +2,14 @@ package server
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
+	"github.com/spf13/viper"
+
 	cbuilder "github.com/scribd/go-sdk/internal/pkg/configuration/builder"
+	"github.com/scribd/go-sdk/internal/pkg/configuration/validation"
 )
 
 const (
@@ -17,18 +21,22 @@ type (
 	Config struct {
 		Host string `mapstructure:"host"`
 
-		HTTPPort    string      `mapstructure:"http_port"`
+		HTTPPort    string      `mapstructure:"http_port" validate:"omitempty,port"`
 		HTTPTimeout HTTPTimeout `mapstructure:"http_timeout"`
 
-		GRPCPort string `mapstructure:"grpc_port"`
+		GRPCPort string `mapstructure:"grpc_port" validate:"omitempty,port"`
 		Cors     Cors   `mapstructure:"cors"`
+
+		// loader is how the configuration is read, and read again when
+		// it's watched.
+		loader cbuilder.Loader
 	}
 
 	// HTTPTimeout represents collection of different timeout regarding net/http.Server.
 	HTTPTimeout struct {
-		Write time.Duration `mapstructure:"write"`
-		Read  time.Duration `mapstructure:"read"`
-		Idle  time.Duration `mapstructure:"idle"`
+		Write time.Duration `mapstructure:"write" validate:"gte=0"`
+		Read  time.Duration `mapstructure:"read" validate:"gte=0"`
+		Idle  time.Duration `mapstructure:"idle" validate:"gte=0"`
 	}
 
 	// Cors struct represents a flag indicating if CORS feature is enabled or not
@@ -43,7 +51,7 @@ type (
 	CorsSetting struct {
 		// Path represents a server route string, for example "/example/{id}" for which the following CORS settings
 		// will be applied
-		Path string `mapstructure:"path"`
+		Path string `mapstructure:"path" validate:"required"`
 		// AllowedOrigins is a list of origins a cross-domain request can be executed from.
 		// If the special "*" value is present in the list, all origins will be allowed.
 		// An origin may contain a wildcard (*) to replace 0 or more characters
@@ -77,27 +85,65 @@ type (
 		AllowCredentials bool `mapstructure:"allow_credentials"`
 		// MaxAge indicates how long (in seconds) the results of a preflight request
 		// can be cached
-		MaxAge int `mapstructure:"max_age"`
+		MaxAge int `mapstructure:"max_age" validate:"gte=0"`
 		// AllowCredentials indicates whether the request can include user credentials like
 		// cookies, HTTP authentication or client side SSL certificates.
 		OptionsPassthrough bool `mapstructure:"options_passthrough"`
 	}
 )
 
+func init() {
+	cbuilder.Register("server", func(loader cbuilder.Loader) (any, error) {
+		return newConfig(loader)
+	})
+}
+
 // NewConfig returns a new ServerConfig instance
 func NewConfig() (*Config, error) {
-	config := &Config{}
-	viperBuilder := cbuilder.New("server")
+	return newConfig(cbuilder.Loader{})
+}
 
-	vConf, err := viperBuilder.Build()
+func newConfig(loader cbuilder.Loader) (*Config, error) {
+	vConf, err := loader.New("server").Build()
 	if err != nil {
-		return config, err
+		return &Config{}, err
+	}
+
+	return decodeConfig(vConf, loader)
+}
+
+// Watch starts watching the server configuration file. Every time a change
+// to it is loaded, onChange is called with the previous and the new
+// configuration. A change that cannot be loaded is passed to onError, which
+// can be nil, and the previous configuration stays in place.
+//
+// The CORS origin functions are not part of the configuration file, they
+// have to be set again on the new configuration.
+func (c *Config) Watch(onChange func(old, new *Config), onError func(error)) (io.Closer, error) {
+	viperBuilder := c.loader.New("server")
+	if _, err := viperBuilder.Build(); err != nil {
+		return nil, err
 	}
 
-	if err = vConf.Unmarshal(config); err != nil {
+	current := *c
+	return cbuilder.WatchDecoded(viperBuilder, &current, func(vConf *viper.Viper) (*Config, error) {
+		return decodeConfig(vConf, c.loader)
+	}, onChange, onError)
+}
+
+func decodeConfig(vConf *viper.Viper, loader cbuilder.Loader) (*Config, error) {
+	// The build hook is for the initial load only, not for the reloads.
+	loader.OnBuild = nil
+
+	config := &Config{loader: loader}
+	if err := vConf.Unmarshal(config); err != nil {
 		return config, fmt.Errorf("unable to decode into struct: %s", err.Error())
 	}
 
+	if err := validation.Validate("server", config); err != nil {
+		return config, err
+	}
+
 	return config, nil
 }
 
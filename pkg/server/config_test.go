@@ -91,3 +91,28 @@ func TestNewConfigWithAppRoot(t *testing.T) {
 		})
 	}
 }
+
+func TestNewConfigValidation(t *testing.T) {
+	appRoot := t.TempDir()
+	t.Setenv("APP_ROOT", appRoot)
+	require.NoError(t, os.Mkdir(filepath.Join(appRoot, "config"), 0o700))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(appRoot, "config", "server.yml"),
+		[]byte("test:\n  http_port: 8080\n  http_timeout:\n    read: 1s\n"),
+		0o600,
+	))
+
+	_, err := NewConfig()
+	require.NoError(t, err)
+
+	t.Setenv("APP_SERVER_HTTP_PORT", "http")
+	t.Setenv("APP_SERVER_HTTP_TIMEOUT_READ", "-1s")
+
+	_, err = NewConfig()
+	require.Error(t, err)
+
+	assert.Equal(t,
+		"server.http_port (APP_SERVER_HTTP_PORT): must be a port number, got \"http\"\n"+
+			"server.http_timeout.read (APP_SERVER_HTTP_TIMEOUT_READ): must be >= 0",
+		err.Error())
+}
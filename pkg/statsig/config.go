@@ -6,24 +6,35 @@ import (
 	"time"
 
 	cbuilder "github.com/scribd/go-sdk/internal/pkg/configuration/builder"
+	"github.com/scribd/go-sdk/internal/pkg/configuration/validation"
 )
 
 // Config stores the configuration for the statsig.
 type Config struct {
 	SecretKey          string        `mapstructure:"secret_key"`
 	LocalMode          bool          `mapstructure:"local_mode"`
-	ConfigSyncInterval time.Duration `mapstructure:"config_sync_interval"`
-	IDListSyncInterval time.Duration `mapstructure:"id_list_sync_interval"`
+	ConfigSyncInterval time.Duration `mapstructure:"config_sync_interval" validate:"gte=0"`
+	IDListSyncInterval time.Duration `mapstructure:"id_list_sync_interval" validate:"gte=0"`
 
 	environment string
 }
 
+func init() {
+	cbuilder.Register("statsig", func(loader cbuilder.Loader) (any, error) {
+		return newConfig(loader)
+	})
+}
+
 // NewConfig returns a new StatsigConfig instance.
 func NewConfig() (*Config, error) {
+	return newConfig(cbuilder.Loader{})
+}
+
+func newConfig(loader cbuilder.Loader) (*Config, error) {
 	config := &Config{}
 	config.environment = os.Getenv("APP_ENV")
 
-	viperBuilder := cbuilder.New("statsig")
+	viperBuilder := loader.New("statsig")
 
 	vConf, err := viperBuilder.Build()
 	if err != nil {
@@ -34,5 +45,9 @@ func NewConfig() (*Config, error) {
 		return config, fmt.Errorf("unable to decode into struct: %s", err.Error())
 	}
 
+	if err = validation.Validate("statsig", config); err != nil {
+		return config, err
+	}
+
 	return config, nil
 }
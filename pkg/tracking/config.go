@@ -3,27 +3,65 @@ package tracking
 import (
 	"fmt"
 	"os"
+	"regexp"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/sirupsen/logrus"
 
 	cbuilder "github.com/scribd/go-sdk/internal/pkg/configuration/builder"
+	"github.com/scribd/go-sdk/internal/pkg/configuration/validation"
 )
 
 // Config stores the configuration for the tracking.
 type Config struct {
 	SentryDSN string `mapstructure:"dsn"`
+	// SampleRate is the rate of the events sent, between 0 and 1. Zero,
+	// the default, sends them all.
+	SampleRate float64 `mapstructure:"sample_rate" validate:"gte=0,lte=1"`
+	// Levels are the log levels of the entries reported as events, panic,
+	// fatal and error by default.
+	Levels []string `mapstructure:"levels"`
+	// BreadcrumbLevel is the most verbose log level of the entries recorded
+	// as breadcrumbs of the events of their request, info by default.
+	BreadcrumbLevel string `mapstructure:"breadcrumb_level"`
+	// MaxBreadcrumbs is the maximum number of breadcrumbs of an event. Zero
+	// keeps Sentry's default, 100.
+	MaxBreadcrumbs int `mapstructure:"max_breadcrumbs" validate:"gte=0"`
+	// IgnoreErrors are the regular expressions of the error messages not
+	// reported.
+	IgnoreErrors []string `mapstructure:"ignore_errors"`
+	// ScrubFields are the names of the fields, tags, headers and cookies
+	// whose values are replaced before the events are sent, matched
+	// case-insensitively as substrings. They replace the default ones:
+	// password, secret, token, authorization, cookie and api_key.
+	ScrubFields []string `mapstructure:"scrub_fields"`
+	// BeforeSend changes or drops the events after they are scrubbed, like
+	// sentry.ClientOptions.BeforeSend. It's set by the application.
+	BeforeSend func(event *sentry.Event, hint *sentry.EventHint) *sentry.Event `mapstructure:"-"`
 
 	environment string
 	release     string
 	serverName  string
 }
 
+func init() {
+	cbuilder.Register("tracking", func(loader cbuilder.Loader) (any, error) {
+		return newConfig(loader)
+	})
+}
+
 // NewConfig returns a new TrackingConfig instance.
 func NewConfig() (*Config, error) {
+	return newConfig(cbuilder.Loader{})
+}
+
+func newConfig(loader cbuilder.Loader) (*Config, error) {
 	config := &Config{}
 	config.environment = os.Getenv("APP_ENV")
 	config.release = os.Getenv("APP_VERSION")
 	config.serverName = os.Getenv("APP_SERVER_NAME")
 
-	viperBuilder := cbuilder.New("sentry")
+	viperBuilder := loader.New("sentry")
 
 	vConf, err := viperBuilder.Build()
 	if err != nil {
@@ -34,5 +72,38 @@ func NewConfig() (*Config, error) {
 		return config, fmt.Errorf("unable to decode into struct: %s", err.Error())
 	}
 
+	if err = validation.Validate("sentry", config); err != nil {
+		return config, err
+	}
+
 	return config, nil
 }
+
+// Validate checks the levels and the patterns of the ignored errors.
+func (c *Config) Validate() error {
+	var errs validation.Errors
+
+	for _, level := range c.Levels {
+		if _, err := logrus.ParseLevel(level); err != nil {
+			errs = append(errs, &validation.FieldError{Path: "levels", Err: fmt.Errorf("unknown log level %q", level)})
+		}
+	}
+
+	if c.BreadcrumbLevel != "" {
+		if _, err := logrus.ParseLevel(c.BreadcrumbLevel); err != nil {
+			errs = append(errs, &validation.FieldError{Path: "breadcrumb_level", Err: fmt.Errorf("unknown log level %q", c.BreadcrumbLevel)})
+		}
+	}
+
+	for _, pattern := range c.IgnoreErrors {
+		if _, err := regexp.Compile(pattern); err != nil {
+			errs = append(errs, &validation.FieldError{Path: "ignore_errors", Err: err})
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
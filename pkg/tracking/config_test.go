@@ -78,3 +78,44 @@ func TestNewConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigValidate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config Config
+		errors []string
+	}{
+		{
+			name:   "Valid",
+			config: Config{Levels: []string{"error", "warn"}, BreadcrumbLevel: "debug", IgnoreErrors: []string{"^context"}},
+		},
+		{
+			name:   "UnknownLevels",
+			config: Config{Levels: []string{"error", "loud"}, BreadcrumbLevel: "verbose"},
+			errors: []string{
+				`levels: unknown log level "loud"`,
+				`breadcrumb_level: unknown log level "verbose"`,
+			},
+		},
+		{
+			name:   "InvalidPattern",
+			config: Config{IgnoreErrors: []string{"(unclosed"}},
+			errors: []string{"ignore_errors: error parsing regexp"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if len(tc.errors) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Error(t, err)
+			for _, msg := range tc.errors {
+				assert.ErrorContains(t, err, msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package tracking
+
+import (
+	"context"
+
+	"github.com/DataDog/dd-trace-go/v2/ddtrace/tracer"
+	"github.com/getsentry/sentry-go"
+
+	sdkrequestidcontext "github.com/scribd/go-sdk/pkg/context/requestid"
+)
+
+const (
+	requestIDTag = "request_id"
+	routeTag     = "route"
+	traceIDTag   = "trace_id"
+	userTag      = "user"
+)
+
+// WithRequestHub returns a context carrying a clone of the hub of ctx, or of
+// the current hub, to record the breadcrumbs and the user of a request
+// without sharing them with the other requests. The events reported with it
+// are tagged with the route and the request ID of the request.
+//
+// It's called by the logging middleware and interceptors, so that the entries
+// logged during a request are the breadcrumbs of its events only.
+func WithRequestHub(ctx context.Context, route string) context.Context {
+	hub := hubFromContext(ctx).Clone()
+
+	scope := hub.Scope()
+	if route != "" {
+		scope.SetTag(routeTag, route)
+	}
+	if requestID, err := sdkrequestidcontext.Extract(ctx); err == nil {
+		scope.SetTag(requestIDTag, requestID)
+	}
+
+	return sentry.SetHubOnContext(ctx, hub)
+}
+
+// SetUser sets the user of the events reported with the hub of the request
+// of ctx, tagging them with the ID of the user. It does nothing outside of
+// a request.
+func SetUser(ctx context.Context, user sentry.User) {
+	hub := requestHub(ctx)
+	if hub == nil {
+		return
+	}
+
+	scope := hub.Scope()
+	scope.SetUser(user)
+	if user.ID != "" {
+		scope.SetTag(userTag, user.ID)
+	}
+}
+
+// requestHub returns the hub of the request of ctx, if any.
+func requestHub(ctx context.Context) *sentry.Hub {
+	if ctx == nil {
+		return nil
+	}
+
+	return sentry.GetHubFromContext(ctx)
+}
+
+// hubFromContext returns the hub of the request of ctx, or the current hub.
+func hubFromContext(ctx context.Context) *sentry.Hub {
+	if hub := requestHub(ctx); hub != nil {
+		return hub
+	}
+
+	return sentry.CurrentHub()
+}
+
+// ContextTags returns the request ID and the trace ID of the context as
+// tags, added by the reporters to the tags of the errors captured.
+func ContextTags(ctx context.Context) map[string]string {
+	tags := map[string]string{}
+	if ctx == nil {
+		return tags
+	}
+
+	if requestID, err := sdkrequestidcontext.Extract(ctx); err == nil {
+		tags[requestIDTag] = requestID
+	}
+
+	if span, ok := tracer.SpanFromContext(ctx); ok {
+		tags[traceIDTag] = span.Context().TraceID()
+	}
+
+	return tags
+}
@@ -0,0 +1,200 @@
+package tracking
+
+import (
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// breadcrumbCategory is the category of the breadcrumbs of the log
+	// entries.
+	breadcrumbCategory = "log"
+
+	defaultBreadcrumbLevel = logrus.InfoLevel
+
+	// fatalFlushTimeout is the time given to the reporter to send the
+	// Fatal and Panic entries, before the program exits.
+	fatalFlushTimeout = time.Second * 5
+)
+
+var defaultLevels = []logrus.Level{
+	logrus.PanicLevel,
+	logrus.FatalLevel,
+	logrus.ErrorLevel,
+}
+
+// Hook is a service hook for the Logrus logger.
+//
+// It's used for reporting errors and messages on specific log levels with a
+// Reporter, such as the Sentry one. The entries are captured as an
+// EntryError, wrapping the error logged with WithError, if any. The entries
+// whose error is marked as Reported are not captured again.
+type Hook struct {
+	reporter Reporter
+	levels   []logrus.Level
+	tags     map[string]string
+
+	breadcrumbs *BreadcrumbHook
+}
+
+// NewHook returns a hook reporting the entries with the reporter, on the
+// levels of the configuration, Panic, Fatal and Error by default.
+func NewHook(reporter Reporter, config *Config) (*Hook, error) {
+	levels, breadcrumbLevels, err := hookLevels(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Hook{
+		reporter:    reporter,
+		levels:      levels,
+		tags:        map[string]string{},
+		breadcrumbs: &BreadcrumbHook{levels: breadcrumbLevels},
+	}, nil
+}
+
+// Levels returns the list of Logrus levels for which this hook is configured
+// to report errors.
+func (hook *Hook) Levels() []logrus.Level {
+	return hook.levels
+}
+
+// Fire reports the given Logrus Entry with the reporter of the hook.
+func (hook *Hook) Fire(entry *logrus.Entry) error {
+	entryError, _ := entry.Data[logrus.ErrorKey].(error)
+	if entryError != nil && isReported(entryError) {
+		return nil
+	}
+
+	hook.reporter.Capture(entry.Context, &EntryError{
+		Level:   entry.Level,
+		Message: entry.Message,
+		Fields:  map[string]any(entry.Data),
+		Err:     entryError,
+	}, maps.Clone(hook.tags))
+
+	if entry.Level <= logrus.FatalLevel {
+		hook.reporter.Flush(fatalFlushTimeout)
+	}
+
+	return nil
+}
+
+// Reporter returns the reporter of the hook.
+func (hook *Hook) Reporter() Reporter {
+	return hook.reporter
+}
+
+// BreadcrumbHook returns the hook recording the entries of the levels less
+// severe than the reported ones, down to the breadcrumb level of the
+// configuration, as breadcrumbs of the events of their request.
+func (hook *Hook) BreadcrumbHook() *BreadcrumbHook {
+	return hook.breadcrumbs
+}
+
+// SetTags sets the given map of tags to every Sentry Event handled by this hook.
+func (hook *Hook) SetTags(tags map[string]string) {
+	hook.tags = tags
+}
+
+// AddTag add a pair (key, value) in the map of tags attached to every
+// Sentry Event handled by this hook.
+func (hook *Hook) AddTag(key, value string) {
+	hook.tags[key] = value
+}
+
+// SetRelease sets the release that every Sentry Event handled by this
+// hook refers to. It does nothing with the other reporters.
+func (hook *Hook) SetRelease(release string) {
+	if r, ok := hook.reporter.(*SentryReporter); ok {
+		r.release = release
+	}
+}
+
+// SetEnvironment sets the environment that every Sentry Event handled by this
+// hook refers to. It does nothing with the other reporters.
+func (hook *Hook) SetEnvironment(environment string) {
+	if r, ok := hook.reporter.(*SentryReporter); ok {
+		r.environment = environment
+	}
+}
+
+// BreadcrumbHook is a Logrus hook recording the entries as breadcrumbs in
+// the hub of their request, set by WithRequestHub, so that the events of the
+// request carry the entries logged before them. The entries logged outside
+// of a request are not recorded.
+type BreadcrumbHook struct {
+	levels []logrus.Level
+}
+
+// Levels returns the list of Logrus levels recorded as breadcrumbs.
+func (hook *BreadcrumbHook) Levels() []logrus.Level {
+	return hook.levels
+}
+
+// Fire records the entry as a breadcrumb of the hub of its context.
+func (hook *BreadcrumbHook) Fire(entry *logrus.Entry) error {
+	hub := requestHub(entry.Context)
+	if hub == nil {
+		return nil
+	}
+
+	data := make(map[string]any, len(entry.Data))
+	for key, value := range entry.Data {
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+		data[key] = value
+	}
+
+	hub.AddBreadcrumb(&sentry.Breadcrumb{
+		Category:  breadcrumbCategory,
+		Message:   entry.Message,
+		Data:      data,
+		Level:     levelsMap[entry.Level],
+		Timestamp: entry.Time,
+	}, nil)
+
+	return nil
+}
+
+// hookLevels returns the levels reported and the levels recorded as
+// breadcrumbs, the levels less severe than the reported ones down to the
+// breadcrumb level.
+func hookLevels(config *Config) ([]logrus.Level, []logrus.Level, error) {
+	levels := defaultLevels
+	if len(config.Levels) > 0 {
+		levels = make([]logrus.Level, 0, len(config.Levels))
+		for _, name := range config.Levels {
+			level, err := logrus.ParseLevel(name)
+			if err != nil {
+				return nil, nil, fmt.Errorf("parsing sentry level. err: %w", err)
+			}
+			levels = append(levels, level)
+		}
+	}
+
+	breadcrumbLevel := defaultBreadcrumbLevel
+	if config.BreadcrumbLevel != "" {
+		var err error
+		if breadcrumbLevel, err = logrus.ParseLevel(config.BreadcrumbLevel); err != nil {
+			return nil, nil, fmt.Errorf("parsing sentry breadcrumb level. err: %w", err)
+		}
+	}
+
+	leastSevere := logrus.PanicLevel
+	for _, level := range levels {
+		leastSevere = max(leastSevere, level)
+	}
+
+	var breadcrumbLevels []logrus.Level
+	for level := leastSevere + 1; level <= breadcrumbLevel; level++ {
+		breadcrumbLevels = append(breadcrumbLevels, level)
+	}
+
+	return levels, breadcrumbLevels, nil
+}
@@ -0,0 +1,85 @@
+package tracking
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type capture struct {
+	err  error
+	tags map[string]string
+}
+
+type recordingReporter struct {
+	captures []capture
+	flushes  int
+}
+
+func (r *recordingReporter) Capture(_ context.Context, err error, tags map[string]string) {
+	r.captures = append(r.captures, capture{err: err, tags: tags})
+}
+
+func (r *recordingReporter) Flush(time.Duration) bool {
+	r.flushes++
+	return true
+}
+
+func TestHookFire(t *testing.T) {
+	reporter := &recordingReporter{}
+	hook, err := NewHook(reporter, &Config{})
+	require.NoError(t, err)
+	hook.AddTag("service", "users")
+
+	logger := newMockLogger(hook)
+	errTimeout := errors.New("timeout")
+
+	logger.Warnf("not reported")
+	logger.WithError(errTimeout).WithField("table", "users").Errorf("query failed")
+	logger.Errorf("without error")
+
+	require.Len(t, reporter.captures, 2)
+	assert.Equal(t, map[string]string{"service": "users"}, reporter.captures[0].tags)
+	assert.ErrorIs(t, reporter.captures[0].err, errTimeout)
+
+	var entry *EntryError
+	require.ErrorAs(t, reporter.captures[0].err, &entry)
+	assert.Equal(t, logrus.ErrorLevel, entry.Level)
+	assert.Equal(t, "query failed", entry.Message)
+	assert.Equal(t, "users", entry.Fields["table"])
+
+	require.ErrorAs(t, reporter.captures[1].err, &entry)
+	assert.Nil(t, entry.Err)
+	assert.Equal(t, "without error", entry.Error())
+
+	assert.Zero(t, reporter.flushes)
+}
+
+func TestHookFireReported(t *testing.T) {
+	reporter := &recordingReporter{}
+	hook, err := NewHook(reporter, &Config{})
+	require.NoError(t, err)
+
+	logger := newMockLogger(hook)
+	logger.WithError(Reported(errors.New("panic"))).Errorf("recovered")
+
+	assert.Empty(t, reporter.captures)
+	assert.Nil(t, Reported(nil))
+}
+
+func TestHookFireFlushesFatal(t *testing.T) {
+	reporter := &recordingReporter{}
+	hook, err := NewHook(reporter, &Config{})
+	require.NoError(t, err)
+
+	err = hook.Fire(&logrus.Entry{Level: logrus.FatalLevel, Message: "fatal", Data: logrus.Fields{}})
+	require.NoError(t, err)
+
+	assert.Len(t, reporter.captures, 1)
+	assert.Equal(t, 1, reporter.flushes)
+}
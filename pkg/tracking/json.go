@@ -0,0 +1,94 @@
+package tracking
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// JSONReporter is a Reporter writing the errors as JSON lines, such as:
+//
+//	{"time":"...","level":"error","message":"query failed","error":"timeout","tags":{"request_id":"..."},"fields":{...}}
+//
+// It's meant for the environments without an error tracking backend, such as
+// the development one, where the errors are collected with the output of
+// the application.
+type JSONReporter struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+var _ Reporter = (*JSONReporter)(nil)
+
+// NewJSONReporter returns a reporter writing the errors to out.
+func NewJSONReporter(out io.Writer) *JSONReporter {
+	return &JSONReporter{out: out}
+}
+
+// NewStdoutReporter returns a reporter writing the errors to the standard
+// output.
+func NewStdoutReporter() *JSONReporter {
+	return NewJSONReporter(os.Stdout)
+}
+
+// jsonReport is the JSON line of an error.
+type jsonReport struct {
+	Time    time.Time         `json:"time"`
+	Level   string            `json:"level"`
+	Message string            `json:"message"`
+	Error   string            `json:"error,omitempty"`
+	Tags    map[string]string `json:"tags,omitempty"`
+	Fields  map[string]any    `json:"fields,omitempty"`
+}
+
+// Capture writes the error as a JSON line. The errors of the fields are
+// written as their message.
+func (r *JSONReporter) Capture(ctx context.Context, err error, tags map[string]string) {
+	entry := entryOf(err)
+
+	report := jsonReport{
+		Time:    time.Now().UTC(),
+		Level:   entry.Level.String(),
+		Message: entry.Message,
+		Tags:    eventTags(ctx, tags),
+	}
+
+	if entry.Err != nil {
+		report.Error = entry.Err.Error()
+	}
+
+	if len(entry.Fields) > 0 {
+		report.Fields = make(map[string]any, len(entry.Fields))
+		for key, value := range entry.Fields {
+			if err, ok := value.(error); ok {
+				value = err.Error()
+			}
+			report.Fields[key] = value
+		}
+	}
+
+	line, marshalErr := json.Marshal(report)
+	if marshalErr != nil {
+		line, _ = json.Marshal(jsonReport{
+			Time:    report.Time,
+			Level:   report.Level,
+			Message: report.Message,
+			Error:   fmt.Sprintf("%s (fields not reported: %v)", report.Error, marshalErr),
+			Tags:    report.Tags,
+		})
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, _ = r.out.Write(append(line, '\n'))
+}
+
+// Flush does nothing, the errors being written when captured.
+func (r *JSONReporter) Flush(time.Duration) bool {
+	return true
+}
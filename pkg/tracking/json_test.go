@@ -0,0 +1,97 @@
+package tracking
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkrequestidcontext "github.com/scribd/go-sdk/pkg/context/requestid"
+)
+
+func TestJSONReporterCapture(t *testing.T) {
+	ctx := sdkrequestidcontext.ToContext(context.Background(), "request-id")
+
+	testCases := []struct {
+		name string
+		err  error
+		tags map[string]string
+		want map[string]any
+	}{
+		{
+			name: "Error",
+			err:  errors.New("timeout"),
+			tags: map[string]string{"transport": "kafka"},
+			want: map[string]any{
+				"level":   "error",
+				"message": "timeout",
+				"error":   "timeout",
+				"tags":    map[string]any{"transport": "kafka", "request_id": "request-id"},
+			},
+		},
+		{
+			name: "Entry",
+			err: &EntryError{
+				Level:   logrus.WarnLevel,
+				Message: "query failed",
+				Fields:  map[string]any{"table": "users", "error": errors.New("timeout")},
+				Err:     errors.New("timeout"),
+			},
+			want: map[string]any{
+				"level":   "warning",
+				"message": "query failed",
+				"error":   "timeout",
+				"tags":    map[string]any{"request_id": "request-id"},
+				"fields":  map[string]any{"table": "users", "error": "timeout"},
+			},
+		},
+		{
+			name: "EntryWithoutError",
+			err:  &EntryError{Level: logrus.ErrorLevel, Message: "without error"},
+			want: map[string]any{
+				"level":   "error",
+				"message": "without error",
+				"tags":    map[string]any{"request_id": "request-id"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			reporter := NewJSONReporter(&buffer)
+
+			reporter.Capture(ctx, tc.err, tc.tags)
+			assert.True(t, reporter.Flush(0))
+
+			var got map[string]any
+			require.NoError(t, json.Unmarshal(buffer.Bytes(), &got))
+			assert.NotEmpty(t, got["time"])
+			delete(got, "time")
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestJSONReporterUnsupportedFields(t *testing.T) {
+	var buffer bytes.Buffer
+	reporter := NewJSONReporter(&buffer)
+
+	reporter.Capture(context.Background(), &EntryError{
+		Level:   logrus.ErrorLevel,
+		Message: "query failed",
+		Fields:  map[string]any{"callback": func() {}},
+	}, nil)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &got))
+	assert.Equal(t, "query failed", got["message"])
+	assert.Contains(t, got["error"], "fields not reported")
+	assert.NotContains(t, got, "fields")
+}
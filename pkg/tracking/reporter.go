@@ -0,0 +1,80 @@
+package tracking
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Reporter reports errors to an error tracking backend, such as Sentry.
+type Reporter interface {
+	// Capture reports the error, with the tags given and the tags of the
+	// context, see ContextTags.
+	Capture(ctx context.Context, err error, tags map[string]string)
+	// Flush waits until the errors captured are sent, or until the
+	// timeout. It reports whether they were all sent.
+	Flush(timeout time.Duration) bool
+}
+
+// EntryError is the error captured by the Hook for a log entry: the error
+// of the entry, if any, with its level, its message and its fields.
+type EntryError struct {
+	Level   logrus.Level
+	Message string
+	Fields  map[string]any
+	// Err is the error logged with WithError, nil for the entries logged
+	// without one.
+	Err error
+}
+
+// Error returns the message of the error of the entry, or the message of
+// the entry.
+func (e *EntryError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+
+	return e.Message
+}
+
+func (e *EntryError) Unwrap() error {
+	return e.Err
+}
+
+// entryOf returns the entry of the error, or an entry at Error level for
+// the errors captured without one.
+func entryOf(err error) *EntryError {
+	var entry *EntryError
+	if errors.As(err, &entry) {
+		return entry
+	}
+
+	return &EntryError{Level: logrus.ErrorLevel, Message: err.Error(), Err: err}
+}
+
+// reportedError is an error already reported.
+type reportedError struct {
+	error
+}
+
+func (e reportedError) Unwrap() error {
+	return e.error
+}
+
+// Reported returns the error marked as reported, so that the Hook does not
+// report it again when it's logged.
+func Reported(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return reportedError{err}
+}
+
+// isReported reports whether the error is marked as reported.
+func isReported(err error) bool {
+	var reported reportedError
+	return errors.As(err, &reported)
+}
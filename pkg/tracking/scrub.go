@@ -0,0 +1,112 @@
+package tracking
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// scrubbedValue replaces the values scrubbed from the events.
+const scrubbedValue = "[Filtered]"
+
+var defaultScrubFields = []string{"password", "secret", "token", "authorization", "cookie", "api_key"}
+
+// scrubber replaces the values of the sensitive fields of the events.
+type scrubber struct {
+	fields []string
+}
+
+func newScrubber(fields []string) *scrubber {
+	if len(fields) == 0 {
+		fields = defaultScrubFields
+	}
+
+	lowered := make([]string, len(fields))
+	for i, field := range fields {
+		lowered[i] = strings.ToLower(field)
+	}
+
+	return &scrubber{fields: lowered}
+}
+
+// sensitive reports whether the values of the key are scrubbed.
+func (s *scrubber) sensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, field := range s.fields {
+		if strings.Contains(key, field) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// scrubEvent scrubs the extra data, the tags, the request and the
+// breadcrumbs of the event.
+func (s *scrubber) scrubEvent(event *sentry.Event) {
+	event.Extra = s.scrubMap(event.Extra)
+
+	for key := range event.Tags {
+		if s.sensitive(key) {
+			event.Tags[key] = scrubbedValue
+		}
+	}
+
+	if event.Request != nil {
+		for key := range event.Request.Headers {
+			if s.sensitive(key) {
+				event.Request.Headers[key] = scrubbedValue
+			}
+		}
+
+		if event.Request.Cookies != "" && s.sensitive("cookie") {
+			event.Request.Cookies = scrubbedValue
+		}
+	}
+
+	for _, breadcrumb := range event.Breadcrumbs {
+		breadcrumb.Data = s.scrubMap(breadcrumb.Data)
+	}
+}
+
+// scrubMap returns a copy of the map with the values of the sensitive keys
+// scrubbed, the nested maps included.
+func (s *scrubber) scrubMap(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+
+	scrubbed := make(map[string]any, len(m))
+	for key, value := range m {
+		if s.sensitive(key) {
+			scrubbed[key] = scrubbedValue
+			continue
+		}
+
+		scrubbed[key] = s.scrubValue(value)
+	}
+
+	return scrubbed
+}
+
+// scrubValue scrubs the value if it's a map with string keys, such as the
+// logger.Fields.
+func (s *scrubber) scrubValue(value any) any {
+	if m, ok := value.(map[string]any); ok {
+		return s.scrubMap(m)
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return value
+	}
+
+	m := make(map[string]any, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		m[iter.Key().String()] = iter.Value().Interface()
+	}
+
+	return s.scrubMap(m)
+}
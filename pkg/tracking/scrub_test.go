@@ -0,0 +1,70 @@
+package tracking
+
+import (
+	"testing"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+)
+
+type fields map[string]any
+
+func TestScrubEvent(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fields   []string
+		event    *sentry.Event
+		expected *sentry.Event
+	}{
+		{
+			name: "DefaultFields",
+			event: &sentry.Event{
+				Extra: map[string]any{
+					"user_id": 42,
+					"http":    fields{"authorization": "Bearer abc", "path": "/"},
+				},
+				Tags: map[string]string{"api_key": "abc", "route": "/"},
+				Request: &sentry.Request{
+					Headers: map[string]string{"Authorization": "Bearer abc", "Accept": "*/*"},
+					Cookies: "session=abc",
+				},
+				Breadcrumbs: []*sentry.Breadcrumb{{Data: map[string]any{"Password": "secret"}}},
+			},
+			expected: &sentry.Event{
+				Extra: map[string]any{
+					"user_id": 42,
+					"http":    map[string]any{"authorization": "[Filtered]", "path": "/"},
+				},
+				Tags: map[string]string{"api_key": "[Filtered]", "route": "/"},
+				Request: &sentry.Request{
+					Headers: map[string]string{"Authorization": "[Filtered]", "Accept": "*/*"},
+					Cookies: "[Filtered]",
+				},
+				Breadcrumbs: []*sentry.Breadcrumb{{Data: map[string]any{"Password": "[Filtered]"}}},
+			},
+		},
+		{
+			name:   "ConfiguredFields",
+			fields: []string{"Email"},
+			event: &sentry.Event{
+				Extra: map[string]any{"user_email": "jane@example.com", "password": "secret"},
+				Request: &sentry.Request{
+					Cookies: "session=abc",
+				},
+			},
+			expected: &sentry.Event{
+				Extra: map[string]any{"user_email": "[Filtered]", "password": "secret"},
+				Request: &sentry.Request{
+					Cookies: "session=abc",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			newScrubber(tc.fields).scrubEvent(tc.event)
+			assert.Equal(t, tc.expected, tc.event)
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package tracking
 
 import (
+	"context"
 	"fmt"
+	"maps"
 	"time"
 
 	"github.com/getsentry/sentry-go"
@@ -20,116 +22,113 @@ var (
 	}
 )
 
-// Hook is a service hook for the Logrus logger.
-//
-// It's used for sending errors and messages to Sentry on specific
-// log levels. It wraps a default Sentry client.
-type Hook struct {
-	levels      []logrus.Level
-	tags        map[string]string
+// SentryReporter is a Reporter sending the errors to Sentry as events, with
+// the hub of their context, the hub of their request set by WithRequestHub,
+// or with the current hub. The errors are sent as exceptions; the entries
+// of the Hook logged without an error as messages.
+type SentryReporter struct {
 	release     string
 	environment string
 }
 
-// Levels returns the list of Logrus levels for which this hook is configured
-// to report errors.
-func (hook *Hook) Levels() []logrus.Level {
-	return hook.levels
-}
+var _ Reporter = (*SentryReporter)(nil)
 
-// Fire uses the configured Sentry client to report the given Logrus Entry as
-// Sentry Event.
-func (hook *Hook) Fire(entry *logrus.Entry) error {
-	entryError, ok := entry.Data[logrus.ErrorKey].(error)
-	if !ok || entryError == nil {
-		return nil
-	}
+// NewSentryReporter initializes Sentry in package level and returns a
+// reporter sending the errors to it.
+func NewSentryReporter(config *Config) (*SentryReporter, error) {
+	scrubber := newScrubber(config.ScrubFields)
 
-	stacktrace := sentry.ExtractStacktrace(entryError)
-	if stacktrace == nil {
-		stacktrace = sentry.NewStacktrace()
+	if err := sentry.Init(sentry.ClientOptions{
+		// The DSN to use. If the DSN is not set, the client is effectively disabled.
+		Dsn: config.SentryDSN,
+		// In debug mode, the debug information is printed to stdout to help you understand what
+		// sentry is doing.
+		Debug: false,
+		// Configures whether SDK should generate and attach stacktraces to pure capture message calls.
+		AttachStacktrace: true,
+		// The sample rate for event submission (0.0 - 1.0, defaults to 1.0)
+		SampleRate: config.SampleRate,
+		// The maximum number of breadcrumbs of an event (defaults to 100).
+		MaxBreadcrumbs: config.MaxBreadcrumbs,
+		// The regular expressions of the error messages not reported.
+		IgnoreErrors: config.IgnoreErrors,
+		// Scrubs the events, then calls the BeforeSend of the configuration.
+		BeforeSend: func(event *sentry.Event, hint *sentry.EventHint) *sentry.Event {
+			scrubber.scrubEvent(event)
+
+			if config.BeforeSend != nil {
+				return config.BeforeSend(event, hint)
+			}
+
+			return event
+		},
+		// The server name to be reported.
+		ServerName: config.serverName,
+		// The release to be sent with events.
+		Release: config.release,
+		// The environment to be sent with events.
+		Environment: config.environment,
+	}); err != nil {
+		return nil, fmt.Errorf("initializing sentry. err: %w", err)
 	}
 
-	exceptions := []sentry.Exception{{
-		Type:       entry.Message,
-		Value:      entryError.Error(),
-		Stacktrace: stacktrace,
-	}}
+	return &SentryReporter{}, nil
+}
+
+// Capture sends the error to Sentry.
+func (r *SentryReporter) Capture(ctx context.Context, err error, tags map[string]string) {
+	entry := entryOf(err)
 
 	event := &sentry.Event{
 		Level:       levelsMap[entry.Level],
 		Message:     entry.Message,
-		Extra:       map[string]any(entry.Data),
-		Tags:        hook.tags,
-		Environment: hook.environment,
-		Release:     hook.release,
-		Exception:   exceptions,
+		Extra:       entry.Fields,
+		Tags:        eventTags(ctx, tags),
+		Environment: r.environment,
+		Release:     r.release,
 	}
 
-	sentry.CaptureEvent(event)
-
-	if entry.Level <= logrus.FatalLevel {
-		sentry.Flush(time.Second * 5)
+	if entry.Err != nil {
+		stacktrace := sentry.ExtractStacktrace(entry.Err)
+		if stacktrace == nil {
+			stacktrace = sentry.NewStacktrace()
+		}
+
+		event.Exception = []sentry.Exception{{
+			Type:       entry.Message,
+			Value:      entry.Err.Error(),
+			Stacktrace: stacktrace,
+		}}
 	}
 
-	return nil
+	hubFromContext(ctx).CaptureEvent(event)
 }
 
-// SetTags sets the given map of tags to every Sentry Event handled by this hook.
-func (hook *Hook) SetTags(tags map[string]string) {
-	hook.tags = tags
+// Flush waits until the events are sent to Sentry, or until the timeout.
+func (r *SentryReporter) Flush(timeout time.Duration) bool {
+	return sentry.Flush(timeout)
 }
 
-// AddTag add a pair (key, value) in the map of tags attached to every
-// Sentry Event handled by this hook.
-func (hook *Hook) AddTag(key, value string) {
-	hook.tags[key] = value
-}
+// eventTags returns the tags given, with the tags of the context.
+func eventTags(ctx context.Context, tags map[string]string) map[string]string {
+	merged := maps.Clone(tags)
+	if merged == nil {
+		merged = map[string]string{}
+	}
 
-// SetRelease sets the release that every Sentry Event handled by this
-// hook refers to.
-func (hook *Hook) SetRelease(release string) {
-	hook.release = release
-}
+	maps.Copy(merged, ContextTags(ctx))
 
-// SetEnvironment sets the environment that every Sentry Event handled by this
-// hook refers to.
-func (hook *Hook) SetEnvironment(environment string) {
-	hook.environment = environment
+	return merged
 }
 
 // NewSentryHook creates a hook to be added to an instance of logger
 // and initializes Sentry in package level. SentryHook will be triggered
-// on Panic, Fatal and Error levels.
+// on the levels of the configuration, Panic, Fatal and Error by default.
 func NewSentryHook(config *Config) (*Hook, error) {
-	if err := sentry.Init(sentry.ClientOptions{
-		// The DSN to use. If the DSN is not set, the client is effectively disabled.
-		Dsn: config.SentryDSN,
-		// In debug mode, the debug information is printed to stdout to help you understand what
-		// sentry is doing.
-		Debug: false,
-		// Configures whether SDK should generate and attach stacktraces to pure capture message calls.
-		AttachStacktrace: true,
-		// The sample rate for event submission (0.0 - 1.0, defaults to 1.0)
-		SampleRate: 1.0,
-		// The server name to be reported.
-		ServerName: config.serverName,
-		// The release to be sent with events.
-		Release: config.release,
-		// The environment to be sent with events.
-		Environment: config.environment,
-	}); err != nil {
-		return nil, fmt.Errorf("initializing sentry. err: %w", err)
-	}
-
-	levels := []logrus.Level{
-		logrus.PanicLevel,
-		logrus.FatalLevel,
-		logrus.ErrorLevel,
+	reporter, err := NewSentryReporter(config)
+	if err != nil {
+		return nil, err
 	}
 
-	return &Hook{
-		levels: levels,
-		tags:   map[string]string{},
-	}, nil
+	return NewHook(reporter, config)
 }
@@ -1,13 +1,18 @@
 package tracking
 
 import (
+	"context"
 	"errors"
 	"io"
 	"testing"
 
+	"github.com/DataDog/dd-trace-go/v2/ddtrace/mocktracer"
+	"github.com/DataDog/dd-trace-go/v2/ddtrace/tracer"
 	"github.com/getsentry/sentry-go"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+
+	sdkrequestidcontext "github.com/scribd/go-sdk/pkg/context/requestid"
 )
 
 const (
@@ -24,9 +29,13 @@ func TestNewSentryHook(t *testing.T) {
 	assert.Empty(t, sentry.LastEventID(),
 		"eventID must be empty without calling sentry hook levels")
 
-	logger.Errorf("sample message")
+	logger.Warnf("sample message")
 	assert.Empty(t, sentry.LastEventID(),
-		"eventID must be empty without calling WithError")
+		"eventID must be empty without calling sentry hook levels")
+
+	logger.Errorf("sample message")
+	assert.NotEmpty(t, sentry.LastEventID(),
+		"eventID must be set without calling WithError")
 
 	logger.WithError(errors.New("sample error")).Errorf("sample message")
 	assert.NotEmpty(t, sentry.LastEventID(),
@@ -79,3 +88,105 @@ func newMockLogger(hook *Hook) *logrus.Logger {
 
 	return logger
 }
+
+// recordingConfig returns a configuration recording the events sent, instead
+// of sending them.
+func recordingConfig(config Config) (*Config, *[]*sentry.Event) {
+	var events []*sentry.Event
+	config.BeforeSend = func(event *sentry.Event, _ *sentry.EventHint) *sentry.Event {
+		events = append(events, event)
+		return nil
+	}
+
+	return &config, &events
+}
+
+func TestSentryHookConfiguredLevels(t *testing.T) {
+	config, events := recordingConfig(Config{Levels: []string{"error", "warn"}, BreadcrumbLevel: "debug"})
+	hook, err := NewSentryHook(config)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []logrus.Level{logrus.ErrorLevel, logrus.WarnLevel}, hook.Levels())
+	assert.Equal(t, []logrus.Level{logrus.InfoLevel, logrus.DebugLevel}, hook.BreadcrumbHook().Levels())
+
+	logger := newMockLogger(hook)
+	logger.Warnf("warning")
+
+	assert.Len(t, *events, 1)
+	assert.Equal(t, sentry.LevelWarning, (*events)[0].Level)
+	assert.Equal(t, "warning", (*events)[0].Message)
+	assert.Empty(t, (*events)[0].Exception)
+}
+
+func TestSentryHookIgnoreErrors(t *testing.T) {
+	config, events := recordingConfig(Config{IgnoreErrors: []string{"^context canceled$"}})
+	hook, err := NewSentryHook(config)
+	assert.NoError(t, err)
+
+	logger := newMockLogger(hook)
+	logger.WithError(errors.New("context canceled")).Errorf("request failed")
+	logger.WithError(errors.New("connection refused")).Errorf("request failed")
+
+	assert.Len(t, *events, 1)
+	assert.Equal(t, "connection refused", (*events)[0].Exception[0].Value)
+}
+
+func TestSentryHookRequestHub(t *testing.T) {
+	config, events := recordingConfig(Config{})
+	hook, err := NewSentryHook(config)
+	assert.NoError(t, err)
+
+	logger := newMockLogger(hook)
+	logger.Hooks.Add(hook.BreadcrumbHook())
+
+	ctx := sdkrequestidcontext.ToContext(context.Background(), "request-1")
+	ctx = WithRequestHub(ctx, "/users/{id}")
+	SetUser(ctx, sentry.User{ID: "42"})
+
+	logger.WithContext(ctx).WithField("user_id", 42).Infof("loading user")
+	logger.WithContext(ctx).Debugf("not recorded")
+	logger.WithContext(ctx).WithField("password", "secret").Warnf("slow query")
+	logger.WithContext(ctx).WithError(errors.New("not found")).Errorf("loading failed")
+
+	// The entries logged outside of the request are not recorded.
+	logger.Infof("outside of the request")
+	logger.WithError(errors.New("failed")).Errorf("outside of the request")
+
+	assert.Len(t, *events, 2)
+
+	event := (*events)[0]
+	assert.Equal(t, "loading failed", event.Message)
+	assert.Equal(t, "request-1", event.Tags["request_id"])
+	assert.Equal(t, "/users/{id}", event.Tags["route"])
+	assert.Equal(t, "42", event.Tags["user"])
+	assert.Equal(t, "42", event.User.ID)
+
+	assert.Len(t, event.Breadcrumbs, 2)
+	assert.Equal(t, "loading user", event.Breadcrumbs[0].Message)
+	assert.Equal(t, sentry.LevelInfo, event.Breadcrumbs[0].Level)
+	assert.Equal(t, 42, event.Breadcrumbs[0].Data["user_id"])
+	assert.Equal(t, "slow query", event.Breadcrumbs[1].Message)
+	assert.Equal(t, "[Filtered]", event.Breadcrumbs[1].Data["password"])
+
+	event = (*events)[1]
+	assert.Empty(t, event.Breadcrumbs)
+	assert.NotContains(t, event.Tags, "route")
+	assert.NotContains(t, event.Tags, "request_id")
+}
+
+func TestSentryHookTraceID(t *testing.T) {
+	mt := mocktracer.Start()
+	defer mt.Stop()
+
+	config, events := recordingConfig(Config{})
+	hook, err := NewSentryHook(config)
+	assert.NoError(t, err)
+
+	span, ctx := tracer.StartSpanFromContext(context.Background(), "request")
+	defer span.Finish()
+
+	newMockLogger(hook).WithContext(ctx).Errorf("failed")
+
+	assert.Len(t, *events, 1)
+	assert.Equal(t, span.Context().TraceID(), (*events)[0].Tags["trace_id"])
+}
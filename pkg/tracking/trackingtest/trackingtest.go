@@ -0,0 +1,217 @@
+/*
+Package trackingtest provides a Reporter recording the errors captured, to
+assert on them in the tests instead of sending them to Sentry.
+
+	r := trackingtest.New()
+	l, err := sdklogger.NewBuilder(config).SetReporter(r).BuildTestLogger(&buffer)
+
+	handler(ctx)
+
+	r.AssertCaptured(t, "^query failed$", map[string]string{"request_id": "1"})
+
+It's safe for concurrent use.
+*/
+package trackingtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scribd/go-sdk/pkg/tracking"
+)
+
+// Report is an error captured by a Recorder.
+type Report struct {
+	Time time.Time
+	// Err is the error captured. The entries of the logger hook are
+	// captured as a *tracking.EntryError.
+	Err error
+	// Level is the level of the entry of the error, Error for the errors
+	// captured without an entry.
+	Level logrus.Level
+	// Message is the message of the entry of the error, or the message of
+	// the error.
+	Message string
+	// Tags are the tags given and the tags of the context.
+	Tags map[string]string
+}
+
+// Recorder is a tracking.Reporter recording the errors captured.
+type Recorder struct {
+	mu      sync.Mutex
+	reports []Report
+	flushes int
+}
+
+var _ tracking.Reporter = (*Recorder)(nil)
+
+// New returns a Recorder.
+func New() *Recorder {
+	return &Recorder{}
+}
+
+// Capture records the error.
+func (r *Recorder) Capture(ctx context.Context, err error, tags map[string]string) {
+	report := Report{
+		Time:    time.Now(),
+		Err:     err,
+		Level:   logrus.ErrorLevel,
+		Message: err.Error(),
+		Tags:    maps.Clone(tags),
+	}
+
+	var entry *tracking.EntryError
+	if errors.As(err, &entry) {
+		report.Level = entry.Level
+		report.Message = entry.Message
+	}
+
+	if report.Tags == nil {
+		report.Tags = map[string]string{}
+	}
+	if ctx != nil {
+		maps.Copy(report.Tags, tracking.ContextTags(ctx))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reports = append(r.reports, report)
+}
+
+// Flush counts the flushes, see Flushes.
+func (r *Recorder) Flush(time.Duration) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.flushes++
+
+	return true
+}
+
+// Reports returns the errors captured, in the order they were captured.
+func (r *Recorder) Reports() []Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Report(nil), r.reports...)
+}
+
+// Flushes returns the number of calls to Flush.
+func (r *Recorder) Flushes() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.flushes
+}
+
+// Reset forgets the errors captured and the flushes.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reports = nil
+	r.flushes = 0
+}
+
+// Find returns the reports whose message matches the regular expression
+// and whose tags include the tags given.
+func (r *Recorder) Find(msgRegex string, tags map[string]string) []Report {
+	re := regexp.MustCompile(msgRegex)
+
+	var found []Report
+	for _, report := range r.Reports() {
+		if re.MatchString(report.Message) && includes(report.Tags, tags) {
+			found = append(found, report)
+		}
+	}
+
+	return found
+}
+
+// AssertCaptured asserts that an error was captured with a message matching
+// the regular expression and with the tags given, as Find finds it.
+func (r *Recorder) AssertCaptured(t assert.TestingT, msgRegex string, tags map[string]string, msgAndArgs ...any) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	if len(r.Find(msgRegex, tags)) > 0 {
+		return true
+	}
+
+	return assert.Fail(t, fmt.Sprintf("No error captured matching %q with the tags %v, the errors being:\n%s",
+		msgRegex, tags, r.dump()), msgAndArgs...)
+}
+
+// AssertNotCaptured asserts that no error was captured with a message
+// matching the regular expression and with the tags given.
+func (r *Recorder) AssertNotCaptured(t assert.TestingT, msgRegex string, tags map[string]string, msgAndArgs ...any) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	found := r.Find(msgRegex, tags)
+	if len(found) == 0 {
+		return true
+	}
+
+	return assert.Fail(t, fmt.Sprintf("Unexpected error captured matching %q with the tags %v: %q",
+		msgRegex, tags, found[0].Message), msgAndArgs...)
+}
+
+// AssertErrorCaptured asserts that an error matching target, as errors.Is
+// matches it, was captured.
+func (r *Recorder) AssertErrorCaptured(t assert.TestingT, target error, msgAndArgs ...any) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	for _, report := range r.Reports() {
+		if errors.Is(report.Err, target) {
+			return true
+		}
+	}
+
+	return assert.Fail(t, fmt.Sprintf("No error captured matching %q, the errors being:\n%s",
+		target, r.dump()), msgAndArgs...)
+}
+
+// AssertCount asserts that n errors were captured.
+func (r *Recorder) AssertCount(t assert.TestingT, n int, msgAndArgs ...any) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	return assert.Len(t, r.Reports(), n, msgAndArgs...)
+}
+
+// dump returns the reports, one per line, for the failure messages.
+func (r *Recorder) dump() string {
+	var b strings.Builder
+	for _, report := range r.Reports() {
+		fmt.Fprintf(&b, "\t%s %q %v error=%q\n", report.Level, report.Message, report.Tags, report.Err)
+	}
+
+	return b.String()
+}
+
+// includes reports whether the tags include the expected ones.
+func includes(tags, expected map[string]string) bool {
+	for key, want := range expected {
+		if got, ok := tags[key]; !ok || got != want {
+			return false
+		}
+	}
+
+	return true
+}
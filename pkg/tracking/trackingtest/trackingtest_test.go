@@ -0,0 +1,93 @@
+package trackingtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkrequestidcontext "github.com/scribd/go-sdk/pkg/context/requestid"
+	"github.com/scribd/go-sdk/pkg/tracking"
+)
+
+func TestRecorder(t *testing.T) {
+	r := New()
+	ctx := sdkrequestidcontext.ToContext(context.Background(), "request-id")
+	errTimeout := errors.New("timeout")
+
+	r.Capture(ctx, errTimeout, map[string]string{"transport": "sqs"})
+	r.Capture(context.Background(), &tracking.EntryError{
+		Level:   logrus.WarnLevel,
+		Message: "slow query",
+	}, nil)
+	r.Flush(0)
+
+	reports := r.Reports()
+	assert.Len(t, reports, 2)
+	assert.Equal(t, logrus.ErrorLevel, reports[0].Level)
+	assert.Equal(t, "timeout", reports[0].Message)
+	assert.Equal(t, map[string]string{"transport": "sqs", "request_id": "request-id"}, reports[0].Tags)
+	assert.Equal(t, logrus.WarnLevel, reports[1].Level)
+	assert.Equal(t, "slow query", reports[1].Message)
+	assert.Equal(t, 1, r.Flushes())
+
+	assert.Len(t, r.Find("^(timeout|slow query)$", nil), 2)
+	assert.Len(t, r.Find("timeout", map[string]string{"transport": "kafka"}), 0)
+
+	r.AssertCount(t, 2)
+	r.AssertCaptured(t, "^timeout$", map[string]string{"request_id": "request-id"})
+	r.AssertNotCaptured(t, "^timeout$", map[string]string{"request_id": "other"})
+	r.AssertErrorCaptured(t, errTimeout)
+
+	r.Reset()
+	assert.Empty(t, r.Reports())
+	assert.Zero(t, r.Flushes())
+}
+
+// recordingT records the failures of the assertions.
+type recordingT struct {
+	errors []string
+}
+
+func (t *recordingT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestRecorderAssertionsFail(t *testing.T) {
+	r := New()
+	r.Capture(context.Background(), errors.New("timeout"), map[string]string{"transport": "sqs"})
+
+	rt := &recordingT{}
+
+	assert.False(t, r.AssertCaptured(rt, "^not found$", nil))
+	require.Len(t, rt.errors, 1)
+	assert.Contains(t, rt.errors[0], `No error captured matching "^not found$"`)
+	assert.Contains(t, rt.errors[0], `error "timeout" map[transport:sqs]`)
+
+	assert.False(t, r.AssertNotCaptured(rt, "^timeout$", nil))
+	assert.False(t, r.AssertErrorCaptured(rt, errors.New("timeout")))
+	assert.False(t, r.AssertCount(rt, 2))
+	assert.Len(t, rt.errors, 4)
+}
+
+func TestRecorderConcurrency(t *testing.T) {
+	r := New()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.Capture(context.Background(), errors.New("timeout"), nil)
+			_ = r.Reports()
+		}()
+	}
+	wg.Wait()
+
+	r.AssertCount(t, 10)
+}
@@ -333,7 +333,7 @@ func TestSetLogger(t *testing.T) {
 		func(context.Context, *kgo.Record) (response any, err error) {
 			return struct{}{}, nil
 		},
-		PublisherBefore(SetLogger(l)),
+		PublisherBefore(SetRequestID(), SetLogger(l)),
 		PublisherDeliverer(func(ctx context.Context, publisher Publisher, message *kgo.Record) (*kgo.Record, error) {
 			l, ctxErr := sdkloggercontext.Extract(ctx)
 			require.NotNil(t, l)
@@ -359,11 +359,8 @@ func TestSetLogger(t *testing.T) {
 	err = json.Unmarshal(buffer.Bytes(), &fields)
 	require.Nil(t, err)
 
-	assert.NotEmpty(t, fields["pubsub"])
+	assert.NotEmpty(t, fields["request_id"])
 	assert.NotEmpty(t, fields["dd"])
-
-	var pubsub = (fields["pubsub"]).(map[string]any)
-	assert.NotNil(t, pubsub["request_id"])
 }
 
 func testReqEncoder(_ context.Context, m *kgo.Record, request any) error {
@@ -9,7 +9,6 @@ import (
 	sdkloggercontext "github.com/scribd/go-sdk/pkg/context/logger"
 	sdkmetricscontext "github.com/scribd/go-sdk/pkg/context/metrics"
 	sdkrequestidcontext "github.com/scribd/go-sdk/pkg/context/requestid"
-	sdkinstrumentation "github.com/scribd/go-sdk/pkg/instrumentation"
 	sdklogger "github.com/scribd/go-sdk/pkg/logger"
 	sdkmetrics "github.com/scribd/go-sdk/pkg/metrics"
 )
@@ -54,24 +53,7 @@ func SetRequestID() RequestFunc {
 // It will also try to setup context values to the logger fields.
 func SetLogger(l sdklogger.Logger) RequestFunc {
 	return func(ctx context.Context, msg *kgo.Record) context.Context {
-		logContext := sdkinstrumentation.TraceLogs(ctx)
-
-		requestID, err := sdkrequestidcontext.Extract(ctx)
-		if err != nil {
-			l.WithFields(sdklogger.Fields{
-				"error": err.Error(),
-			}).Tracef("Could not retrieve request id from the context")
-		}
-
-		logger := l.WithFields(sdklogger.Fields{
-			"pubsub": sdklogger.Fields{
-				"request_id": requestID,
-			},
-			"dd": sdklogger.Fields{
-				"trace_id": logContext.TraceID,
-				"span_id":  logContext.SpanID,
-			},
-		})
+		logger := sdklogger.WithContext(l, ctx)
 
 		return sdkloggercontext.ToContext(ctx, logger)
 	}
@@ -12,6 +12,7 @@ import (
 
 	kafkasdk "github.com/scribd/go-sdk/pkg/instrumentation/kafka"
 	sdkkafka "github.com/scribd/go-sdk/pkg/pubsub/kafka"
+	"github.com/scribd/go-sdk/pkg/tracking"
 )
 
 // Subscriber wraps an endpoint and provides a handler for kafka messages.
@@ -23,6 +24,7 @@ type Subscriber struct {
 	finalizer    []SubscriberFinalizerFunc
 	errorHandler transport.ErrorHandler
 	errorEncoder ErrorEncoder
+	reporter     tracking.Reporter
 }
 
 // NewSubscriber constructs a new subscriber provides a handler for kafka messages.
@@ -79,6 +81,15 @@ func SubscriberErrorHandler(errorHandler transport.ErrorHandler) SubscriberOptio
 	}
 }
 
+// SubscriberReporter is used to report the errors of the processing of the
+// messages, such as to Sentry, tagged with the transport and the topic of the
+// message. By default, the errors are not reported.
+func SubscriberReporter(reporter tracking.Reporter) SubscriberOption {
+	return func(c *Subscriber) {
+		c.reporter = reporter
+	}
+}
+
 // SubscriberFinalizer is executed at the end of every message processing.
 // By default, no finalizer is registered.
 func SubscriberFinalizer(f ...SubscriberFinalizerFunc) SubscriberOption {
@@ -108,14 +119,14 @@ func (s Subscriber) ServeMsg(h Handler) sdkkafka.MsgHandler {
 		request, err := s.dec(ctx, msg)
 		if err != nil {
 			s.errorEncoder(ctx, err, msg, h)
-			s.errorHandler.Handle(ctx, err)
+			s.handleError(ctx, err, msg)
 			return
 		}
 
 		response, err := s.e(ctx, request)
 		if err != nil {
 			s.errorEncoder(ctx, err, msg, h)
-			s.errorHandler.Handle(ctx, err)
+			s.handleError(ctx, err, msg)
 			return
 		}
 
@@ -125,6 +136,19 @@ func (s Subscriber) ServeMsg(h Handler) sdkkafka.MsgHandler {
 	}
 }
 
+// handleError handles the error with the error handler and reports it with
+// the reporter, if any.
+func (s Subscriber) handleError(ctx context.Context, err error, msg *kgo.Record) {
+	s.errorHandler.Handle(ctx, err)
+
+	if s.reporter != nil {
+		s.reporter.Capture(ctx, err, map[string]string{
+			"transport": "kafka",
+			"topic":     msg.Topic,
+		})
+	}
+}
+
 // SubscriberFinalizerFunc can be used to perform work at the end of message processing,
 // after the response has been constructed. The principal
 // intended use is for request logging.
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/scribd/go-sdk/pkg/tracking/trackingtest"
 )
 
 // TestSubscriberBadDecode checks if decoder errors are handled properly.
@@ -35,6 +37,22 @@ func TestSubscriberBadDecode(t *testing.T) {
 	}
 }
 
+// TestSubscriberReporter checks if endpoint errors are reported.
+func TestSubscriberReporter(t *testing.T) {
+	recorder := trackingtest.New()
+
+	sub := NewSubscriber(
+		func(context.Context, any) (any, error) { return nil, errors.New("err!") },
+		func(context.Context, *kgo.Record) (any, error) { return struct{}{}, nil },
+		SubscriberReporter(recorder),
+	)
+
+	sub.ServeMsg(nil)(&kgo.Record{Topic: "users"})
+
+	recorder.AssertCount(t, 1)
+	recorder.AssertCaptured(t, "^err!$", map[string]string{"transport": "kafka", "topic": "users"})
+}
+
 // TestSubscriberBadEndpoint checks if endpoint errors are handled properly.
 func TestSubscriberBadEndpoint(t *testing.T) {
 	errCh := make(chan error, 1)
@@ -10,8 +10,6 @@ import (
 	sdkdatabasecontext "github.com/scribd/go-sdk/pkg/context/database"
 	sdkloggercontext "github.com/scribd/go-sdk/pkg/context/logger"
 	sdkmetricscontext "github.com/scribd/go-sdk/pkg/context/metrics"
-	sdkrequestidcontext "github.com/scribd/go-sdk/pkg/context/requestid"
-	sdkinstrumentation "github.com/scribd/go-sdk/pkg/instrumentation"
 	sdklogger "github.com/scribd/go-sdk/pkg/logger"
 	sdkmetrics "github.com/scribd/go-sdk/pkg/metrics"
 )
@@ -47,24 +45,7 @@ type PublisherResponseFunc func(
 // It will also try to setup context values to the logger fields.
 func SetPublisherLogger(l sdklogger.Logger) PublisherRequestFunc {
 	return func(ctx context.Context, input *sqs.SendMessageInput) context.Context {
-		logContext := sdkinstrumentation.TraceLogs(ctx)
-
-		requestID, err := sdkrequestidcontext.Extract(ctx)
-		if err != nil {
-			l.WithFields(sdklogger.Fields{
-				"error": err.Error(),
-			}).Tracef("Could not retrieve request id from the context")
-		}
-
-		logger := l.WithFields(sdklogger.Fields{
-			"pubsub": sdklogger.Fields{
-				"request_id": requestID,
-			},
-			"dd": sdklogger.Fields{
-				"trace_id": logContext.TraceID,
-				"span_id":  logContext.SpanID,
-			},
-		})
+		logger := sdklogger.WithContext(l, ctx)
 
 		return sdkloggercontext.ToContext(ctx, logger)
 	}
@@ -74,24 +55,7 @@ func SetPublisherLogger(l sdklogger.Logger) PublisherRequestFunc {
 // It will also try to setup context values to the logger fields.
 func SetSubscriberLogger(l sdklogger.Logger) SubscriberRequestFunc {
 	return func(ctx context.Context, cancel context.CancelFunc, message types.Message) context.Context {
-		logContext := sdkinstrumentation.TraceLogs(ctx)
-
-		requestID, err := sdkrequestidcontext.Extract(ctx)
-		if err != nil {
-			l.WithFields(sdklogger.Fields{
-				"error": err.Error(),
-			}).Tracef("Could not retrieve request id from the context")
-		}
-
-		logger := l.WithFields(sdklogger.Fields{
-			"pubsub": sdklogger.Fields{
-				"request_id": requestID,
-			},
-			"dd": sdklogger.Fields{
-				"trace_id": logContext.TraceID,
-				"span_id":  logContext.SpanID,
-			},
-		})
+		logger := sdklogger.WithContext(l, ctx)
 
 		return sdkloggercontext.ToContext(ctx, logger)
 	}
@@ -133,7 +97,7 @@ func SetSubscriberDatabaseLogging() SubscriberRequestFunc {
 		}
 
 		newDB := db.Session(&gorm.Session{
-			Logger: sdklogger.NewGormLogger(l),
+			Logger: sdklogger.NewGormLoggerFrom(db.Logger, l),
 			NewDB:  true,
 		})
 
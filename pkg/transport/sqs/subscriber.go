@@ -11,6 +11,8 @@ import (
 	"github.com/go-kit/kit/endpoint"
 	"github.com/go-kit/kit/transport"
 	"github.com/go-kit/log"
+
+	"github.com/scribd/go-sdk/pkg/tracking"
 )
 
 type (
@@ -37,6 +39,7 @@ type (
 		errorEncoder ErrorEncoder
 		finalizer    []SubscriberFinalizerFunc
 		errorHandler transport.ErrorHandler
+		reporter     tracking.Reporter
 	}
 )
 
@@ -96,6 +99,13 @@ func SubscriberErrorHandler(errorHandler transport.ErrorHandler) SubscriberOptio
 	return func(s *Subscriber) { s.errorHandler = errorHandler }
 }
 
+// SubscriberReporter is used to report the errors of the processing of the
+// messages, such as to Sentry, tagged with the transport and the URL of the
+// queue. By default, the errors are not reported.
+func SubscriberReporter(reporter tracking.Reporter) SubscriberOption {
+	return func(s *Subscriber) { s.reporter = reporter }
+}
+
 // SubscriberFinalizer is executed once all the received SQS messages are done being processed.
 // By default, no finalizer is registered.
 func SubscriberFinalizer(f ...SubscriberFinalizerFunc) SubscriberOption {
@@ -122,7 +132,7 @@ func SubscriberDeleteMessageBefore() SubscriberOption {
 	return func(s *Subscriber) {
 		deleteBefore := func(ctx context.Context, cancel context.CancelFunc, msg types.Message) context.Context {
 			if err := deleteMessage(ctx, s.sqsClient, s.queueURL, msg); err != nil {
-				s.errorHandler.Handle(ctx, err)
+				s.handleError(ctx, err)
 				s.errorEncoder(ctx, err, msg, s.sqsClient)
 				cancel()
 			}
@@ -139,7 +149,7 @@ func SubscriberDeleteMessageAfter() SubscriberOption {
 		deleteAfter := func(
 			ctx context.Context, cancel context.CancelFunc, msg types.Message, _ any) context.Context {
 			if err := deleteMessage(ctx, s.sqsClient, s.queueURL, msg); err != nil {
-				s.errorHandler.Handle(ctx, err)
+				s.handleError(ctx, err)
 				s.errorEncoder(ctx, err, msg, s.sqsClient)
 				cancel()
 			}
@@ -169,14 +179,14 @@ func (s Subscriber) ServeMessage(ctx context.Context) func(msg types.Message) er
 
 		req, err := s.dec(newCtx, msg)
 		if err != nil {
-			s.errorHandler.Handle(newCtx, err)
+			s.handleError(newCtx, err)
 			s.errorEncoder(newCtx, err, msg, s.sqsClient)
 			return err
 		}
 
 		response, err := s.e(newCtx, req)
 		if err != nil {
-			s.errorHandler.Handle(newCtx, err)
+			s.handleError(newCtx, err)
 			s.errorEncoder(newCtx, err, msg, s.sqsClient)
 			return err
 		}
@@ -215,13 +225,26 @@ func SubscriberNackMessageErrorEncoder() SubscriberOption {
 				VisibilityTimeout: 1,
 			})
 			if sqsErr != nil {
-				s.errorHandler.Handle(ctx, sqsErr)
+				s.handleError(ctx, sqsErr)
 			}
 		}
 		s.errorEncoder = nackErrorHandler
 	}
 }
 
+// handleError handles the error with the error handler and reports it with
+// the reporter, if any.
+func (s Subscriber) handleError(ctx context.Context, err error) {
+	s.errorHandler.Handle(ctx, err)
+
+	if s.reporter != nil {
+		s.reporter.Capture(ctx, err, map[string]string{
+			"transport": "sqs",
+			"queue":     s.queueURL,
+		})
+	}
+}
+
 func deleteMessage(ctx context.Context, sqsClient SQSClient, queueURL string, msg types.Message) error {
 	_, err := sqsClient.DeleteMessage(ctx, &sqs.DeleteMessageInput{
 		QueueUrl:      &queueURL,
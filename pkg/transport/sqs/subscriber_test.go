@@ -11,6 +11,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+
+	"github.com/scribd/go-sdk/pkg/tracking/trackingtest"
 )
 
 const (
@@ -217,6 +219,29 @@ func TestSubscriberBadEndpoint(t *testing.T) {
 	}
 }
 
+// TestSubscriberReporter checks if endpoint errors are reported.
+func TestSubscriberReporter(t *testing.T) {
+	recorder := trackingtest.New()
+	subscriber := NewSubscriber(&mockClient{},
+		func(context.Context, any) (any, error) { return struct{}{}, errors.New(testErrMessage) },
+		func(context.Context, types.Message) (any, error) { return nil, nil },
+		func(context.Context, *sqs.SendMessageInput, any) error { return nil },
+		queueURL,
+		SubscriberReporter(recorder),
+	)
+
+	err := subscriber.ServeMessage(context.Background())(types.Message{
+		Body:      aws.String("MessageBody"),
+		MessageId: aws.String("fakeMsgID"),
+	})
+	if err == nil {
+		t.Errorf("expected error")
+	}
+
+	recorder.AssertCount(t, 1)
+	recorder.AssertCaptured(t, "^"+testErrMessage+"$", map[string]string{"transport": "sqs", "queue": queueURL})
+}
+
 // TestSubscriberSuccess checks if subscriber responds correctly to message.
 func TestSubscriberSuccess(t *testing.T) {
 	obj := testReq{